@@ -0,0 +1,310 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/api/v2/service"
+	gw "github.com/MinterTeam/node-grpc-gateway/api_pb"
+	"github.com/gorilla/handlers"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// extensionRequest is a request to API v2 method which is not described in gRPC gateway
+type extensionRequest struct {
+	*http.Request
+	param string
+	err   error
+}
+
+// pathParam returns the rest of URL path after the method name
+func (r *extensionRequest) pathParam() string {
+	return r.param
+}
+
+func (r *extensionRequest) uint64(name string) uint64 {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0
+	}
+	result, err := strconv.ParseUint(value, 10, 64)
+	if err != nil && r.err == nil {
+		r.err = status.Errorf(codes.InvalidArgument, "invalid %s: %s", name, value)
+	}
+	return result
+}
+
+// decodeBody decodes JSON body of the request to v
+func (r *extensionRequest) decodeBody(v interface{}) {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && r.err == nil {
+		r.err = status.Errorf(codes.InvalidArgument, "invalid request body: %s", err)
+	}
+}
+
+// extensionHandler serves extension method, returned value is encoded to JSON
+type extensionHandler func(ctx context.Context, r *extensionRequest) (interface{}, error)
+
+// extensions returns API v2 methods served as plain JSON over HTTP in addition to gRPC gateway
+func extensions(srv *service.Service) map[string]extensionHandler {
+	return map[string]extensionHandler{
+		"/validator_uptime/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.ValidatorPerformanceRequest{
+				PublicKey:  r.pathParam(),
+				FromHeight: r.uint64("from_height"),
+				ToHeight:   r.uint64("to_height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.ValidatorUptime(ctx, req)
+		},
+		"/validator_history/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.ValidatorPerformanceRequest{
+				PublicKey:  r.pathParam(),
+				FromHeight: r.uint64("from_height"),
+				ToHeight:   r.uint64("to_height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.ValidatorHistory(ctx, req)
+		},
+		"/candidate_stakes/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.CandidateStakesRequest{
+				PublicKey: r.pathParam(),
+				Height:    r.uint64("height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.CandidateStakes(ctx, req)
+		},
+		"/check_status/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.CheckStatusRequest{
+				Check:  r.pathParam(),
+				Height: r.uint64("height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.CheckStatus(ctx, req)
+		},
+		"/multisig_proposals/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			if r.Method == http.MethodPost {
+				req := &service.MultisigProposalRequest{}
+				r.decodeBody(req)
+				if r.err != nil {
+					return nil, r.err
+				}
+				return srv.SubmitMultisigProposal(ctx, req)
+			}
+			return srv.MultisigProposals(ctx, r.pathParam())
+		},
+		"/multisig_proposal/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			if r.Method == http.MethodPost {
+				req := &service.MultisigProposalSignRequest{ID: r.pathParam()}
+				r.decodeBody(req)
+				if r.err != nil {
+					return nil, r.err
+				}
+				return srv.SignMultisigProposal(ctx, req)
+			}
+			return srv.MultisigProposal(ctx, r.pathParam())
+		},
+		"/multisig/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.MultisigInfoRequest{
+				Address: r.pathParam(),
+				Height:  r.uint64("height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.MultisigInfo(ctx, req)
+		},
+		"/allowances/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.AllowancesRequest{
+				Owner:   r.pathParam(),
+				Spender: r.URL.Query().Get("spender"),
+				Height:  r.uint64("height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.Allowances(ctx, req)
+		},
+		"/member_multisigs/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.MultisigInfoRequest{
+				Address: r.pathParam(),
+				Height:  r.uint64("height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.MemberMultisigs(ctx, req)
+		},
+		"/swap_pool_twap/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.SwapPoolTWAPRequest{
+				Pool:       r.pathParam(),
+				FromHeight: r.uint64("from_height"),
+				ToHeight:   r.uint64("to_height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.SwapPoolTWAP(ctx, req)
+		},
+		"/swap_pool_position/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			return srv.SwapPoolPosition(ctx, r.pathParam())
+		},
+		"/swap_estimate/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.SwapEstimateRequest{
+				Route:       r.pathParam(),
+				ValueToSell: r.URL.Query().Get("value_to_sell"),
+				Slippage:    r.URL.Query().Get("slippage"),
+				Height:      r.uint64("height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.SwapEstimate(ctx, req)
+		},
+		"/mining_program/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.MiningProgramRequest{
+				Program: r.pathParam(),
+				Height:  r.uint64("height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.MiningProgram(ctx, req)
+		},
+		"/airdrop/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.AirdropRequest{
+				Airdrop: r.pathParam(),
+				Height:  r.uint64("height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.Airdrop(ctx, req)
+		},
+		"/coin_curve/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.CoinCurveRequest{
+				Coin:         r.pathParam(),
+				Crr:          r.uint64("crr"),
+				Reserve:      r.URL.Query().Get("reserve"),
+				Volume:       r.URL.Query().Get("volume"),
+				Amount:       r.URL.Query().Get("amount"),
+				ReserveDelta: r.URL.Query().Get("reserve_delta"),
+				Points:       r.uint64("points"),
+				Height:       r.uint64("height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.CoinCurve(ctx, req)
+		},
+		"/coin_holders/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.CoinHoldersRequest{
+				Coin:    r.pathParam(),
+				Page:    r.uint64("page"),
+				PerPage: r.uint64("per_page"),
+				Height:  r.uint64("height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.CoinHolders(ctx, req)
+		},
+		"/coin_frozen_addresses/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.CoinFrozenAddressesRequest{
+				Coin:   r.pathParam(),
+				Height: r.uint64("height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.CoinFrozenAddresses(ctx, req)
+		},
+		"/coin_metadata/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			req := &service.CoinMetadataRequest{
+				Coin:   r.pathParam(),
+				Height: r.uint64("height"),
+			}
+			if r.err != nil {
+				return nil, r.err
+			}
+			return srv.CoinMetadata(ctx, req)
+		},
+		"/governance_tally/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			return srv.GovernanceTally(ctx, r.pathParam())
+		},
+		"/order_fills/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			return srv.OrderFills(ctx, r.pathParam())
+		},
+		"/address_order_fills/": func(ctx context.Context, r *extensionRequest) (interface{}, error) {
+			return srv.AddressOrderFills(ctx, r.pathParam())
+		},
+	}
+}
+
+func registerExtensions(mux *http.ServeMux, srv *service.Service) {
+	for pattern, handler := range extensions(srv) {
+		mux.Handle("/v2"+pattern, handlers.CompressHandler(allowCORS(serveExtension(pattern, srv, handler))))
+	}
+}
+
+func serveExtension(pattern string, srv *service.Service, handler extensionHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), srv.TimeoutDuration())
+		defer cancel()
+
+		param := strings.TrimPrefix(r.URL.Path, "/v2"+pattern)
+		result, err := handler(ctx, &extensionRequest{Request: r, param: strings.Trim(param, "/")})
+		if err != nil {
+			extensionError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			grpclog.Infof("Failed to write response: %v", err)
+		}
+	})
+}
+
+func extensionError(w http.ResponseWriter, err error) {
+	s, ok := status.FromError(err)
+	if !ok {
+		s = status.New(codes.Unknown, err.Error())
+	}
+
+	codeString, data := parseStatus(s)
+	delete(data, "code")
+
+	buf, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(&gw.ErrorBody{
+		Error: &gw.ErrorBody_Error{
+			Code:    codeString,
+			Message: s.Message(),
+			Data:    data,
+		},
+	})
+	if err != nil {
+		grpclog.Infof("Failed to marshal error message %q: %v", s, err)
+		http.Error(w, `{"error": {"code": "500", "message": "failed to marshal error message"}}`, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(s.Code()))
+	if _, err := w.Write(buf); err != nil {
+		grpclog.Infof("Failed to write response: %v", err)
+	}
+}
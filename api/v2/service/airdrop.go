@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AirdropRequest is a request of the airdrop, Airdrop is "id" or "id/address"
+type AirdropRequest struct {
+	Airdrop string
+	Height  uint64
+}
+
+// AirdropResponse is the airdrop with its unclaimed remainder.
+// If the address is requested, IsClaimed shows whether the address has already claimed its value.
+type AirdropResponse struct {
+	ID           uint64 `json:"id"`
+	Owner        string `json:"owner"`
+	Coin         *Coin  `json:"coin"`
+	Value        string `json:"value"`
+	MerkleRoot   string `json:"merkle_root"`
+	ExpireHeight uint64 `json:"expire_height"`
+	Claimed      string `json:"claimed"`
+	Remainder    string `json:"remainder"`
+	IsClaimed    *bool  `json:"is_claimed,omitempty"`
+}
+
+// Airdrop returns the airdrop and, if the address is set, whether it is claimed by the address
+func (s *Service) Airdrop(ctx context.Context, req *AirdropRequest) (*AirdropResponse, error) {
+	params := strings.Split(req.Airdrop, "/")
+	if len(params) > 2 {
+		return nil, status.Error(codes.InvalidArgument, "airdrop should be set as id or id/address")
+	}
+	id, err := strconv.ParseUint(params[0], 10, 32)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid airdrop id")
+	}
+	var address *types.Address
+	if len(params) == 2 {
+		if !strings.HasPrefix(strings.Title(params[1]), "Mx") {
+			return nil, status.Error(codes.InvalidArgument, "invalid address")
+		}
+		addr := types.HexToAddress(params[1])
+		address = &addr
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	drop := cState.Airdrops().GetAirdrop(uint32(id))
+	if drop == nil {
+		return nil, status.Error(codes.NotFound, "airdrop not found")
+	}
+
+	response := &AirdropResponse{
+		ID:           id,
+		Owner:        drop.Owner.String(),
+		Coin:         &Coin{ID: uint64(drop.Coin), Symbol: cState.Coins().GetCoin(drop.Coin).GetFullSymbol()},
+		Value:        drop.Value.String(),
+		MerkleRoot:   drop.MerkleRoot.String(),
+		ExpireHeight: drop.ExpireHeight,
+		Claimed:      drop.Claimed.String(),
+		Remainder:    drop.Remainder().String(),
+	}
+
+	if address != nil {
+		isClaimed := cState.Airdrops().IsClaimed(uint32(id), *address)
+		response.IsClaimed = &isClaimed
+	}
+
+	return response, nil
+}
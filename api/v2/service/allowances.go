@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AllowancesRequest is a request of allowances of the owner, Spender is an optional filter
+type AllowancesRequest struct {
+	Owner   string
+	Spender string
+	Height  uint64
+}
+
+// AllowancesResponse is a list of amounts which spenders are allowed to transfer from the owner
+type AllowancesResponse struct {
+	Owner      string       `json:"owner"`
+	Allowances []*Allowance `json:"allowances"`
+}
+
+// Allowance is an amount of the coin which the spender is allowed to transfer
+type Allowance struct {
+	Spender string `json:"spender"`
+	Coin    *Coin  `json:"coin"`
+	Value   string `json:"value"`
+}
+
+// Allowances returns non-zero allowances given by the owner with approve transactions
+func (s *Service) Allowances(ctx context.Context, req *AllowancesRequest) (*AllowancesResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Owner), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+	owner := types.HexToAddress(req.Owner)
+
+	var spender *types.Address
+	if req.Spender != "" {
+		if !strings.HasPrefix(strings.Title(req.Spender), "Mx") {
+			return nil, status.Error(codes.InvalidArgument, "invalid spender address")
+		}
+		address := types.HexToAddress(req.Spender)
+		spender = &address
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	response := &AllowancesResponse{
+		Owner:      owner.String(),
+		Allowances: []*Allowance{},
+	}
+	for _, allowance := range cState.Accounts().GetAllowances(owner) {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return nil, timeoutStatus.Err()
+		}
+
+		if spender != nil && allowance.Spender != *spender {
+			continue
+		}
+
+		response.Allowances = append(response.Allowances, &Allowance{
+			Spender: allowance.Spender.String(),
+			Coin:    &Coin{ID: uint64(allowance.Coin), Symbol: cState.Coins().GetCoin(allowance.Coin).GetFullSymbol()},
+			Value:   allowance.Value.String(),
+		})
+	}
+
+	return response, nil
+}
@@ -201,6 +201,12 @@ func (s *Service) Block(ctx context.Context, req *pb.BlockRequest) (*pb.BlockRes
 					AddLimitOrder:           e.AddLimitOrder,
 					RemoveLimitOrder:        e.RemoveLimitOrder,
 				}
+			case *events.StopOrderActivatedEvent, *events.StopOrderExpiredEvent, *events.OrderFilledEvent, *events.LiquidityChangedEvent, *events.MiningRewardEvent, *events.UpdateParameterEvent, *events.TreasuryProposalEvent, *events.AirdropExpiredEvent:
+				data, err := toStruct(e)
+				if err != nil {
+					return nil, status.Error(codes.Internal, err.Error())
+				}
+				m = data
 			default:
 				return nil, status.Error(codes.Internal, "unknown event type")
 			}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CandidateStakesRequest is a request of candidate stakes composition
+type CandidateStakesRequest struct {
+	PublicKey string
+	Height    uint64
+}
+
+// CandidateStakesResponse is a composition of candidate stakes
+type CandidateStakesResponse struct {
+	PublicKey  string `json:"public_key"`
+	Validator  bool   `json:"validator"`
+	TotalStake string `json:"total_stake"`
+	UsedSlots  uint64 `json:"used_slots"`
+	MaxSlots   uint64 `json:"max_slots"`
+	// MinStake is a bip value which a new stake should exceed when all slots are used
+	MinStake string                         `json:"min_stake"`
+	Coins    []*CandidateStakesResponseCoin `json:"coins"`
+	Kick     *CandidateStakesResponseKick   `json:"kick"`
+	WaitList []*CandidateStakesResponseWait `json:"waitlist"`
+	Entry    *CandidateStakesResponseEntry  `json:"validator_entry"`
+}
+
+type CandidateStakesResponseCoin struct {
+	Coin       *Coin  `json:"coin"`
+	Value      string `json:"value"`
+	BipValue   string `json:"bip_value"`
+	Delegators uint64 `json:"delegators"`
+	Share      string `json:"share"`
+}
+
+// CandidateStakesResponseKick is a part of stakes which would be moved to waitlist
+// if the waitlisted stakes of the candidate were delegated again
+type CandidateStakesResponseKick struct {
+	Stakes   uint64 `json:"stakes"`
+	BipValue string `json:"bip_value"`
+	Share    string `json:"share"`
+}
+
+type CandidateStakesResponseWait struct {
+	Owner    string `json:"owner"`
+	Coin     *Coin  `json:"coin"`
+	Value    string `json:"value"`
+	BipValue string `json:"bip_value"`
+}
+
+// CandidateStakesResponseEntry is a stake required to enter the validators set
+type CandidateStakesResponseEntry struct {
+	MinStake  string `json:"min_stake"`
+	Shortfall string `json:"shortfall"`
+}
+
+// CandidateStakes returns candidate stakes grouped by coins, the stakes which would be kicked by the waitlisted ones,
+// waitlist of the candidate and the stake required to enter the validators set.
+func (s *Service) CandidateStakes(ctx context.Context, req *CandidateStakesRequest) (*CandidateStakesResponse, error) {
+	if !strings.HasPrefix(req.PublicKey, "Mp") {
+		return nil, status.Error(codes.InvalidArgument, "invalid public_key")
+	}
+	pubkey := types.HexToPubkey(req.PublicKey)
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if req.Height != 0 {
+		cState.Candidates().LoadCandidates()
+		cState.Validators().LoadValidators()
+		cState.Candidates().LoadStakes()
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	candidate := cState.Candidates().GetCandidate(pubkey)
+	if candidate == nil {
+		return nil, status.Error(codes.NotFound, "Candidate not found")
+	}
+
+	totalStake := cState.Candidates().GetTotalStake(pubkey)
+	stakes := cState.Candidates().GetStakes(pubkey)
+	response := &CandidateStakesResponse{
+		PublicKey:  pubkey.String(),
+		Validator:  cState.Validators().GetByPublicKey(pubkey) != nil,
+		TotalStake: totalStake.String(),
+		UsedSlots:  uint64(len(stakes)),
+		MaxSlots:   candidates.MaxDelegatorsPerCandidate,
+		MinStake:   "0",
+		Coins:      []*CandidateStakesResponseCoin{},
+		WaitList:   []*CandidateStakesResponseWait{},
+	}
+
+	var coinIDs []types.CoinID
+	byCoin := map[types.CoinID]*CandidateStakesResponseCoin{}
+	values := map[types.CoinID][2]*big.Int{}
+	stakeValues := make([]*big.Int, 0, len(stakes))
+	for _, stake := range stakes {
+		stakeValues = append(stakeValues, stake.BipValue)
+		if _, ok := byCoin[stake.Coin]; !ok {
+			coinIDs = append(coinIDs, stake.Coin)
+			byCoin[stake.Coin] = &CandidateStakesResponseCoin{Coin: coinResponse(cState, stake.Coin)}
+			values[stake.Coin] = [2]*big.Int{big.NewInt(0), big.NewInt(0)}
+		}
+		byCoin[stake.Coin].Delegators++
+		values[stake.Coin][0].Add(values[stake.Coin][0], stake.Value)
+		values[stake.Coin][1].Add(values[stake.Coin][1], stake.BipValue)
+	}
+
+	sort.SliceStable(coinIDs, func(i, j int) bool {
+		return values[coinIDs[i]][1].Cmp(values[coinIDs[j]][1]) == 1
+	})
+	for _, id := range coinIDs {
+		coin := byCoin[id]
+		coin.Value = values[id][0].String()
+		coin.BipValue = values[id][1].String()
+		coin.Share = percent(values[id][1], totalStake)
+		response.Coins = append(response.Coins, coin)
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	var waitValues []*big.Int
+	for _, item := range cState.WaitList().GetByPubKey(pubkey) {
+		bipValue := cState.Candidates().BipValue(item.Coin, item.Value)
+		waitValues = append(waitValues, bipValue)
+		response.WaitList = append(response.WaitList, &CandidateStakesResponseWait{
+			Owner:    item.Owner.String(),
+			Coin:     coinResponse(cState, item.Coin),
+			Value:    item.Value.String(),
+			BipValue: bipValue.String(),
+		})
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	sort.SliceStable(stakeValues, func(i, j int) bool {
+		return stakeValues[i].Cmp(stakeValues[j]) == -1
+	})
+	sort.SliceStable(waitValues, func(i, j int) bool {
+		return waitValues[i].Cmp(waitValues[j]) == 1
+	})
+
+	kicked := big.NewInt(0)
+	response.Kick = &CandidateStakesResponseKick{}
+	if len(stakeValues) >= candidates.MaxDelegatorsPerCandidate {
+		response.MinStake = stakeValues[0].String()
+		// every waitlisted stake which is greater than the smallest remaining stake
+		// passes IsDelegatorStakeSufficient and displaces it
+		for i := 0; i < len(waitValues) && i < len(stakeValues); i++ {
+			if waitValues[i].Cmp(stakeValues[i]) != 1 {
+				break
+			}
+			kicked.Add(kicked, stakeValues[i])
+			response.Kick.Stakes++
+		}
+	}
+	response.Kick.BipValue = kicked.String()
+	response.Kick.Share = percent(kicked, totalStake)
+
+	entryStake := cState.Candidates().ValidatorStakeThreshold(cState.Params().GetValidatorsCount(s.blockchain.Height()))
+	shortfall := big.NewInt(0)
+	if !response.Validator && totalStake.Cmp(entryStake) != 1 {
+		shortfall.Sub(entryStake, totalStake)
+	}
+	response.Entry = &CandidateStakesResponseEntry{
+		MinStake:  entryStake.String(),
+		Shortfall: shortfall.String(),
+	}
+
+	return response, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/check"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Statuses of a check
+const (
+	CheckStatusValid   = "valid"
+	CheckStatusUsed    = "used"
+	CheckStatusRevoked = "revoked"
+	CheckStatusExpired = "expired"
+)
+
+// CheckStatusRequest is a request of status of the raw check
+type CheckStatusRequest struct {
+	Check  string
+	Height uint64
+}
+
+// CheckStatusResponse is a decoded check with its status
+type CheckStatusResponse struct {
+	Hash     string `json:"hash"`
+	Issuer   string `json:"issuer"`
+	Nonce    string `json:"nonce"`
+	ChainID  uint64 `json:"chain_id"`
+	DueBlock uint64 `json:"due_block"`
+	Coin     *Coin  `json:"coin"`
+	Value    string `json:"value"`
+	GasCoin  *Coin  `json:"gas_coin"`
+	Status   string `json:"status"`
+}
+
+// CheckStatus decodes the check and returns its status: valid, used, revoked or expired.
+func (s *Service) CheckStatus(ctx context.Context, req *CheckStatusRequest) (*CheckStatusResponse, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.Title(req.Check), "Mc"))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid check")
+	}
+
+	decodedCheck, err := check.DecodeFromBytes(raw)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	issuer, err := decodedCheck.Sender()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	checkStatus := CheckStatusValid
+	switch {
+	case cState.Checks().IsCheckUsed(decodedCheck):
+		checkStatus = CheckStatusUsed
+	case cState.Checks().IsCheckRevoked(issuer, decodedCheck):
+		checkStatus = CheckStatusRevoked
+	case decodedCheck.DueBlock <= height:
+		checkStatus = CheckStatusExpired
+	}
+
+	return &CheckStatusResponse{
+		Hash:     decodedCheck.Hash().String(),
+		Issuer:   issuer.String(),
+		Nonce:    hex.EncodeToString(decodedCheck.Nonce),
+		ChainID:  uint64(decodedCheck.ChainID),
+		DueBlock: decodedCheck.DueBlock,
+		Coin:     coinResponse(cState, decodedCheck.Coin),
+		Value:    decodedCheck.Value.String(),
+		GasCoin:  coinResponse(cState, decodedCheck.GasCoin),
+		Status:   checkStatus,
+	}, nil
+}
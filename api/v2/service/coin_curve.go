@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/formula"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultCoinCurvePoints is the number of points of the bonding curve if it is not requested
+	defaultCoinCurvePoints = 10
+	// maxCoinCurvePoints is the maximum number of points of the bonding curve
+	maxCoinCurvePoints = 100
+)
+
+// CoinCurveRequest is a request of the bonding curve analytics.
+// Coin is an optional coin id, Crr, Reserve and Volume override parameters of the coin and are required without it.
+// Amount is a number of coins to buy and sell, ReserveDelta is a signed change of the reserve, all values are in pip.
+type CoinCurveRequest struct {
+	Coin         string
+	Crr          uint64
+	Reserve      string
+	Volume       string
+	Amount       string
+	ReserveDelta string
+	Points       uint64
+	Height       uint64
+}
+
+// CoinCurveResponse is the bonding curve of the reserve-backed coin. Prices are in base coins per coin.
+// BuyPrice and SellPrice are the cost of buying and the return of selling one coin.
+type CoinCurveResponse struct {
+	Coin          *Coin                   `json:"coin,omitempty"`
+	Crr           uint64                  `json:"crr"`
+	Reserve       string                  `json:"reserve"`
+	Volume        string                  `json:"volume"`
+	MaxSupply     string                  `json:"max_supply,omitempty"`
+	SpotPrice     string                  `json:"spot_price"`
+	BuyPrice      string                  `json:"buy_price"`
+	SellPrice     string                  `json:"sell_price"`
+	Buy           *CoinCurveTrade         `json:"buy,omitempty"`
+	Sell          *CoinCurveTrade         `json:"sell,omitempty"`
+	ReserveChange *CoinCurveReserveChange `json:"reserve_change,omitempty"`
+	Points        []*CoinCurvePoint       `json:"points"`
+}
+
+// CoinCurveTrade is the buy or sell of Value coins by the curve, Reserve is the cost of the buy or the return of the sell
+type CoinCurveTrade struct {
+	Value        string `json:"value"`
+	Reserve      string `json:"reserve"`
+	AveragePrice string `json:"average_price"`
+	PriceAfter   string `json:"price_after"`
+	PriceImpact  string `json:"price_impact"`
+}
+
+// CoinCurveReserveChange is the state of the coin after the reserve is changed by the buy or sell of Volume coins
+type CoinCurveReserveChange struct {
+	ReserveDelta string `json:"reserve_delta"`
+	VolumeDelta  string `json:"volume_delta"`
+	Reserve      string `json:"reserve"`
+	Volume       string `json:"volume"`
+	PriceAfter   string `json:"price_after"`
+	PriceImpact  string `json:"price_impact"`
+}
+
+// CoinCurvePoint is the reserve and the spot price of the coin at the volume
+type CoinCurvePoint struct {
+	Volume  string `json:"volume"`
+	Reserve string `json:"reserve"`
+	Price   string `json:"price"`
+}
+
+// CoinCurve returns points of the bonding curve of the coin from zero to the double of its volume,
+// marginal prices and estimates of the buy, sell and reserve change. Commissions are not included.
+func (s *Service) CoinCurve(ctx context.Context, req *CoinCurveRequest) (*CoinCurveResponse, error) {
+	response := &CoinCurveResponse{Points: []*CoinCurvePoint{}}
+
+	var reserve, volume *big.Int
+	crr := req.Crr
+	if req.Coin != "" {
+		id, err := strconv.ParseUint(req.Coin, 10, 32)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid coin id")
+		}
+
+		cState, err := s.blockchain.GetStateForHeight(req.Height)
+		if err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		coin := cState.Coins().GetCoin(types.CoinID(id))
+		if coin == nil {
+			return nil, status.Error(codes.NotFound, "Coin not found")
+		}
+		if coin.ID().IsBaseCoin() || !coin.BaseOrHasReserve() {
+			return nil, status.Error(codes.FailedPrecondition, "coin has no reserve")
+		}
+
+		response.Coin = &Coin{ID: uint64(coin.ID()), Symbol: coin.GetFullSymbol()}
+		response.MaxSupply = coin.MaxSupply().String()
+		reserve, volume = coin.Reserve(), coin.Volume()
+		if crr == 0 {
+			crr = uint64(coin.Crr())
+		}
+	}
+
+	var err error
+	if reserve, err = curveValue("reserve", req.Reserve, reserve); err != nil {
+		return nil, err
+	}
+	if volume, err = curveValue("volume", req.Volume, volume); err != nil {
+		return nil, err
+	}
+	if crr < 10 || crr > 100 {
+		return nil, status.Error(codes.InvalidArgument, "crr should be between 10 and 100")
+	}
+
+	points := req.Points
+	if points == 0 {
+		points = defaultCoinCurvePoints
+	}
+	if points > maxCoinCurvePoints {
+		return nil, status.Errorf(codes.OutOfRange, "maximum number of points is %d", maxCoinCurvePoints)
+	}
+
+	spotPrice := curveSpotPrice(reserve, volume, crr)
+	oneCoin := helpers.BipToPip(big.NewInt(1))
+	response.Crr = crr
+	response.Reserve = reserve.String()
+	response.Volume = volume.String()
+	response.SpotPrice = spotPrice.FloatString(precision)
+	response.BuyPrice = new(big.Rat).SetFrac(formula.CalculatePurchaseAmount(volume, reserve, uint32(crr), oneCoin), oneCoin).FloatString(precision)
+	if volume.Cmp(oneCoin) != -1 {
+		response.SellPrice = new(big.Rat).SetFrac(formula.CalculateSaleReturn(volume, reserve, uint32(crr), oneCoin), oneCoin).FloatString(precision)
+	} else {
+		response.SellPrice = new(big.Rat).SetFrac(reserve, oneCoin).FloatString(precision)
+	}
+
+	if req.Amount != "" {
+		amount, ok := new(big.Int).SetString(req.Amount, 10)
+		if !ok || amount.Sign() != 1 {
+			return nil, status.Error(codes.InvalidArgument, "amount should be a positive integer")
+		}
+
+		cost := formula.CalculatePurchaseAmount(volume, reserve, uint32(crr), amount)
+		response.Buy = curveTrade(amount, cost, spotPrice, new(big.Int).Add(reserve, cost), new(big.Int).Add(volume, amount), crr)
+
+		if amount.Cmp(volume) != 1 {
+			ret := formula.CalculateSaleReturn(volume, reserve, uint32(crr), amount)
+			response.Sell = curveTrade(amount, ret, spotPrice, new(big.Int).Sub(reserve, ret), new(big.Int).Sub(volume, amount), crr)
+		}
+	}
+
+	if req.ReserveDelta != "" {
+		delta, ok := new(big.Int).SetString(req.ReserveDelta, 10)
+		if !ok || delta.Sign() == 0 {
+			return nil, status.Error(codes.InvalidArgument, "reserve_delta should be a non-zero integer")
+		}
+		if new(big.Int).Neg(delta).Cmp(reserve) != -1 {
+			return nil, status.Errorf(codes.OutOfRange, "coin has reserve %s, you wanted withdraw %s", reserve, new(big.Int).Neg(delta))
+		}
+
+		var volumeDelta *big.Int
+		if delta.Sign() == 1 {
+			volumeDelta = formula.CalculatePurchaseReturn(volume, reserve, uint32(crr), delta)
+		} else {
+			volumeDelta = new(big.Int).Neg(formula.CalculateSaleAmount(volume, reserve, uint32(crr), new(big.Int).Neg(delta)))
+		}
+		reserveAfter, volumeAfter := new(big.Int).Add(reserve, delta), new(big.Int).Add(volume, volumeDelta)
+		if volumeAfter.Sign() != 1 {
+			return nil, status.Errorf(codes.OutOfRange, "coin has volume %s, you wanted burn %s", volume, new(big.Int).Neg(volumeDelta))
+		}
+		priceAfter := curveSpotPrice(reserveAfter, volumeAfter, crr)
+		response.ReserveChange = &CoinCurveReserveChange{
+			ReserveDelta: delta.String(),
+			VolumeDelta:  volumeDelta.String(),
+			Reserve:      reserveAfter.String(),
+			Volume:       volumeAfter.String(),
+			PriceAfter:   priceAfter.FloatString(precision),
+			PriceImpact:  curvePriceImpact(spotPrice, priceAfter).FloatString(precision),
+		}
+	}
+
+	for i := uint64(1); i <= points; i++ {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return nil, timeoutStatus.Err()
+		}
+
+		pointVolume := new(big.Int).Mul(volume, new(big.Int).SetUint64(2*i))
+		pointVolume.Quo(pointVolume, new(big.Int).SetUint64(points))
+
+		var pointReserve *big.Int
+		switch pointVolume.Cmp(volume) {
+		case -1:
+			pointReserve = new(big.Int).Sub(reserve, formula.CalculateSaleReturn(volume, reserve, uint32(crr), new(big.Int).Sub(volume, pointVolume)))
+		case 1:
+			pointReserve = new(big.Int).Add(reserve, formula.CalculatePurchaseAmount(volume, reserve, uint32(crr), new(big.Int).Sub(pointVolume, volume)))
+		default:
+			pointReserve = new(big.Int).Set(reserve)
+		}
+
+		response.Points = append(response.Points, &CoinCurvePoint{
+			Volume:  pointVolume.String(),
+			Reserve: pointReserve.String(),
+			Price:   curveSpotPrice(pointReserve, pointVolume, crr).FloatString(precision),
+		})
+	}
+
+	return response, nil
+}
+
+// curveValue returns the requested value of the coin parameter or the current one
+func curveValue(name, requested string, current *big.Int) (*big.Int, error) {
+	if requested == "" {
+		if current == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s should be set without the coin", name)
+		}
+		return current, nil
+	}
+
+	value, ok := new(big.Int).SetString(requested, 10)
+	if !ok || value.Sign() != 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "%s should be a positive integer", name)
+	}
+	return value, nil
+}
+
+// curveSpotPrice returns the marginal price of the coin, reserve / (volume * crr / 100)
+func curveSpotPrice(reserve, volume *big.Int, crr uint64) *big.Rat {
+	return new(big.Rat).SetFrac(
+		new(big.Int).Mul(reserve, big.NewInt(100)),
+		new(big.Int).Mul(volume, new(big.Int).SetUint64(crr)),
+	)
+}
+
+// curvePriceImpact returns the deviation of the price from the spot price in percent
+func curvePriceImpact(spotPrice, price *big.Rat) *big.Rat {
+	impact := new(big.Rat).Sub(price, spotPrice)
+	impact.Abs(impact).Quo(impact, spotPrice)
+	return impact.Mul(impact, big.NewRat(100, 1))
+}
+
+// curveTrade returns the buy or sell of value coins for the reserve which moves the coin to reserveAfter and volumeAfter
+func curveTrade(value, reserve *big.Int, spotPrice *big.Rat, reserveAfter, volumeAfter *big.Int, crr uint64) *CoinCurveTrade {
+	trade := &CoinCurveTrade{
+		Value:        value.String(),
+		Reserve:      reserve.String(),
+		AveragePrice: new(big.Rat).SetFrac(reserve, value).FloatString(precision),
+		PriceAfter:   "0",
+		PriceImpact:  curvePriceImpact(spotPrice, new(big.Rat).SetFrac(reserve, value)).FloatString(precision),
+	}
+	if volumeAfter.Sign() == 1 {
+		trade.PriceAfter = curveSpotPrice(reserveAfter, volumeAfter, crr).FloatString(precision)
+	}
+	return trade
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CoinFrozenAddressesRequest is a request of addresses frozen by the owner of the token, Coin is the coin id
+type CoinFrozenAddressesRequest struct {
+	Coin   string
+	Height uint64
+}
+
+// CoinFrozenAddressesResponse is a list of addresses which are not allowed to transfer the freezable token
+type CoinFrozenAddressesResponse struct {
+	Coin      *Coin    `json:"coin"`
+	Freezable bool     `json:"freezable"`
+	Addresses []string `json:"addresses"`
+}
+
+// CoinFrozenAddresses returns addresses frozen for the token with FreezeAddress transactions
+func (s *Service) CoinFrozenAddresses(ctx context.Context, req *CoinFrozenAddressesRequest) (*CoinFrozenAddressesResponse, error) {
+	id, err := strconv.ParseUint(req.Coin, 10, 32)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid coin id")
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	coin := cState.Coins().GetCoin(types.CoinID(id))
+	if coin == nil {
+		return nil, status.Error(codes.NotFound, "Coin not found")
+	}
+
+	response := &CoinFrozenAddressesResponse{
+		Coin:      &Coin{ID: uint64(coin.ID()), Symbol: coin.GetFullSymbol()},
+		Freezable: coin.IsFreezable(),
+		Addresses: []string{},
+	}
+	for _, address := range cState.Coins().GetFrozenAddresses(coin.ID()) {
+		response.Addresses = append(response.Addresses, address.String())
+	}
+
+	return response, nil
+}
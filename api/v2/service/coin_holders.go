@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultCoinHoldersPerPage is the number of holders on the page if it is not requested
+	defaultCoinHoldersPerPage = 50
+	// maxCoinHoldersPerPage is the maximum number of holders on the page
+	maxCoinHoldersPerPage = 500
+)
+
+// coinHoldersTops are numbers of the largest holders in the supply distribution
+var coinHoldersTops = []uint64{10, 100, 1000}
+
+// CoinHoldersRequest is a request of holders of the coin, Page starts from 1
+type CoinHoldersRequest struct {
+	Coin    string
+	Page    uint64
+	PerPage uint64
+	Height  uint64
+}
+
+// CoinHoldersResponse is a page of holders of the coin sorted by balances with the distribution of the supply held by accounts
+type CoinHoldersResponse struct {
+	Coin         *Coin                `json:"coin"`
+	HoldersCount uint64               `json:"holders_count"`
+	Held         string               `json:"held"`
+	Distribution []*CoinHoldersTop    `json:"distribution"`
+	Holders      []*CoinHolderBalance `json:"holders"`
+}
+
+// CoinHoldersTop is the balance and the share of the held supply of Top largest holders
+type CoinHoldersTop struct {
+	Top   uint64 `json:"top"`
+	Value string `json:"value"`
+	Share string `json:"share"`
+}
+
+// CoinHolderBalance is the balance of the holder with its share of the held supply and its place
+type CoinHolderBalance struct {
+	Rank    uint64 `json:"rank"`
+	Address string `json:"address"`
+	Value   string `json:"value"`
+	Share   string `json:"share"`
+}
+
+// CoinHolders returns addresses with positive balance of the coin sorted by balances.
+// Balances in liquidity pools, orders, stakes and frozen funds are not counted.
+func (s *Service) CoinHolders(ctx context.Context, req *CoinHoldersRequest) (*CoinHoldersResponse, error) {
+	id, err := strconv.ParseUint(req.Coin, 10, 32)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid coin id")
+	}
+
+	page, perPage := req.Page, req.PerPage
+	if page == 0 {
+		page = 1
+	}
+	if perPage == 0 {
+		perPage = defaultCoinHoldersPerPage
+	}
+	if perPage > maxCoinHoldersPerPage {
+		return nil, status.Errorf(codes.OutOfRange, "maximum number of holders on the page is %d", maxCoinHoldersPerPage)
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	coin := cState.Coins().GetCoin(types.CoinID(id))
+	if coin == nil {
+		return nil, status.Error(codes.NotFound, "Coin not found")
+	}
+
+	response := &CoinHoldersResponse{
+		Coin:         &Coin{ID: uint64(coin.ID()), Symbol: coin.GetFullSymbol()},
+		HoldersCount: cState.Accounts().GetHoldersCount(coin.ID()),
+		Holders:      []*CoinHolderBalance{},
+	}
+
+	held := big.NewInt(0)
+	tops := make([]*big.Int, len(coinHoldersTops))
+	from, to := (page-1)*perPage, page*perPage
+	var balances []*big.Int
+	var rank uint64
+	var timeoutErr error
+	cState.Accounts().IterateHolders(coin.ID(), func(address types.Address, balance *big.Int) bool {
+		if rank%1000 == 0 {
+			if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+				timeoutErr = timeoutStatus.Err()
+				return true
+			}
+		}
+
+		held.Add(held, balance)
+		rank++
+		for i, top := range coinHoldersTops {
+			if rank == top {
+				tops[i] = big.NewInt(0).Set(held)
+			}
+		}
+		if rank > from && rank <= to {
+			balances = append(balances, balance)
+			response.Holders = append(response.Holders, &CoinHolderBalance{
+				Rank:    rank,
+				Address: address.String(),
+				Value:   balance.String(),
+			})
+		}
+		return false
+	})
+	if timeoutErr != nil {
+		return nil, timeoutErr
+	}
+
+	response.Held = held.String()
+	for i, holder := range response.Holders {
+		holder.Share = holdersShare(balances[i], held)
+	}
+	for i, top := range coinHoldersTops {
+		value := tops[i]
+		if value == nil {
+			value = held
+		}
+		response.Distribution = append(response.Distribution, &CoinHoldersTop{
+			Top:   top,
+			Value: value.String(),
+			Share: holdersShare(value, held),
+		})
+	}
+
+	return response, nil
+}
+
+// holdersShare returns the share of the value in the held supply in percent
+func holdersShare(value, held *big.Int) string {
+	if held.Sign() == 0 {
+		return "0"
+	}
+	share := new(big.Rat).SetFrac(value, held)
+	return share.Mul(share, big.NewRat(100, 1)).FloatString(precision)
+}
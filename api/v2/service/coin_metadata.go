@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CoinMetadataRequest is a request of the metadata of the coin, Coin is the coin id
+type CoinMetadataRequest struct {
+	Coin   string
+	Height uint64
+}
+
+// CoinMetadataResponse is the metadata attached to the coin by its owner.
+// It complements CoinInfo, which response is defined by the gRPC gateway.
+type CoinMetadataResponse struct {
+	Coin         *Coin  `json:"coin"`
+	OwnerAddress string `json:"owner_address,omitempty"`
+	Description  string `json:"description"`
+	URL          string `json:"url"`
+	LogoHash     string `json:"logo_hash"`
+}
+
+// CoinMetadata returns the description, the website URL and the hash of the logo of the coin, they are empty if the owner has not set them
+func (s *Service) CoinMetadata(ctx context.Context, req *CoinMetadataRequest) (*CoinMetadataResponse, error) {
+	id, err := strconv.ParseUint(req.Coin, 10, 32)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid coin id")
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	coin := cState.Coins().GetCoin(types.CoinID(id))
+	if coin == nil {
+		return nil, s.createError(status.New(codes.NotFound, "Coin not found"), transaction.EncodeError(code.NewCoinNotExists("", strconv.Itoa(int(id)))))
+	}
+
+	response := &CoinMetadataResponse{
+		Coin: &Coin{ID: uint64(coin.ID()), Symbol: coin.GetFullSymbol()},
+	}
+	if metadata := coin.Metadata(); metadata != nil {
+		response.Description = metadata.Description
+		response.URL = metadata.URL
+		response.LogoHash = metadata.LogoHash.String()
+	}
+	if info := cState.Coins().GetSymbolInfo(coin.Symbol()); info != nil && info.OwnerAddress() != nil {
+		response.OwnerAddress = info.OwnerAddress().String()
+	}
+
+	return response, nil
+}
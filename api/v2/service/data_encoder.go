@@ -2,11 +2,13 @@ package service
 
 import (
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
 	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
 	pb "github.com/MinterTeam/node-grpc-gateway/api_pb"
 	"github.com/golang/protobuf/ptypes/any"
 	"google.golang.org/protobuf/proto"
@@ -140,7 +142,7 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 			MaxSupply:            d.MaxSupply.String(),
 		}
 	case transaction.TypeRedeemCheck:
-		d := data.(*transaction.RedeemCheckData)
+		d := data.(*transaction.RedeemCheckDataV340)
 		m = &pb.RedeemCheckData{
 			RawCheck: base64.StdEncoding.EncodeToString(d.RawCheck),
 			Proof:    base64.StdEncoding.EncodeToString(d.Proof[:]),
@@ -323,7 +325,7 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 			Commission: uint64(d.Commission),
 		}
 	case transaction.TypeVoteCommission:
-		d := data.(*transaction.VoteCommissionDataV3)
+		d := (*transaction.VoteCommissionDataV3)(data.(*transaction.VoteCommissionDataV340))
 		m = priceCommissionData(d, rCoins.GetCoin(d.Coin))
 	case transaction.TypeVoteUpdate:
 		d := data.(*transaction.VoteUpdateDataV230)
@@ -333,7 +335,7 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 			Version: d.Version,
 		}
 	case transaction.TypeCreateSwapPool:
-		d := data.(*transaction.CreateSwapPoolData)
+		d := data.(*transaction.CreateSwapPoolDataV340)
 		m = &pb.CreateSwapPoolData{
 			Coin0: &pb.Coin{
 				Id:     uint64(d.Coin0),
@@ -360,6 +362,214 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 			ValueToBuy:  d.ValueToBuy.String(),
 			ValueToSell: d.ValueToSell.String(),
 		}
+	case transaction.TypeAddLimitOrderV2:
+		d := data.(*transaction.AddLimitOrderDataV2)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin_to_buy":   &Coin{ID: uint64(d.CoinToBuy), Symbol: rCoins.GetCoin(d.CoinToBuy).GetFullSymbol()},
+			"coin_to_sell":  &Coin{ID: uint64(d.CoinToSell), Symbol: rCoins.GetCoin(d.CoinToSell).GetFullSymbol()},
+			"value_to_buy":  d.ValueToBuy.String(),
+			"value_to_sell": d.ValueToSell.String(),
+			"expire_height": d.ExpireHeight,
+			"flags":         d.Flags,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeAddStopOrder:
+		d := data.(*transaction.AddStopOrderData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin_to_sell":         &Coin{ID: uint64(d.CoinToSell), Symbol: rCoins.GetCoin(d.CoinToSell).GetFullSymbol()},
+			"value_to_sell":        d.ValueToSell.String(),
+			"coin_to_buy":          &Coin{ID: uint64(d.CoinToBuy), Symbol: rCoins.GetCoin(d.CoinToBuy).GetFullSymbol()},
+			"trigger_value":        d.TriggerValue.String(),
+			"minimum_value_to_buy": d.MinimumValueToBuy.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeRemoveStopOrder:
+		d := data.(*transaction.RemoveStopOrderData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"id": d.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCreateMiningProgram:
+		d := data.(*transaction.CreateMiningProgramData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"liquidity_coin": &Coin{ID: uint64(d.LiquidityCoin), Symbol: rCoins.GetCoin(d.LiquidityCoin).GetFullSymbol()},
+			"reward_coin":    &Coin{ID: uint64(d.RewardCoin), Symbol: rCoins.GetCoin(d.RewardCoin).GetFullSymbol()},
+			"value":          d.Value.String(),
+			"duration":       d.Duration,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeStakeMining:
+		d := data.(*transaction.StakeMiningData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"program_id": d.ProgramID,
+			"value":      d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeUnstakeMining:
+		d := data.(*transaction.UnstakeMiningData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"program_id": d.ProgramID,
+			"value":      d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeClaimMiningReward:
+		d := data.(*transaction.ClaimMiningRewardData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"program_id": d.ProgramID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeVoteParameter:
+		d := data.(*transaction.VoteParameterData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"pub_key":   d.PubKey.String(),
+			"height":    d.Height,
+			"parameter": d.Parameter.String(),
+			"value":     d.Value,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCreateTreasuryProposal:
+		d := data.(*transaction.CreateTreasuryProposalData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"recipient":        d.Recipient.String(),
+			"coin":             &Coin{ID: uint64(d.Coin), Symbol: rCoins.GetCoin(d.Coin).GetFullSymbol()},
+			"value":            d.Value.String(),
+			"description_hash": d.DescriptionHash.String(),
+			"height":           d.Height,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeVoteTreasuryProposal:
+		d := data.(*transaction.VoteTreasuryProposalData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"proposal_id": d.ProposalID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeSetCoinMetadata:
+		d := data.(*transaction.SetCoinMetadataData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin":        &Coin{ID: uint64(d.Coin), Symbol: rCoins.GetCoin(d.Coin).GetFullSymbol()},
+			"description": d.Description,
+			"url":         d.URL,
+			"logo_hash":   d.LogoHash.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeApprove:
+		d := data.(*transaction.ApproveData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"spender": d.Spender.String(),
+			"coin":    &Coin{ID: uint64(d.Coin), Symbol: rCoins.GetCoin(d.Coin).GetFullSymbol()},
+			"value":   d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeTransferFrom:
+		d := data.(*transaction.TransferFromData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"from":  d.From.String(),
+			"to":    d.To.String(),
+			"coin":  &Coin{ID: uint64(d.Coin), Symbol: rCoins.GetCoin(d.Coin).GetFullSymbol()},
+			"value": d.Value.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCreateTokenV2:
+		d := data.(*transaction.CreateTokenDataV2)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"name":           d.Name,
+			"symbol":         d.Symbol.String(),
+			"initial_amount": d.InitialAmount.String(),
+			"max_supply":     d.MaxSupply.String(),
+			"mintable":       d.Mintable,
+			"burnable":       d.Burnable,
+			"freezable":      d.Freezable,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeFreezeAddress:
+		d := data.(*transaction.FreezeAddressData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin":    &Coin{ID: uint64(d.Coin), Symbol: rCoins.GetCoin(d.Coin).GetFullSymbol()},
+			"address": d.Address.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeUnfreezeAddress:
+		d := data.(*transaction.UnfreezeAddressData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin":    &Coin{ID: uint64(d.Coin), Symbol: rCoins.GetCoin(d.Coin).GetFullSymbol()},
+			"address": d.Address.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeCreateAirdrop:
+		d := data.(*transaction.CreateAirdropData)
+		dataStruct, err := toStruct(map[string]interface{}{
+			"coin":          &Coin{ID: uint64(d.Coin), Symbol: rCoins.GetCoin(d.Coin).GetFullSymbol()},
+			"value":         d.Value.String(),
+			"merkle_root":   d.MerkleRoot.String(),
+			"expire_height": d.ExpireHeight,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
+	case transaction.TypeClaimAirdrop:
+		d := data.(*transaction.ClaimAirdropData)
+		proof := make([]string, 0, len(d.Proof))
+		for _, hash := range d.Proof {
+			proof = append(proof, hash.String())
+		}
+		dataStruct, err := toStruct(map[string]interface{}{
+			"airdrop_id": d.AirdropID,
+			"value":      d.Value.String(),
+			"proof":      proof,
+		})
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
 	case transaction.TypeRemoveLimitOrder:
 		d := data.(*transaction.RemoveLimitOrderData)
 		m = &pb.RemoveLimitOrderData{
@@ -389,6 +599,19 @@ func encode(data transaction.Data, txType transaction.TxType, rCoins coins.RCoin
 			},
 			Value: d.Value.String(),
 		}
+	case transaction.TypeRevokeCheck:
+		d := data.(*transaction.RevokeCheckData)
+		revoke := map[string]string{}
+		if d.Hash != (types.Hash{}) {
+			revoke["hash"] = d.Hash.String()
+		} else {
+			revoke["nonce"] = hex.EncodeToString(d.Nonce)
+		}
+		dataStruct, err := toStruct(revoke)
+		if err != nil {
+			return nil, err
+		}
+		m = dataStruct
 	default:
 		return nil, errors.New("unknown tx type")
 	}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Coin is a coin in responses of methods served as plain JSON
+type Coin struct {
+	ID     uint64 `json:"id"`
+	Symbol string `json:"symbol"`
+}
+
+func coinResponse(cState *state.CheckState, id types.CoinID) *Coin {
+	coin := &Coin{ID: uint64(id)}
+	if model := cState.Coins().GetCoin(id); model != nil {
+		coin.Symbol = model.GetFullSymbol()
+	}
+	return coin
+}
+
+// percent returns share of part in total in percent with two decimal places
+func percent(part, total *big.Int) string {
+	if total.Sign() == 0 {
+		return "0.00"
+	}
+	bp := new(big.Int).Mul(part, big.NewInt(10000))
+	bp.Quo(bp, total)
+	hundreds, rest := new(big.Int).QuoRem(bp, big.NewInt(100), new(big.Int))
+	return fmt.Sprintf("%s.%02d", hundreds, rest.Int64())
+}
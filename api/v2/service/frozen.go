@@ -35,7 +35,7 @@ func (s *Service) Frozen(ctx context.Context, req *pb.FrozenRequest) (*pb.Frozen
 	}
 	var frozen []*pb.FrozenResponse_Frozen
 
-	for i := s.blockchain.Height(); i <= s.blockchain.Height()+types.GetUnbondPeriod(); i++ {
+	for i := s.blockchain.Height(); i <= s.blockchain.Height()+cState.Params().GetUnbondPeriod(); i++ {
 
 		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
 			return nil, timeoutStatus.Err()
@@ -125,7 +125,7 @@ func (s *Service) FrozenAll(ctx context.Context, req *pb.FrozenAllRequest) (*pb.
 	}
 	endHeight := req.EndHeight
 	if endHeight == 0 {
-		endHeight = startHeight + types.GetUnbondPeriod()
+		endHeight = startHeight + cState.Params().GetUnbondPeriod()
 	}
 
 	var frozen []*pb.FrozenResponse_Frozen
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"sort"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/minter"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Types of governance proposals
+const (
+	ProposalTypeCommission = "commission"
+	ProposalTypeUpdate     = "update"
+	ProposalTypeHalt       = "halt"
+	ProposalTypeParameter  = "parameter"
+)
+
+// GovernanceTallyResponse is the live tally of pending commission, update, halt and parameter votes by current voting power
+type GovernanceTallyResponse struct {
+	Height     uint64                `json:"height"`
+	TotalPower string                `json:"total_power"`
+	Threshold  string                `json:"threshold"`
+	Proposals  []*GovernanceProposal `json:"proposals"`
+}
+
+// GovernanceProposal is the tally of votes for one option at the target height.
+// Commission, update and parameter proposals compete with other options of the same height, the option with the most power wins.
+type GovernanceProposal struct {
+	Type             string            `json:"type"`
+	Height           uint64            `json:"height"`
+	Version          string            `json:"version,omitempty"`
+	Commission       *types.Commission `json:"commission,omitempty"`
+	Parameter        string            `json:"parameter,omitempty"`
+	Value            *uint64           `json:"value,omitempty"`
+	Votes            []*GovernanceVote `json:"votes"`
+	VotedPower       string            `json:"voted_power"`
+	Percent          string            `json:"percent"`
+	ThresholdReached bool              `json:"threshold_reached"`
+	NotVoted         []*GovernanceVote `json:"not_voted"`
+}
+
+// GovernanceVote is the validator with its voting power, validators which are absent or going to be dropped have zero power
+type GovernanceVote struct {
+	PublicKey string `json:"public_key"`
+	Power     string `json:"power"`
+}
+
+// GovernanceTally returns pending proposals with target height above the current block tallied by current voting power.
+// The filter is an optional proposal type.
+func (s *Service) GovernanceTally(ctx context.Context, filter string) (*GovernanceTallyResponse, error) {
+	switch filter {
+	case "", ProposalTypeCommission, ProposalTypeUpdate, ProposalTypeHalt, ProposalTypeParameter:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown proposal type %q", filter)
+	}
+
+	cState := s.blockchain.CurrentState()
+	height := s.blockchain.Height()
+
+	appState := new(types.AppState)
+	if filter == "" || filter == ProposalTypeCommission {
+		cState.Commission().Export(appState)
+	}
+	if filter == "" || filter == ProposalTypeUpdate {
+		cState.Updates().Export(appState)
+	}
+	if filter == "" || filter == ProposalTypeHalt {
+		cState.Halts().Export(appState)
+	}
+	if filter == "" || filter == ProposalTypeParameter {
+		cState.Params().Export(appState)
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	vals := cState.Validators().GetValidators()
+	powers, totalPower := s.blockchain.VotingPowers(vals)
+	tally := func(proposal *GovernanceProposal, votes []types.Pubkey) *GovernanceProposal {
+		voted := make(map[types.Pubkey]struct{}, len(votes))
+		votedPower := big.NewInt(0)
+		proposal.Votes = make([]*GovernanceVote, 0, len(votes))
+		for _, pubkey := range votes {
+			voted[pubkey] = struct{}{}
+			power := big.NewInt(0)
+			if p, ok := powers[pubkey]; ok {
+				power = p
+			}
+			votedPower.Add(votedPower, power)
+			proposal.Votes = append(proposal.Votes, &GovernanceVote{PublicKey: pubkey.String(), Power: power.String()})
+		}
+		proposal.NotVoted = []*GovernanceVote{}
+		for _, val := range vals {
+			if _, ok := voted[val.PubKey]; ok {
+				continue
+			}
+			if power, ok := powers[val.PubKey]; ok {
+				proposal.NotVoted = append(proposal.NotVoted, &GovernanceVote{PublicKey: val.PubKey.String(), Power: power.String()})
+			}
+		}
+		proposal.VotedPower = votedPower.String()
+		percent := new(big.Rat).SetFrac(new(big.Int).Mul(votedPower, big.NewInt(100)), totalPower)
+		proposal.Percent = percent.FloatString(precision)
+		votingResult := new(big.Float).Quo(new(big.Float).SetInt(votedPower), new(big.Float).SetInt(totalPower))
+		proposal.ThresholdReached = votingResult.Cmp(big.NewFloat(minter.VotingPowerConsensus())) == 1
+		return proposal
+	}
+
+	response := &GovernanceTallyResponse{
+		Height:     height,
+		TotalPower: totalPower.String(),
+		Threshold:  new(big.Float).Mul(big.NewFloat(minter.VotingPowerConsensus()), big.NewFloat(100)).Text('f', 2),
+		Proposals:  []*GovernanceProposal{},
+	}
+	for i := range appState.CommissionVotes {
+		vote := &appState.CommissionVotes[i]
+		if vote.Height <= height {
+			continue
+		}
+		response.Proposals = append(response.Proposals, tally(&GovernanceProposal{Type: ProposalTypeCommission, Height: vote.Height, Commission: &vote.Commission}, vote.Votes))
+	}
+	for _, vote := range appState.UpdateVotes {
+		if vote.Height <= height {
+			continue
+		}
+		response.Proposals = append(response.Proposals, tally(&GovernanceProposal{Type: ProposalTypeUpdate, Height: vote.Height, Version: vote.Version}, vote.Votes))
+	}
+	for i := range appState.ParameterVotes {
+		vote := &appState.ParameterVotes[i]
+		if vote.Height <= height {
+			continue
+		}
+		response.Proposals = append(response.Proposals, tally(&GovernanceProposal{Type: ProposalTypeParameter, Height: vote.Height, Parameter: vote.Parameter, Value: &vote.Value}, vote.Votes))
+	}
+	halts := map[uint64][]types.Pubkey{}
+	for _, halt := range appState.HaltBlocks {
+		if halt.Height <= height {
+			continue
+		}
+		halts[halt.Height] = append(halts[halt.Height], halt.CandidateKey)
+	}
+	for haltHeight, votes := range halts {
+		response.Proposals = append(response.Proposals, tally(&GovernanceProposal{Type: ProposalTypeHalt, Height: haltHeight}, votes))
+	}
+
+	sort.SliceStable(response.Proposals, func(i, j int) bool {
+		return response.Proposals[i].Height < response.Proposals[j].Height
+	})
+
+	return response, nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MiningProgramRequest is a request of the liquidity mining program, Program is "id" or "id/address"
+type MiningProgramRequest struct {
+	Program string
+	Height  uint64
+}
+
+// MiningProgramResponse is the liquidity mining program with its stakes.
+// If the address is requested, only its stake is returned.
+type MiningProgramResponse struct {
+	ID             uint64         `json:"id"`
+	Owner          string         `json:"owner"`
+	LiquidityCoin  *Coin          `json:"liquidity_coin"`
+	RewardCoin     *Coin          `json:"reward_coin"`
+	Value          string         `json:"value"`
+	StartHeight    uint64         `json:"start_height"`
+	EndHeight      uint64         `json:"end_height"`
+	RewardPerBlock string         `json:"reward_per_block"`
+	TotalStaked    string         `json:"total_staked"`
+	Claimed        string         `json:"claimed"`
+	Stakes         []*MiningStake `json:"stakes"`
+}
+
+// MiningStake is the stake in the liquidity mining program and the reward which can be claimed as of the height of the state
+type MiningStake struct {
+	Address string `json:"address"`
+	Value   string `json:"value"`
+	Earned  string `json:"earned"`
+}
+
+// MiningProgram returns the liquidity mining program and rewards earned by its stakes
+func (s *Service) MiningProgram(ctx context.Context, req *MiningProgramRequest) (*MiningProgramResponse, error) {
+	params := strings.Split(req.Program, "/")
+	if len(params) > 2 {
+		return nil, status.Error(codes.InvalidArgument, "program should be set as id or id/address")
+	}
+	id, err := strconv.ParseUint(params[0], 10, 32)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid program id")
+	}
+	var address *types.Address
+	if len(params) == 2 {
+		if !strings.HasPrefix(strings.Title(params[1]), "Mx") {
+			return nil, status.Error(codes.InvalidArgument, "invalid address")
+		}
+		addr := types.HexToAddress(params[1])
+		address = &addr
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	height := req.Height
+	if height == 0 {
+		height = s.blockchain.Height()
+	}
+
+	program := cState.Mining().GetProgram(uint32(id))
+	if program == nil {
+		return nil, status.Error(codes.NotFound, "mining program not found")
+	}
+
+	duration := new(big.Int).SetUint64(program.EndHeight - program.StartHeight)
+	response := &MiningProgramResponse{
+		ID:             id,
+		Owner:          program.Owner.String(),
+		LiquidityCoin:  &Coin{ID: uint64(program.LiquidityCoin), Symbol: cState.Coins().GetCoin(program.LiquidityCoin).GetFullSymbol()},
+		RewardCoin:     &Coin{ID: uint64(program.RewardCoin), Symbol: cState.Coins().GetCoin(program.RewardCoin).GetFullSymbol()},
+		Value:          program.Value.String(),
+		StartHeight:    program.StartHeight,
+		EndHeight:      program.EndHeight,
+		RewardPerBlock: new(big.Int).Quo(program.Value, duration).String(),
+		TotalStaked:    program.TotalStaked.String(),
+		Claimed:        program.Claimed.String(),
+		Stakes:         []*MiningStake{},
+	}
+
+	if address != nil {
+		value := big.NewInt(0)
+		if stake := cState.Mining().GetStake(uint32(id), *address); stake != nil {
+			value = stake.Value
+		}
+		response.Stakes = append(response.Stakes, &MiningStake{
+			Address: address.String(),
+			Value:   value.String(),
+			Earned:  cState.Mining().Earned(uint32(id), *address, height).String(),
+		})
+		return response, nil
+	}
+
+	for _, stake := range cState.Mining().GetStakes(uint32(id)) {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return nil, timeoutStatus.Err()
+		}
+		response.Stakes = append(response.Stakes, &MiningStake{
+			Address: stake.Owner().String(),
+			Value:   stake.Value.String(),
+			Earned:  cState.Mining().Earned(uint32(id), stake.Owner(), height).String(),
+		})
+	}
+
+	return response, nil
+}
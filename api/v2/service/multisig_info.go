@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MultisigInfoRequest is a request of the multisig settings or multisigs of the member
+type MultisigInfoRequest struct {
+	Address string
+	Height  uint64
+}
+
+// MultisigInfoResponse is the current settings of the multisig with its previous configurations
+type MultisigInfoResponse struct {
+	Address   string                 `json:"address"`
+	Threshold uint64                 `json:"threshold"`
+	Members   []*MultisigMember      `json:"members"`
+	History   []*MultisigHistoryItem `json:"history"`
+}
+
+// MultisigMember is a member of the multisig with its weight
+type MultisigMember struct {
+	Address string `json:"address"`
+	Weight  uint64 `json:"weight"`
+}
+
+// MultisigHistoryItem is a configuration of the multisig which was replaced at Height
+type MultisigHistoryItem struct {
+	Height    uint64            `json:"height"`
+	Threshold uint64            `json:"threshold"`
+	Members   []*MultisigMember `json:"members"`
+}
+
+// MemberMultisigsResponse is a list of multisigs the address is a member of
+type MemberMultisigsResponse struct {
+	Address   string                    `json:"address"`
+	Multisigs []*MemberMultisigResponse `json:"multisigs"`
+}
+
+// MemberMultisigResponse is a multisig with the weight of the member
+type MemberMultisigResponse struct {
+	Address   string `json:"address"`
+	Threshold uint64 `json:"threshold"`
+	Weight    uint64 `json:"weight"`
+}
+
+// MultisigInfo returns weights, threshold and members of the multisig with the history of its edits.
+func (s *Service) MultisigInfo(ctx context.Context, req *MultisigInfoRequest) (*MultisigInfoResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+	address := types.HexToAddress(req.Address)
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	account := cState.Accounts().GetAccount(address)
+	if account == nil || !account.IsMultisig() {
+		return nil, status.Error(codes.NotFound, "multisig does not exists")
+	}
+
+	multisigData := account.Multisig()
+	response := &MultisigInfoResponse{
+		Address:   address.String(),
+		Threshold: uint64(multisigData.Threshold),
+		Members:   multisigMembers(multisigData.Addresses, multisigData.Weights),
+		History:   []*MultisigHistoryItem{},
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	for _, edit := range cState.Accounts().GetMultisigHistory(address) {
+		response.History = append(response.History, &MultisigHistoryItem{
+			Height:    edit.Height,
+			Threshold: uint64(edit.Threshold),
+			Members:   multisigMembers(edit.Addresses, edit.Weights),
+		})
+	}
+
+	return response, nil
+}
+
+// MemberMultisigs returns multisig addresses the address is a member of.
+func (s *Service) MemberMultisigs(ctx context.Context, req *MultisigInfoRequest) (*MemberMultisigsResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+	address := types.HexToAddress(req.Address)
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	response := &MemberMultisigsResponse{
+		Address:   address.String(),
+		Multisigs: []*MemberMultisigResponse{},
+	}
+	for _, multisigAddress := range cState.Accounts().GetMultisigsOfMember(address) {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return nil, timeoutStatus.Err()
+		}
+
+		account := cState.Accounts().GetAccount(multisigAddress)
+		if account == nil || !account.IsMultisig() {
+			continue
+		}
+		multisigData := account.Multisig()
+		response.Multisigs = append(response.Multisigs, &MemberMultisigResponse{
+			Address:   multisigAddress.String(),
+			Threshold: uint64(multisigData.Threshold),
+			Weight:    uint64(multisigData.GetWeight(address)),
+		})
+	}
+
+	return response, nil
+}
+
+func multisigMembers(addresses []types.Address, weights []uint32) []*MultisigMember {
+	members := make([]*MultisigMember, 0, len(addresses))
+	for i, address := range addresses {
+		members = append(members, &MultisigMember{
+			Address: address.String(),
+			Weight:  uint64(weights[i]),
+		})
+	}
+	return members
+}
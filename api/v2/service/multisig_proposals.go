@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/multisig"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MultisigProposalRequest is a request to create proposal of the multisig transaction.
+// Tx is encoded transaction with SigTypeMulti, its signatures are added to the proposal.
+type MultisigProposalRequest struct {
+	Tx string `json:"tx"`
+}
+
+// MultisigProposalSignRequest is a request to add signature of the member to the proposal.
+// Signature is 65 bytes of R, S and V of the proposal ID signature.
+// The request without the signature sends the fully signed proposal again after the failed sending.
+type MultisigProposalSignRequest struct {
+	ID        string `json:"-"`
+	Signature string `json:"signature"`
+}
+
+// MultisigProposalsResponse is a list of pending proposals of the multisig
+type MultisigProposalsResponse struct {
+	Multisig  string                      `json:"multisig"`
+	Proposals []*MultisigProposalResponse `json:"proposals"`
+}
+
+// MultisigProposalResponse is a proposal with collected signatures and result of its sending
+type MultisigProposalResponse struct {
+	ID          string                            `json:"id"`
+	Multisig    string                            `json:"multisig"`
+	Nonce       uint64                            `json:"nonce"`
+	Tx          string                            `json:"tx"`
+	Height      uint64                            `json:"height"`
+	Signers     []*MultisigProposalResponseSigner `json:"signers"`
+	Weight      uint64                            `json:"weight"`
+	Threshold   uint64                            `json:"threshold"`
+	Broadcasted bool                              `json:"broadcasted"`
+	TxHash      string                            `json:"tx_hash,omitempty"`
+	Code        uint32                            `json:"code"`
+	Log         string                            `json:"log,omitempty"`
+}
+
+type MultisigProposalResponseSigner struct {
+	Address string `json:"address"`
+	Weight  uint64 `json:"weight"`
+}
+
+// SubmitMultisigProposal stores the multisig transaction to collect signatures of the members,
+// the transaction should be signed by at least one of them.
+// The transaction is sent as soon as the weight of signatures reaches the threshold.
+func (s *Service) SubmitMultisigProposal(ctx context.Context, req *MultisigProposalRequest) (*MultisigProposalResponse, error) {
+	if !strings.HasPrefix(strings.Title(req.Tx), "0x") {
+		return nil, status.Error(codes.InvalidArgument, "invalid transaction")
+	}
+	raw, err := hex.DecodeString(req.Tx[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	tx, err := s.decoderTx.DecodeFromBytes(raw)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if tx.SignatureType != transaction.SigTypeMulti {
+		return nil, status.Error(codes.InvalidArgument, "transaction should have multisig signature type")
+	}
+
+	var signatureData transaction.SignatureMulti
+	if err := rlp.DecodeBytes(tx.SignatureData, &signatureData); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	multisigData, err := s.pendingMultisig(signatureData.Multisig)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Nonce <= s.blockchain.CurrentState().Accounts().GetNonce(signatureData.Multisig) {
+		return nil, status.Error(codes.InvalidArgument, "nonce of the transaction is already used")
+	}
+
+	unsigned := &transaction.Transaction{
+		Nonce:         tx.Nonce,
+		ChainID:       tx.ChainID,
+		GasPrice:      tx.GasPrice,
+		GasCoin:       tx.GasCoin,
+		Type:          tx.Type,
+		Data:          tx.Data,
+		Payload:       tx.Payload,
+		ServiceData:   tx.ServiceData,
+		SignatureType: tx.SignatureType,
+	}
+	unsigned.SetMultisigAddress(signatureData.Multisig)
+	encoded, err := unsigned.Serialize()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	id := tx.Hash()
+	var signatures []multisig.Signature
+	for _, sig := range signatureData.Signatures {
+		signature, err := multisigSignature(id, multisigData, sig)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, signature)
+	}
+
+	_, err = s.multisigPool.Add(&multisig.Proposal{
+		ID:         id,
+		Multisig:   signatureData.Multisig,
+		Nonce:      tx.Nonce,
+		Tx:         encoded,
+		Signatures: signatures,
+		Height:     s.blockchain.Height(),
+	})
+	if err == multisig.ErrNotSigned {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	// signatures of the already stored proposal are added to it
+	for _, signature := range signatures {
+		if _, err := s.multisigPool.AddSignature(id, signature); err != nil && err != multisig.ErrAlreadySigned && err != multisig.ErrAlreadyBroadcasted {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+
+	proposal, err := s.broadcastMultisigProposal(ctx, id, multisigData)
+	if err != nil {
+		return nil, err
+	}
+
+	return multisigProposalResponse(proposal, multisigData), nil
+}
+
+// SignMultisigProposal adds signature of the member to the proposal and sends the transaction
+// if the weight of signatures reaches the threshold. The failed sending is retried by the next request.
+func (s *Service) SignMultisigProposal(ctx context.Context, req *MultisigProposalSignRequest) (*MultisigProposalResponse, error) {
+	id, err := proposalID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal := s.multisigPool.Get(id)
+	if proposal == nil {
+		return nil, status.Error(codes.NotFound, multisig.ErrProposalNotFound.Error())
+	}
+
+	multisigData, err := s.pendingMultisig(proposal.Multisig)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Signature != "" {
+		sigBytes, err := hex.DecodeString(strings.TrimPrefix(req.Signature, "0x"))
+		if err != nil || len(sigBytes) != 65 {
+			return nil, status.Error(codes.InvalidArgument, "signature should be 65 bytes in hex")
+		}
+
+		signature, err := multisigSignature(id, multisigData, transaction.Signature{
+			R: new(big.Int).SetBytes(sigBytes[:32]),
+			S: new(big.Int).SetBytes(sigBytes[32:64]),
+			V: new(big.Int).SetBytes([]byte{sigBytes[64] + 27}),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = s.multisigPool.AddSignature(id, signature)
+		if err != nil && err != multisig.ErrAlreadySigned {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+
+	proposal, err = s.broadcastMultisigProposal(ctx, id, multisigData)
+	if err != nil {
+		return nil, err
+	}
+
+	return multisigProposalResponse(proposal, multisigData), nil
+}
+
+// MultisigProposal returns the proposal by its ID.
+func (s *Service) MultisigProposal(_ context.Context, idString string) (*MultisigProposalResponse, error) {
+	id, err := proposalID(idString)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal := s.multisigPool.Get(id)
+	if proposal == nil {
+		return nil, status.Error(codes.NotFound, multisig.ErrProposalNotFound.Error())
+	}
+
+	multisigData, err := s.pendingMultisig(proposal.Multisig)
+	if err != nil {
+		return nil, err
+	}
+
+	return multisigProposalResponse(proposal, multisigData), nil
+}
+
+// MultisigProposals returns pending proposals of the multisig.
+func (s *Service) MultisigProposals(_ context.Context, address string) (*MultisigProposalsResponse, error) {
+	if !strings.HasPrefix(strings.Title(address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+	multisigAddress := types.HexToAddress(address)
+
+	multisigData, err := s.pendingMultisig(multisigAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MultisigProposalsResponse{
+		Multisig:  multisigAddress.String(),
+		Proposals: []*MultisigProposalResponse{},
+	}
+	for _, proposal := range s.multisigPool.List(multisigAddress) {
+		response.Proposals = append(response.Proposals, multisigProposalResponse(proposal, multisigData))
+	}
+
+	return response, nil
+}
+
+// pendingMultisig returns current settings of the multisig and drops its proposals with used nonce
+func (s *Service) pendingMultisig(address types.Address) (*accounts.Multisig, error) {
+	cState := s.blockchain.CurrentState()
+	account := cState.Accounts().GetAccount(address)
+	if account == nil || !account.IsMultisig() {
+		return nil, status.Error(codes.NotFound, "multisig does not exists")
+	}
+
+	s.multisigPool.Prune(address, cState.Accounts().GetNonce(address))
+
+	multisigData := account.Multisig()
+	return &multisigData, nil
+}
+
+// multisigSignature returns the signature of the proposal by the member of the multisig
+func multisigSignature(id types.Hash, multisigData *accounts.Multisig, sig transaction.Signature) (multisig.Signature, error) {
+	signer, err := transaction.RecoverPlain(id, sig.R, sig.S, sig.V)
+	if err != nil {
+		return multisig.Signature{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if multisigData.GetWeight(signer) == 0 {
+		return multisig.Signature{}, status.Errorf(codes.PermissionDenied, "%s is not a member of the multisig", signer.String())
+	}
+
+	sigBytes := make([]byte, 65)
+	sig.R.FillBytes(sigBytes[:32])
+	sig.S.FillBytes(sigBytes[32:64])
+	sigBytes[64] = byte(sig.V.Uint64() - 27)
+
+	return multisig.Signature{Signer: signer, Sig: sigBytes}, nil
+}
+
+// broadcastMultisigProposal sends the proposal if the weight of its signatures reaches the threshold
+// and it is not sent yet or its previous sending is failed
+func (s *Service) broadcastMultisigProposal(ctx context.Context, id types.Hash, multisigData *accounts.Multisig) (*multisig.Proposal, error) {
+	proposal := s.multisigPool.Get(id)
+	if proposal == nil {
+		return nil, status.Error(codes.NotFound, multisig.ErrProposalNotFound.Error())
+	}
+
+	if proposalWeight(proposal, multisigData) < multisigData.Threshold || !s.multisigPool.MarkBroadcasted(id) {
+		return proposal, nil
+	}
+
+	tx, err := s.decoderTx.DecodeFromBytes(proposal.Tx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	for _, signature := range proposal.Signatures {
+		if multisigData.GetWeight(signature.Signer) != 0 {
+			tx.SetSignature(signature.Sig)
+		}
+	}
+	encoded, err := tx.Serialize()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	result, statusErr := s.broadcastTxSync(ctx, encoded)
+	if statusErr != nil {
+		s.multisigPool.SetResult(id, "", code.Unavailable, statusErr.Message())
+	} else {
+		s.multisigPool.SetResult(id, "Mt"+strings.ToLower(fmt.Sprintf("%x", result.Hash)), result.Code, result.Log)
+	}
+
+	return s.multisigPool.Get(id), nil
+}
+
+func proposalWeight(proposal *multisig.Proposal, multisigData *accounts.Multisig) uint32 {
+	var weight uint32
+	for _, sig := range proposal.Signatures {
+		weight += multisigData.GetWeight(sig.Signer)
+	}
+	return weight
+}
+
+func proposalID(id string) (types.Hash, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(id, "0x"))
+	if err != nil || len(b) != types.HashLength {
+		return types.Hash{}, status.Error(codes.InvalidArgument, "invalid proposal id")
+	}
+	return types.BytesToHash(b), nil
+}
+
+func multisigProposalResponse(proposal *multisig.Proposal, multisigData *accounts.Multisig) *MultisigProposalResponse {
+	response := &MultisigProposalResponse{
+		ID:          proposal.ID.String(),
+		Multisig:    proposal.Multisig.String(),
+		Nonce:       proposal.Nonce,
+		Tx:          "0x" + hex.EncodeToString(proposal.Tx),
+		Height:      proposal.Height,
+		Signers:     make([]*MultisigProposalResponseSigner, 0, len(proposal.Signatures)),
+		Weight:      uint64(proposalWeight(proposal, multisigData)),
+		Threshold:   uint64(multisigData.Threshold),
+		Broadcasted: proposal.Broadcasted,
+		TxHash:      proposal.TxHash,
+		Code:        proposal.Code,
+		Log:         proposal.Log,
+	}
+	for _, sig := range proposal.Signatures {
+		response.Signers = append(response.Signers, &MultisigProposalResponseSigner{
+			Address: sig.Signer.String(),
+			Weight:  uint64(multisigData.GetWeight(sig.Signer)),
+		})
+	}
+	return response
+}
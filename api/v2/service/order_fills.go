@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OrderFillsResponse is a history of fills of the limit order or of all limit orders of the address
+type OrderFillsResponse struct {
+	Fills []*events.OrderFilledEvent `json:"fills"`
+}
+
+// OrderFills returns every fill of the limit order with the remaining volumes after it.
+func (s *Service) OrderFills(ctx context.Context, id string) (*OrderFillsResponse, error) {
+	orderID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid order id")
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	return orderFillsResponse(s.blockchain.GetEventsDB().LoadOrderFills(uint32(orderID))), nil
+}
+
+// AddressOrderFills returns fills of all limit orders owned by the address.
+func (s *Service) AddressOrderFills(ctx context.Context, address string) (*OrderFillsResponse, error) {
+	if !strings.HasPrefix(strings.Title(address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	return orderFillsResponse(s.blockchain.GetEventsDB().LoadAddressOrderFills(types.HexToAddress(address))), nil
+}
+
+func orderFillsResponse(fills []*events.OrderFilledEvent) *OrderFillsResponse {
+	if fills == nil {
+		fills = []*events.OrderFilledEvent{}
+	}
+	return &OrderFillsResponse{Fills: fills}
+}
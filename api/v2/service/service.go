@@ -7,6 +7,7 @@ import (
 
 	"github.com/MinterTeam/minter-go-node/config"
 	"github.com/MinterTeam/minter-go-node/coreV2/minter"
+	"github.com/MinterTeam/minter-go-node/coreV2/multisig"
 	"github.com/MinterTeam/minter-go-node/coreV2/rewards"
 	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
 	"github.com/MinterTeam/node-grpc-gateway/api_pb"
@@ -26,6 +27,8 @@ type Service struct {
 	rewards    *rewards.Reward
 	api_pb.UnimplementedApiServiceServer
 	decoderTx transaction.DecoderTx
+
+	multisigPool *multisig.Pool
 }
 
 // NewService create gRPC server implementation
@@ -38,6 +41,8 @@ func NewService(blockchain *minter.Blockchain, client *rpc.Local, node *tmNode.N
 		version:    version,
 		tmNode:     node,
 		decoderTx:  transaction.NewExecutorV3(transaction.GetData),
+
+		multisigPool: multisig.NewPool(),
 	}
 }
 
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxSwapEstimateRoute is the maximum number of coins in the route, as in EstimateCoinSell
+const maxSwapEstimateRoute = 5
+
+// SwapEstimateRequest is a request of the sell estimate through pools, Route is "coin0/coin1/.../coinN".
+// Slippage is an optional bound of the price impact in percent.
+type SwapEstimateRequest struct {
+	Route       string
+	ValueToSell string
+	Slippage    string
+	Height      uint64
+}
+
+// SwapEstimateResponse is the sell through the route of pools with the price impact of every hop.
+// Prices are in coins to buy per coin to sell, the price impact includes commissions of pools and orders.
+type SwapEstimateResponse struct {
+	Route          []uint64           `json:"route"`
+	ValueToSell    string             `json:"value_to_sell"`
+	ValueToBuy     string             `json:"value_to_buy"`
+	MidPrice       string             `json:"mid_price"`
+	ExecutionPrice string             `json:"execution_price"`
+	PriceImpact    string             `json:"price_impact"`
+	Slippage       string             `json:"slippage,omitempty"`
+	MaxValueToSell string             `json:"max_value_to_sell,omitempty"`
+	Hops           []*SwapEstimateHop `json:"hops"`
+}
+
+// SwapEstimateHop is the sell through one pool of the route.
+// The sold value is split into the burned commission, the part filled by limit orders and the part swapped by the pool curve.
+type SwapEstimateHop struct {
+	PoolID         uint64               `json:"pool_id"`
+	CoinIn         uint64               `json:"coin_in"`
+	CoinOut        uint64               `json:"coin_out"`
+	ValueIn        string               `json:"value_in"`
+	ValueOut       string               `json:"value_out"`
+	MidPriceBefore string               `json:"mid_price_before"`
+	MidPriceAfter  string               `json:"mid_price_after"`
+	ExecutionPrice string               `json:"execution_price"`
+	PriceImpact    string               `json:"price_impact"`
+	Burned         string               `json:"burned"`
+	OrdersValueIn  string               `json:"orders_value_in"`
+	OrdersValueOut string               `json:"orders_value_out"`
+	PoolValueIn    string               `json:"pool_value_in"`
+	PoolValueOut   string               `json:"pool_value_out"`
+	Orders         []*SwapEstimateOrder `json:"orders"`
+}
+
+// SwapEstimateOrder is the part of the limit order filled by the sell
+type SwapEstimateOrder struct {
+	ID       uint64 `json:"id"`
+	Price    string `json:"price"`
+	ValueIn  string `json:"value_in"`
+	ValueOut string `json:"value_out"`
+}
+
+// swapEstimate is the sell through the route before formatting
+type swapEstimate struct {
+	valueOut    *big.Int
+	midPrice    *big.Rat
+	priceImpact *big.Rat
+	hops        []*SwapEstimateHop
+}
+
+// SwapEstimate returns the execution price, mid prices and the price impact of the sell through the route of pools.
+// If the slippage is set, it also returns the maximum value to sell with the price impact not above it.
+// Commission of the transaction is not included.
+func (s *Service) SwapEstimate(ctx context.Context, req *SwapEstimateRequest) (*SwapEstimateResponse, error) {
+	var route []types.CoinID
+	for _, coin := range strings.Split(req.Route, "/") {
+		id, err := strconv.ParseUint(coin, 10, 32)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid coin id %q in route", coin)
+		}
+		route = append(route, types.CoinID(id))
+	}
+	if len(route) < 2 {
+		return nil, status.Error(codes.InvalidArgument, "route should be set as coin0/coin1/.../coinN")
+	}
+	if len(route) > maxSwapEstimateRoute {
+		return nil, status.Errorf(codes.OutOfRange, "maximum allowed length of the exchange chain is %d", maxSwapEstimateRoute)
+	}
+
+	valueToSell, ok := new(big.Int).SetString(req.ValueToSell, 10)
+	if !ok || valueToSell.Sign() != 1 {
+		return nil, status.Error(codes.InvalidArgument, "value_to_sell should be a positive integer")
+	}
+
+	var slippage *big.Rat
+	if req.Slippage != "" {
+		slippage, ok = new(big.Rat).SetString(req.Slippage)
+		if !ok || slippage.Sign() != 1 || slippage.Cmp(big.NewRat(100, 1)) != -1 {
+			return nil, status.Error(codes.InvalidArgument, "slippage should be a percent between 0 and 100")
+		}
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(req.Height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	estimate, err := s.estimateSell(ctx, cState, route, valueToSell)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SwapEstimateResponse{
+		ValueToSell:    valueToSell.String(),
+		ValueToBuy:     estimate.valueOut.String(),
+		MidPrice:       estimate.midPrice.FloatString(precision),
+		ExecutionPrice: swap.CalcPriceSellRat(valueToSell, estimate.valueOut).FloatString(precision),
+		PriceImpact:    estimate.priceImpact.FloatString(precision),
+		Hops:           estimate.hops,
+	}
+	for _, coin := range route {
+		response.Route = append(response.Route, uint64(coin))
+	}
+
+	if slippage != nil {
+		maxValue, err := s.maxSellWithinSlippage(ctx, cState, route, valueToSell, slippage)
+		if err != nil {
+			return nil, err
+		}
+		response.Slippage = slippage.FloatString(precision)
+		response.MaxValueToSell = maxValue.String()
+	}
+
+	return response, nil
+}
+
+func (s *Service) estimateSell(ctx context.Context, cState *state.CheckState, route []types.CoinID, valueToSell *big.Int) (*swapEstimate, error) {
+	estimate := &swapEstimate{midPrice: big.NewRat(1, 1)}
+	value := new(big.Int).Set(valueToSell)
+	dup := make(map[uint32]struct{})
+	for i := 1; i < len(route); i++ {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return nil, timeoutStatus.Err()
+		}
+
+		coinIn, coinOut := route[i-1], route[i]
+		if coinIn == coinOut {
+			return nil, status.Error(codes.InvalidArgument, "equal coins id")
+		}
+		swapper := cState.Swap().GetSwapper(coinIn, coinOut)
+		if !swapper.Exists() {
+			return nil, status.Errorf(codes.NotFound, "swap pool between coins %d and %d not exists", coinIn, coinOut)
+		}
+		if _, ok := dup[swapper.GetID()]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Forbidden to repeat the pool in the route, pool duplicate %d", swapper.GetID())
+		}
+		dup[swapper.GetID()] = struct{}{}
+
+		valueOut, orders := swapper.CalculateBuyForSellWithOrders(value)
+		if valueOut == nil || valueOut.Sign() != 1 {
+			reserve0, reserve1 := swapper.Reserves()
+			return nil, status.Errorf(codes.OutOfRange, "swap pool has reserves %s of coin %d and %s of coin %d, you wanted sell %s", reserve0, coinIn, reserve1, coinOut, value)
+		}
+
+		burned, _, poolValueIn, poolValueOut, _ := swap.CalcDiffPool(value, valueOut, orders)
+
+		midPriceBefore := swapper.PriceRat()
+		executionPrice := swap.CalcPriceSellRat(value, valueOut)
+		midPriceAfter := swapper.AddLastSwapStepWithOrders(value, valueOut, false).PriceRat()
+
+		hop := &SwapEstimateHop{
+			PoolID:         uint64(swapper.GetID()),
+			CoinIn:         uint64(coinIn),
+			CoinOut:        uint64(coinOut),
+			ValueIn:        value.String(),
+			ValueOut:       valueOut.String(),
+			MidPriceBefore: midPriceBefore.FloatString(precision),
+			MidPriceAfter:  midPriceAfter.FloatString(precision),
+			ExecutionPrice: executionPrice.FloatString(precision),
+			PriceImpact:    priceImpact(midPriceBefore, executionPrice).FloatString(precision),
+			Burned:         burned.String(),
+			OrdersValueIn:  new(big.Int).Sub(new(big.Int).Sub(value, burned), poolValueIn).String(),
+			OrdersValueOut: new(big.Int).Sub(valueOut, poolValueOut).String(),
+			PoolValueIn:    poolValueIn.String(),
+			PoolValueOut:   poolValueOut.String(),
+			Orders:         make([]*SwapEstimateOrder, 0, len(orders)),
+		}
+		for _, order := range orders {
+			hop.Orders = append(hop.Orders, &SwapEstimateOrder{
+				ID:       uint64(order.ID()),
+				Price:    order.PriceRat().FloatString(precision),
+				ValueIn:  order.WantBuy.String(),
+				ValueOut: order.WantSell.String(),
+			})
+		}
+
+		estimate.hops = append(estimate.hops, hop)
+		estimate.midPrice.Mul(estimate.midPrice, midPriceBefore)
+		value = valueOut
+	}
+
+	estimate.valueOut = value
+	estimate.priceImpact = priceImpact(estimate.midPrice, swap.CalcPriceSellRat(valueToSell, value))
+	return estimate, nil
+}
+
+// maxSellWithinSlippage searches the maximum value to sell with the price impact not above the slippage.
+// The price impact grows with the value, so the bound is found by doubling and bisection to 1e-6 of the value.
+func (s *Service) maxSellWithinSlippage(ctx context.Context, cState *state.CheckState, route []types.CoinID, valueToSell *big.Int, slippage *big.Rat) (*big.Int, error) {
+	fits := func(value *big.Int) (bool, error) {
+		estimate, err := s.estimateSell(ctx, cState, route, value)
+		if err != nil {
+			if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+				return false, timeoutStatus.Err()
+			}
+			return false, nil
+		}
+		return estimate.priceImpact.Cmp(slippage) != 1, nil
+	}
+
+	low, high := big.NewInt(0), new(big.Int).Set(valueToSell)
+	for i := 0; i < 256; i++ {
+		ok, err := fits(high)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		low.Set(high)
+		high.Lsh(high, 1)
+	}
+
+	for {
+		step := new(big.Int).Sub(high, low)
+		if step.Cmp(big.NewInt(1)) != 1 || new(big.Int).Mul(step, big.NewInt(1e6)).Cmp(high) != 1 {
+			return low, nil
+		}
+		middle := new(big.Int).Add(low, step.Rsh(step, 1))
+		ok, err := fits(middle)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+}
+
+// priceImpact returns the difference between the mid price and the execution price in percent of the mid price
+func priceImpact(midPrice, executionPrice *big.Rat) *big.Rat {
+	if midPrice.Sign() == 0 {
+		return new(big.Rat)
+	}
+	impact := new(big.Rat).Sub(midPrice, executionPrice)
+	impact.Quo(impact, midPrice)
+	return impact.Mul(impact, big.NewRat(100, 1))
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/transaction"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SwapPoolPositionResponse is the position of the liquidity provider built from its AddLiquidity and RemoveLiquidity history.
+// Liquidity received by transfers is not a part of the position. Values are denominated in coin1.
+type SwapPoolPositionResponse struct {
+	Address         string                          `json:"address"`
+	PoolID          uint64                          `json:"pool_id"`
+	Coin0           uint64                          `json:"coin0"`
+	Coin1           uint64                          `json:"coin1"`
+	Balance         string                          `json:"balance"`
+	Liquidity       string                          `json:"liquidity"`
+	Amount0         string                          `json:"amount0"`
+	Amount1         string                          `json:"amount1"`
+	CostBasis0      string                          `json:"cost_basis0"`
+	CostBasis1      string                          `json:"cost_basis1"`
+	Fees0           string                          `json:"fees0"`
+	Fees1           string                          `json:"fees1"`
+	HoldValue       string                          `json:"hold_value"`
+	PositionValue   string                          `json:"position_value"`
+	ProfitVsHold    string                          `json:"profit_vs_hold"`
+	ImpermanentLoss string                          `json:"impermanent_loss"`
+	History         []*events.LiquidityChangedEvent `json:"history"`
+}
+
+// SwapPoolPosition returns the cost basis, earned fees and impermanent loss of the liquidity provider, position is "coin0/coin1/address".
+// Fees are measured by the growth of the square root of reserves product per liquidity token since the liquidity was added.
+func (s *Service) SwapPoolPosition(ctx context.Context, position string) (*SwapPoolPositionResponse, error) {
+	params := strings.Split(position, "/")
+	if len(params) != 3 {
+		return nil, status.Error(codes.InvalidArgument, "position should be set as coin0/coin1/address")
+	}
+	coin0, err := strconv.ParseUint(params[0], 10, 32)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid coin0")
+	}
+	coin1, err := strconv.ParseUint(params[1], 10, 32)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid coin1")
+	}
+	if coin0 == coin1 {
+		return nil, status.Error(codes.InvalidArgument, "equal coins id")
+	}
+	if !strings.HasPrefix(strings.Title(params[2]), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+	address := types.HexToAddress(params[2])
+
+	cState := s.blockchain.CurrentState()
+	swapper := cState.Swap().GetSwapper(types.CoinID(coin0), types.CoinID(coin1))
+	if !swapper.Exists() {
+		return nil, status.Error(codes.NotFound, "pair not found")
+	}
+	liquidityCoin := cState.Coins().GetCoinBySymbol(transaction.LiquidityCoinSymbol(swapper.GetID()), 0)
+	reserve0, reserve1 := swapper.Reserves()
+	totalSupply := liquidityCoin.Volume()
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	history := s.blockchain.GetEventsDB().LoadLiquidityChanges(swapper.GetID(), address)
+	if history == nil {
+		history = []*events.LiquidityChangedEvent{}
+	}
+
+	liquidity := big.NewInt(0)
+	costBasis0, costBasis1 := new(big.Rat), new(big.Rat)
+	entryUnits := new(big.Rat) // liquidity multiplied by the square root of reserves product per token at the time it was added
+	for _, change := range history {
+		volume0, volume1 := stringRat(change.Volume0), stringRat(change.Volume1)
+		changeReserve0, changeReserve1 := stringInt(change.Reserve0), stringInt(change.Reserve1)
+		if change.Coin0 != coin0 {
+			volume0, volume1 = volume1, volume0
+			changeReserve0, changeReserve1 = changeReserve1, changeReserve0
+		}
+		changeLiquidity := stringInt(change.Liquidity)
+
+		if !change.Remove {
+			liquidity.Add(liquidity, changeLiquidity)
+			costBasis0.Add(costBasis0, volume0)
+			costBasis1.Add(costBasis1, volume1)
+			entryUnits.Add(entryUnits, new(big.Rat).Mul(new(big.Rat).SetInt(changeLiquidity), rootKPerToken(changeReserve0, changeReserve1, stringInt(change.TotalSupply))))
+			continue
+		}
+
+		if liquidity.Sign() == 0 {
+			continue
+		}
+		if changeLiquidity.Cmp(liquidity) == 1 {
+			changeLiquidity = liquidity
+		}
+		remaining := new(big.Rat).SetFrac(new(big.Int).Sub(liquidity, changeLiquidity), liquidity)
+		costBasis0.Mul(costBasis0, remaining)
+		costBasis1.Mul(costBasis1, remaining)
+		entryUnits.Mul(entryUnits, remaining)
+		liquidity = new(big.Int).Sub(liquidity, changeLiquidity)
+	}
+
+	amount0, amount1 := new(big.Rat), new(big.Rat)
+	feeShare := new(big.Rat)
+	if liquidity.Sign() == 1 && totalSupply.Sign() == 1 {
+		amount0.SetFrac(new(big.Int).Mul(liquidity, reserve0), totalSupply)
+		amount1.SetFrac(new(big.Int).Mul(liquidity, reserve1), totalSupply)
+
+		currentUnits := new(big.Rat).Mul(new(big.Rat).SetInt(liquidity), rootKPerToken(reserve0, reserve1, totalSupply))
+		if currentUnits.Sign() == 1 {
+			feeShare.Sub(big.NewRat(1, 1), new(big.Rat).Quo(entryUnits, currentUnits))
+		}
+		if feeShare.Sign() == -1 {
+			feeShare.SetInt64(0)
+		}
+	}
+
+	price := new(big.Rat)
+	if reserve0.Sign() == 1 {
+		price.SetFrac(reserve1, reserve0)
+	}
+	holdValue := new(big.Rat).Add(new(big.Rat).Mul(costBasis0, price), costBasis1)
+	positionValue := new(big.Rat).Add(new(big.Rat).Mul(amount0, price), amount1)
+
+	impermanentLoss := new(big.Rat)
+	if holdValue.Sign() == 1 {
+		withoutFees := new(big.Rat).Mul(positionValue, new(big.Rat).Sub(big.NewRat(1, 1), feeShare))
+		impermanentLoss.Sub(new(big.Rat).Quo(withoutFees, holdValue), big.NewRat(1, 1))
+	}
+
+	return &SwapPoolPositionResponse{
+		Address:         address.String(),
+		PoolID:          uint64(swapper.GetID()),
+		Coin0:           coin0,
+		Coin1:           coin1,
+		Balance:         cState.Accounts().GetBalance(address, liquidityCoin.ID()).String(),
+		Liquidity:       liquidity.String(),
+		Amount0:         ratInt(amount0).String(),
+		Amount1:         ratInt(amount1).String(),
+		CostBasis0:      ratInt(costBasis0).String(),
+		CostBasis1:      ratInt(costBasis1).String(),
+		Fees0:           ratInt(new(big.Rat).Mul(amount0, feeShare)).String(),
+		Fees1:           ratInt(new(big.Rat).Mul(amount1, feeShare)).String(),
+		HoldValue:       ratInt(holdValue).String(),
+		PositionValue:   ratInt(positionValue).String(),
+		ProfitVsHold:    ratInt(new(big.Rat).Sub(positionValue, holdValue)).String(),
+		ImpermanentLoss: impermanentLoss.FloatString(precision),
+		History:         history,
+	}, nil
+}
+
+// rootKPerToken returns the square root of reserves product per liquidity token, it grows only by swap fees
+func rootKPerToken(reserve0, reserve1, totalSupply *big.Int) *big.Rat {
+	if totalSupply.Sign() != 1 {
+		return new(big.Rat)
+	}
+	return new(big.Rat).SetFrac(new(big.Int).Sqrt(new(big.Int).Mul(reserve0, reserve1)), totalSupply)
+}
+
+func stringInt(value string) *big.Int {
+	result, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return result
+}
+
+func stringRat(value string) *big.Rat {
+	return new(big.Rat).SetInt(stringInt(value))
+}
+
+func ratInt(value *big.Rat) *big.Int {
+	return new(big.Int).Quo(value.Num(), value.Denom())
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SwapPoolTWAPRequest is a request of the time-weighted average price of the pool, Pool is "coin0/coin1"
+type SwapPoolTWAPRequest struct {
+	Pool       string
+	FromHeight uint64
+	ToHeight   uint64
+}
+
+// SwapPoolTWAPResponse is the time-weighted average price of the pool at the end of blocks from FromHeight to ToHeight excluding the last one
+type SwapPoolTWAPResponse struct {
+	Coin0            uint64 `json:"coin0"`
+	Coin1            uint64 `json:"coin1"`
+	FromHeight       uint64 `json:"from_height"`
+	ToHeight         uint64 `json:"to_height"`
+	Price            string `json:"price"`
+	ReversePrice     string `json:"reverse_price"`
+	Price0Cumulative string `json:"price0_cumulative"`
+	Price1Cumulative string `json:"price1_cumulative"`
+}
+
+// SwapPoolTWAP returns the time-weighted average price of coin0 in coin1 and of coin1 in coin0 between two heights.
+// Unlike the spot price it can not be moved by a swap in the last block.
+func (s *Service) SwapPoolTWAP(ctx context.Context, req *SwapPoolTWAPRequest) (*SwapPoolTWAPResponse, error) {
+	coins := strings.Split(req.Pool, "/")
+	if len(coins) != 2 {
+		return nil, status.Error(codes.InvalidArgument, "pool should be set as coin0/coin1")
+	}
+	coin0, err := strconv.ParseUint(coins[0], 10, 32)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid coin0")
+	}
+	coin1, err := strconv.ParseUint(coins[1], 10, 32)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid coin1")
+	}
+	if coin0 == coin1 {
+		return nil, status.Error(codes.InvalidArgument, "equal coins id")
+	}
+
+	if req.FromHeight == 0 {
+		return nil, status.Error(codes.InvalidArgument, "from_height is required")
+	}
+	toHeight := req.ToHeight
+	if toHeight == 0 {
+		toHeight = s.blockchain.Height()
+	}
+	if req.FromHeight >= toHeight {
+		return nil, status.Error(codes.InvalidArgument, "from_height should be less than to_height")
+	}
+
+	fromPrice0, fromPrice1, fromHeight, err := s.priceCumulative(req.FromHeight, types.CoinID(coin0), types.CoinID(coin1))
+	if err != nil {
+		return nil, err
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	toPrice0, toPrice1, toAccumulatorHeight, err := s.priceCumulative(toHeight, types.CoinID(coin0), types.CoinID(coin1))
+	if err != nil {
+		return nil, err
+	}
+	if fromHeight == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "price accumulators of the pool are not started at height %d", req.FromHeight)
+	}
+
+	return &SwapPoolTWAPResponse{
+		Coin0:            coin0,
+		Coin1:            coin1,
+		FromHeight:       req.FromHeight,
+		ToHeight:         toHeight,
+		Price:            swap.CalcTWAP(fromPrice0, toPrice0, fromHeight, toAccumulatorHeight).FloatString(precision),
+		ReversePrice:     swap.CalcTWAP(fromPrice1, toPrice1, fromHeight, toAccumulatorHeight).FloatString(precision),
+		Price0Cumulative: toPrice0.String(),
+		Price1Cumulative: toPrice1.String(),
+	}, nil
+}
+
+func (s *Service) priceCumulative(height uint64, coin0, coin1 types.CoinID) (price0, price1 *big.Int, accumulatorHeight uint64, err error) {
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return nil, nil, 0, status.Error(codes.NotFound, err.Error())
+	}
+
+	swapper := cState.Swap().GetSwapper(coin0, coin1)
+	if !swapper.Exists() {
+		return nil, nil, 0, status.Error(codes.NotFound, "pair not found")
+	}
+
+	price0, price1, accumulatorHeight = swapper.PriceCumulative()
+	return price0, price1, accumulatorHeight, nil
+}
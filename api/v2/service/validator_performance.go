@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxValidatorHistoryRange is a max count of blocks in ValidatorHistory request
+const maxValidatorHistoryRange = 100000
+
+// ValidatorPerformanceRequest is a request of validator performance in range of heights, zero heights mean whole history
+type ValidatorPerformanceRequest struct {
+	PublicKey  string
+	FromHeight uint64
+	ToHeight   uint64
+}
+
+// ValidatorUptimeResponse is a summary of validator performance
+type ValidatorUptimeResponse struct {
+	PublicKey  string `json:"public_key"`
+	FromHeight uint64 `json:"from_height"`
+	ToHeight   uint64 `json:"to_height"`
+	Signed     uint64 `json:"signed"`
+	Missed     uint64 `json:"missed"`
+	Total      uint64 `json:"total"`
+	Uptime     string `json:"uptime"`
+	Jails      uint64 `json:"jails"`
+	Slashes    uint64 `json:"slashes"`
+}
+
+// ValidatorHistoryResponse is a list of validator missed blocks and events
+type ValidatorHistoryResponse struct {
+	PublicKey    string                           `json:"public_key"`
+	FromHeight   uint64                           `json:"from_height"`
+	ToHeight     uint64                           `json:"to_height"`
+	Signed       uint64                           `json:"signed"`
+	MissedBlocks []uint64                         `json:"missed_blocks"`
+	Events       []*ValidatorHistoryResponseEvent `json:"events"`
+}
+
+type ValidatorHistoryResponseEvent struct {
+	Height      uint64 `json:"height"`
+	Type        string `json:"type"`
+	JailedUntil uint64 `json:"jailed_until,omitempty"`
+}
+
+// ValidatorUptime returns count of signed and missed blocks, jails and slashes of validator and its uptime in percent.
+func (s *Service) ValidatorUptime(ctx context.Context, req *ValidatorPerformanceRequest) (*ValidatorUptimeResponse, error) {
+	pubKey, fromHeight, toHeight, err := s.validatorPerformanceRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := s.blockchain.GetPerformanceDB().Summary(pubKey, fromHeight, toHeight)
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	return &ValidatorUptimeResponse{
+		PublicKey:  pubKey.String(),
+		FromHeight: summary.FromHeight,
+		ToHeight:   summary.ToHeight,
+		Signed:     summary.Signed,
+		Missed:     summary.Missed,
+		Total:      summary.Total(),
+		Uptime:     summary.Uptime(),
+		Jails:      summary.Jails,
+		Slashes:    summary.Slashes,
+	}, nil
+}
+
+// ValidatorHistory returns missed blocks, jails, slashes and status changes of validator.
+func (s *Service) ValidatorHistory(ctx context.Context, req *ValidatorPerformanceRequest) (*ValidatorHistoryResponse, error) {
+	pubKey, fromHeight, toHeight, err := s.validatorPerformanceRange(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if toHeight-fromHeight >= maxValidatorHistoryRange {
+		if req.FromHeight == 0 {
+			fromHeight = toHeight - maxValidatorHistoryRange + 1
+		} else {
+			return nil, status.Errorf(codes.InvalidArgument, "range of heights must be less than %d blocks", maxValidatorHistoryRange)
+		}
+	}
+
+	performanceDB := s.blockchain.GetPerformanceDB()
+	response := &ValidatorHistoryResponse{
+		PublicKey:    pubKey.String(),
+		FromHeight:   fromHeight,
+		ToHeight:     toHeight,
+		MissedBlocks: []uint64{},
+		Events:       []*ValidatorHistoryResponseEvent{},
+	}
+	for _, block := range performanceDB.Blocks(pubKey, fromHeight, toHeight) {
+		if block.Signed {
+			response.Signed++
+			continue
+		}
+		response.MissedBlocks = append(response.MissedBlocks, block.Height)
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	for _, event := range performanceDB.Events(pubKey, fromHeight, toHeight) {
+		response.Events = append(response.Events, &ValidatorHistoryResponseEvent{
+			Height:      event.Height,
+			Type:        event.Type,
+			JailedUntil: event.JailedUntil,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *Service) validatorPerformanceRange(req *ValidatorPerformanceRequest) (types.Pubkey, uint64, uint64, error) {
+	if !strings.HasPrefix(req.PublicKey, "Mp") {
+		return types.Pubkey{}, 0, 0, status.Error(codes.InvalidArgument, "public key don't has prefix 'Mp'")
+	}
+
+	toHeight := req.ToHeight
+	if currentHeight := s.blockchain.Height(); toHeight == 0 || toHeight > currentHeight {
+		toHeight = currentHeight
+	}
+
+	fromHeight := req.FromHeight
+	if initialHeight := s.blockchain.InitialHeight(); fromHeight < initialHeight {
+		fromHeight = initialHeight
+	}
+
+	if fromHeight > toHeight {
+		return types.Pubkey{}, 0, 0, status.Error(codes.InvalidArgument, "from_height is greater than to_height")
+	}
+
+	return types.HexToPubkey(req.PublicKey), fromHeight, toHeight, nil
+}
@@ -104,6 +104,7 @@ func Run(srv *service.Service, addrGRPC, addrAPI string, logger log.Logger) erro
 	mux := http.NewServeMux()
 	const openapi = "/v2/openapi-ui/"
 	_ = serveOpenAPI(openapi, mux)
+	registerExtensions(mux, srv)
 	mux.HandleFunc("/v2/", func(writer http.ResponseWriter, request *http.Request) {
 		if request.URL.Path == "/v2/" {
 			http.Redirect(writer, request, openapi, 302)
@@ -24,6 +24,7 @@ type ManagerServiceClient interface {
 	PruneBlocks(ctx context.Context, in *PruneBlocksRequest, opts ...grpc.CallOption) (ManagerService_PruneBlocksClient, error)
 	DealPeer(ctx context.Context, in *DealPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Dashboard(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (ManagerService_DashboardClient, error)
+	UpgradePlan(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UpgradePlanResponse, error)
 }
 
 type managerServiceClient struct {
@@ -134,6 +135,15 @@ func (x *managerServiceDashboardClient) Recv() (*DashboardResponse, error) {
 	return m, nil
 }
 
+func (c *managerServiceClient) UpgradePlan(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UpgradePlanResponse, error) {
+	out := new(UpgradePlanResponse)
+	err := c.cc.Invoke(ctx, "/cli_pb.ManagerService/UpgradePlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ManagerServiceServer is the server API for ManagerService service.
 // All implementations must embed UnimplementedManagerServiceServer
 // for forward compatibility
@@ -144,6 +154,7 @@ type ManagerServiceServer interface {
 	PruneBlocks(*PruneBlocksRequest, ManagerService_PruneBlocksServer) error
 	DealPeer(context.Context, *DealPeerRequest) (*emptypb.Empty, error)
 	Dashboard(*emptypb.Empty, ManagerService_DashboardServer) error
+	UpgradePlan(context.Context, *emptypb.Empty) (*UpgradePlanResponse, error)
 	mustEmbedUnimplementedManagerServiceServer()
 }
 
@@ -169,6 +180,9 @@ func (UnimplementedManagerServiceServer) DealPeer(context.Context, *DealPeerRequ
 func (UnimplementedManagerServiceServer) Dashboard(*emptypb.Empty, ManagerService_DashboardServer) error {
 	return status.Errorf(codes.Unimplemented, "method Dashboard not implemented")
 }
+func (UnimplementedManagerServiceServer) UpgradePlan(context.Context, *emptypb.Empty) (*UpgradePlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpgradePlan not implemented")
+}
 func (UnimplementedManagerServiceServer) mustEmbedUnimplementedManagerServiceServer() {}
 
 // UnsafeManagerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -296,6 +310,24 @@ func (x *managerServiceDashboardServer) Send(m *DashboardResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ManagerService_UpgradePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManagerServiceServer).UpgradePlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cli_pb.ManagerService/UpgradePlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManagerServiceServer).UpgradePlan(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ManagerService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cli_pb.ManagerService",
 	HandlerType: (*ManagerServiceServer)(nil),
@@ -316,6 +348,10 @@ var _ManagerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DealPeer",
 			Handler:    _ManagerService_DealPeer_Handler,
 		},
+		{
+			MethodName: "UpgradePlan",
+			Handler:    _ManagerService_UpgradePlan_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
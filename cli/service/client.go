@@ -184,6 +184,15 @@ func NewCLI(socketPath string) (interface {
 			},
 			Action: netInfoCMD(client),
 		},
+		{
+			Name:    "upgrade_plan",
+			Aliases: []string{"up"},
+			Usage:   "display planned halts and network updates",
+			Flags: []cli.Flag{
+				jsonFlag,
+			},
+			Action: upgradePlanCMD(client),
+		},
 		{
 			Name:    "dashboard",
 			Aliases: []string{"db"},
@@ -482,3 +491,61 @@ func dealPeerCMD(client pb.ManagerServiceClient) func(c *cli.Context) error {
 		return nil
 	}
 }
+
+func upgradePlanCMD(client pb.ManagerServiceClient) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		response, err := client.UpgradePlan(c.Context, &empty.Empty{})
+		if err != nil {
+			return err
+		}
+		if c.Bool("json") {
+			bb, err := protojson.Marshal(response)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bb))
+			return nil
+		}
+
+		fmt.Printf("Current height: %d, version: %s%s\n", response.CurrentHeight, response.CurrentVersion, supportedLabel(response.CurrentVersionSupported))
+		averageBlockTime := time.Duration(response.AverageBlockTime)
+		if averageBlockTime > 0 {
+			fmt.Printf("Average block time: %s\n", averageBlockTime.String())
+		} else {
+			fmt.Println("Average block time: unknown")
+		}
+		if len(response.Items) == 0 {
+			fmt.Println("No planned halts and updates")
+			return nil
+		}
+
+		for _, item := range response.Items {
+			estimate := "unknown"
+			if item.EstimatedTime != nil {
+				estimate = item.EstimatedTime.AsTime().Local().Format(time.RFC1123)
+			}
+			switch item.Type {
+			case pb.UpgradePlanResponse_Item_Update:
+				fmt.Printf("%d (~%s): update to %s%s\n", item.Height, estimate, item.Version, supportedLabel(item.Supported))
+			default:
+				source := fmt.Sprintf("voted %s of %s", item.VotedPower, item.TotalPower)
+				if item.FromConfig {
+					source = "halt_height in config"
+				}
+				result := "pending"
+				if item.Consensus {
+					result = "consensus reached"
+				}
+				fmt.Printf("%d (~%s): halt, %s, %s\n", item.Height, estimate, source, result)
+			}
+		}
+		return nil
+	}
+}
+
+func supportedLabel(supported bool) string {
+	if supported {
+		return ""
+	}
+	return " (not supported by this binary, update your node)"
+}
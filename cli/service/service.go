@@ -19,6 +19,7 @@ import (
 	typesTM "github.com/tendermint/tendermint/types"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	"math/big"
 	"runtime"
@@ -306,6 +307,50 @@ func (m *managerServer) DealPeer(_ context.Context, req *pb.DealPeerRequest) (*e
 	return res, nil
 }
 
+// UpgradePlan returns halts and accepted network updates planned after the current height
+// with estimated time of reaching their heights by the average time of the latest blocks
+func (m *managerServer) UpgradePlan(context.Context, *empty.Empty) (*pb.UpgradePlanResponse, error) {
+	result, err := m.tmRPC.Status(context.Background())
+	if err != nil {
+		return new(pb.UpgradePlanResponse), status.Error(codes.Internal, err.Error())
+	}
+
+	height := m.blockchain.Height()
+	currentVersion := m.blockchain.CurrentVersion()
+	averageBlockTime := m.blockchain.AverageBlockTime()
+	latestBlockTime := result.SyncInfo.LatestBlockTime
+	response := &pb.UpgradePlanResponse{
+		CurrentHeight:           height,
+		CurrentVersion:          currentVersion,
+		CurrentVersionSupported: m.blockchain.IsKnownUpdate(currentVersion),
+		LatestBlockTime:         timestamppb.New(latestBlockTime),
+		AverageBlockTime:        int64(averageBlockTime),
+		Items:                   []*pb.UpgradePlanResponse_Item{},
+	}
+
+	for _, upgrade := range m.blockchain.UpgradePlan() {
+		item := &pb.UpgradePlanResponse_Item{
+			Height:     upgrade.Height,
+			Type:       pb.UpgradePlanResponse_Item_Halt,
+			Version:    upgrade.Version,
+			VotedPower: upgrade.VotedPower.String(),
+			TotalPower: upgrade.TotalPower.String(),
+			Consensus:  upgrade.Consensus,
+			FromConfig: upgrade.FromConfig,
+			Supported:  upgrade.Supported,
+		}
+		if upgrade.Type == minter.UpgradeVersion {
+			item.Type = pb.UpgradePlanResponse_Item_Update
+		}
+		if averageBlockTime > 0 {
+			item.EstimatedTime = timestamppb.New(latestBlockTime.Add(time.Duration(upgrade.Height-height) * averageBlockTime))
+		}
+		response.Items = append(response.Items, item)
+	}
+
+	return response, nil
+}
+
 func maxPeerHeight(sw *p2p.Switch) int64 {
 	var max int64
 	for _, peer := range sw.Peers().List() {
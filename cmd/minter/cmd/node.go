@@ -82,6 +82,10 @@ func runNode(cmd *cobra.Command) error {
 		if err != nil {
 			return err
 		}
+		_, err = storages.InitPerformanceLevelDB("data/performance", minter.GetDbOpts(1024))
+		if err != nil {
+			return err
+		}
 	}
 	_, err = storages.InitStateLevelDB("data/state", minter.GetDbOpts(cfg.StateMemAvailable))
 	if err != nil {
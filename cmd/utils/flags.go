@@ -8,11 +8,12 @@ import (
 )
 
 type Storage struct {
-	minterHome   string
-	minterConfig string
-	eventDB      db.DB
-	stateDB      db.DB
-	snapshotDB   db.DB
+	minterHome    string
+	minterConfig  string
+	eventDB       db.DB
+	stateDB       db.DB
+	snapshotDB    db.DB
+	performanceDB db.DB
 }
 
 func (s *Storage) SetMinterConfig(minterConfig string) {
@@ -35,8 +36,12 @@ func (s *Storage) SnapshotDB() db.DB {
 	return s.snapshotDB
 }
 
+func (s *Storage) PerformanceDB() db.DB {
+	return s.performanceDB
+}
+
 func NewStorage(home string, config string) *Storage {
-	return &Storage{eventDB: db.NewMemDB(), stateDB: db.NewMemDB(), snapshotDB: db.NewMemDB(), minterConfig: config, minterHome: home}
+	return &Storage{eventDB: db.NewMemDB(), stateDB: db.NewMemDB(), snapshotDB: db.NewMemDB(), performanceDB: db.NewMemDB(), minterConfig: config, minterHome: home}
 }
 
 func (s *Storage) InitSnapshotLevelDB(name string, opts *opt.Options) (db.DB, error) {
@@ -57,6 +62,15 @@ func (s *Storage) InitEventLevelDB(name string, opts *opt.Options) (db.DB, error
 	return s.eventDB, nil
 }
 
+func (s *Storage) InitPerformanceLevelDB(name string, opts *opt.Options) (db.DB, error) {
+	levelDB, err := db.NewGoLevelDBWithOpts(name, s.GetMinterHome(), opts)
+	if err != nil {
+		return nil, err
+	}
+	s.performanceDB = levelDB
+	return s.performanceDB, nil
+}
+
 func (s *Storage) InitStateLevelDB(name string, opts *opt.Options) (db.DB, error) {
 	levelDB, err := db.NewGoLevelDBWithOpts(name, s.GetMinterHome(), opts)
 	if err != nil {
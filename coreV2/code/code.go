@@ -30,6 +30,10 @@ const (
 	WrongUpdateVersionName       uint32 = 122
 	WrongDueHeight               uint32 = 123
 	Unavailable                  uint32 = 124
+	WrongParameterValue          uint32 = 125
+	TreasuryProposalNotExists    uint32 = 126
+	TooManyTreasuryProposals     uint32 = 127
+	InsufficientAllowance        uint32 = 128
 
 	// coin creation
 	CoinHasNotReserve uint32 = 200
@@ -43,6 +47,9 @@ const (
 	// recreate coin
 	IsNotOwnerOfCoin uint32 = 206
 
+	// coin metadata
+	WrongCoinMetadata uint32 = 207
+
 	// convert
 	CrossConvert              uint32 = 301
 	MaximumValueToSellReached uint32 = 302
@@ -74,6 +81,7 @@ const (
 	TooHighGasPrice  uint32 = 504
 	WrongGasCoin     uint32 = 505
 	TooLongNonce     uint32 = 506
+	CheckRevoked     uint32 = 507
 
 	// multisig
 	IncorrectWeights                  uint32 = 601
@@ -102,11 +110,27 @@ const (
 	IsNotOwnerOfOrder            uint32 = 712
 	WrongOrderPrice              uint32 = 713
 	WrongOrderVolume             uint32 = 714
+	TooManyStopOrders            uint32 = 715
+	WrongSwapFeeTier             uint32 = 716
+	MiningProgramNotExists       uint32 = 717
+	WrongMiningProgram           uint32 = 718
+	InsufficientMiningStake      uint32 = 719
+	NoMiningReward               uint32 = 720
 
 	// emission coin
 	CoinIsNotToken  uint32 = 800
 	CoinNotMintable uint32 = 801
 	CoinNotBurnable uint32 = 802
+
+	// freezable token
+	CoinNotFreezable uint32 = 803
+	AddressFrozen    uint32 = 804
+	AddressNotFrozen uint32 = 805
+
+	// airdrop
+	AirdropNotExists      uint32 = 900
+	WrongAirdropProof     uint32 = 901
+	AirdropAlreadyClaimed uint32 = 902
 )
 
 func NewInsufficientLiquidityBalance(liquidity, amount0, coin0, amount1, coin1, requestedLiquidity string) *insufficientLiquidityBalance {
@@ -715,6 +739,15 @@ func NewCheckUsed() *checkUsed {
 	return &checkUsed{Code: strconv.Itoa(int(CheckUsed))}
 }
 
+type checkRevoked struct {
+	Code   string `json:"code,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+}
+
+func NewCheckRevoked(issuer string) *checkRevoked {
+	return &checkRevoked{Code: strconv.Itoa(int(CheckRevoked)), Issuer: issuer}
+}
+
 type notEnoughMultisigVotes struct {
 	Code        string `json:"code,omitempty"`
 	NeededVotes string `json:"needed_votes,omitempty"`
@@ -917,6 +950,186 @@ func NewWrongOrderVolume(v0, v1 string) *wrongOrderVolume {
 	}
 }
 
+type tooManyStopOrders struct {
+	Code     string `json:"code,omitempty"`
+	Address  string `json:"address,omitempty"`
+	PoolID   string `json:"pool_id,omitempty"`
+	MaxCount string `json:"max_count,omitempty"`
+}
+
+func NewTooManyStopOrders(address string, poolID uint32, maxCount int) *tooManyStopOrders {
+	return &tooManyStopOrders{Code: strconv.Itoa(int(TooManyStopOrders)), Address: address, PoolID: strconv.Itoa(int(poolID)), MaxCount: strconv.Itoa(maxCount)}
+}
+
+type wrongSwapFeeTier struct {
+	Code    string `json:"code,omitempty"`
+	FeeTier string `json:"fee_tier,omitempty"`
+	Tiers   string `json:"tiers,omitempty"`
+}
+
+func NewWrongSwapFeeTier(feeTier string, tiers string) *wrongSwapFeeTier {
+	return &wrongSwapFeeTier{Code: strconv.Itoa(int(WrongSwapFeeTier)), FeeTier: feeTier, Tiers: tiers}
+}
+
+type miningProgramNotExists struct {
+	Code string `json:"code,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+func NewMiningProgramNotExists(id uint32) *miningProgramNotExists {
+	return &miningProgramNotExists{Code: strconv.Itoa(int(MiningProgramNotExists)), ID: strconv.Itoa(int(id))}
+}
+
+type wrongMiningProgram struct {
+	Code          string `json:"code,omitempty"`
+	LiquidityCoin string `json:"liquidity_coin,omitempty"`
+	StartHeight   string `json:"start_height,omitempty"`
+	EndHeight     string `json:"end_height,omitempty"`
+}
+
+func NewWrongMiningProgram(liquidityCoin string, startHeight, endHeight string) *wrongMiningProgram {
+	return &wrongMiningProgram{Code: strconv.Itoa(int(WrongMiningProgram)), LiquidityCoin: liquidityCoin, StartHeight: startHeight, EndHeight: endHeight}
+}
+
+type insufficientMiningStake struct {
+	Code        string `json:"code,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Stake       string `json:"stake,omitempty"`
+	NeededValue string `json:"needed_value,omitempty"`
+}
+
+func NewInsufficientMiningStake(id uint32, stake string, neededValue string) *insufficientMiningStake {
+	return &insufficientMiningStake{Code: strconv.Itoa(int(InsufficientMiningStake)), ID: strconv.Itoa(int(id)), Stake: stake, NeededValue: neededValue}
+}
+
+type noMiningReward struct {
+	Code    string `json:"code,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+func NewNoMiningReward(id uint32, address string) *noMiningReward {
+	return &noMiningReward{Code: strconv.Itoa(int(NoMiningReward)), ID: strconv.Itoa(int(id)), Address: address}
+}
+
+type wrongParameterValue struct {
+	Code      string `json:"code,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Min       string `json:"min,omitempty"`
+	Max       string `json:"max,omitempty"`
+}
+
+func NewWrongParameterValue(parameter string, value string, min string, max string) *wrongParameterValue {
+	return &wrongParameterValue{Code: strconv.Itoa(int(WrongParameterValue)), Parameter: parameter, Value: value, Min: min, Max: max}
+}
+
+type wrongCoinMetadata struct {
+	Code      string `json:"code,omitempty"`
+	Field     string `json:"field,omitempty"`
+	Length    string `json:"length,omitempty"`
+	MaxLength string `json:"max_length,omitempty"`
+}
+
+func NewWrongCoinMetadata(field string, length string, maxLength string) *wrongCoinMetadata {
+	return &wrongCoinMetadata{Code: strconv.Itoa(int(WrongCoinMetadata)), Field: field, Length: length, MaxLength: maxLength}
+}
+
+type treasuryProposalNotExists struct {
+	Code string `json:"code,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+func NewTreasuryProposalNotExists(id uint32) *treasuryProposalNotExists {
+	return &treasuryProposalNotExists{Code: strconv.Itoa(int(TreasuryProposalNotExists)), ID: strconv.Itoa(int(id))}
+}
+
+type tooManyTreasuryProposals struct {
+	Code     string `json:"code,omitempty"`
+	Height   string `json:"height,omitempty"`
+	MaxCount string `json:"max_count,omitempty"`
+}
+
+func NewTooManyTreasuryProposals(height uint64, maxCount int) *tooManyTreasuryProposals {
+	return &tooManyTreasuryProposals{Code: strconv.Itoa(int(TooManyTreasuryProposals)), Height: strconv.FormatUint(height, 10), MaxCount: strconv.Itoa(maxCount)}
+}
+
+type insufficientAllowance struct {
+	Code        string `json:"code,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	Spender     string `json:"spender,omitempty"`
+	NeededValue string `json:"needed_value,omitempty"`
+	Allowance   string `json:"allowance,omitempty"`
+	CoinSymbol  string `json:"coin_symbol,omitempty"`
+	CoinId      string `json:"coin_id,omitempty"`
+}
+
+func NewInsufficientAllowance(owner string, spender string, neededValue string, allowance string, coinSymbol string, coinId string) *insufficientAllowance {
+	return &insufficientAllowance{Code: strconv.Itoa(int(InsufficientAllowance)), Owner: owner, Spender: spender, NeededValue: neededValue, Allowance: allowance, CoinSymbol: coinSymbol, CoinId: coinId}
+}
+
+type coinNotFreezable struct {
+	Code       string `json:"code,omitempty"`
+	CoinSymbol string `json:"coin_symbol,omitempty"`
+	CoinId     string `json:"coin_id,omitempty"`
+}
+
+func NewCoinNotFreezable(coinSymbol string, coinId string) *coinNotFreezable {
+	return &coinNotFreezable{Code: strconv.Itoa(int(CoinNotFreezable)), CoinSymbol: coinSymbol, CoinId: coinId}
+}
+
+type addressFrozen struct {
+	Code       string `json:"code,omitempty"`
+	Address    string `json:"address,omitempty"`
+	CoinSymbol string `json:"coin_symbol,omitempty"`
+	CoinId     string `json:"coin_id,omitempty"`
+}
+
+func NewAddressFrozen(address string, coinSymbol string, coinId string) *addressFrozen {
+	return &addressFrozen{Code: strconv.Itoa(int(AddressFrozen)), Address: address, CoinSymbol: coinSymbol, CoinId: coinId}
+}
+
+type addressNotFrozen struct {
+	Code       string `json:"code,omitempty"`
+	Address    string `json:"address,omitempty"`
+	CoinSymbol string `json:"coin_symbol,omitempty"`
+	CoinId     string `json:"coin_id,omitempty"`
+}
+
+func NewAddressNotFrozen(address string, coinSymbol string, coinId string) *addressNotFrozen {
+	return &addressNotFrozen{Code: strconv.Itoa(int(AddressNotFrozen)), Address: address, CoinSymbol: coinSymbol, CoinId: coinId}
+}
+
+type airdropNotExists struct {
+	Code string `json:"code,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+func NewAirdropNotExists(id uint32) *airdropNotExists {
+	return &airdropNotExists{Code: strconv.Itoa(int(AirdropNotExists)), ID: strconv.Itoa(int(id))}
+}
+
+type wrongAirdropProof struct {
+	Code    string `json:"code,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Address string `json:"address,omitempty"`
+	Value   string `json:"value,omitempty"`
+}
+
+func NewWrongAirdropProof(id uint32, address string, value string) *wrongAirdropProof {
+	return &wrongAirdropProof{Code: strconv.Itoa(int(WrongAirdropProof)), ID: strconv.Itoa(int(id)), Address: address, Value: value}
+}
+
+type airdropAlreadyClaimed struct {
+	Code    string `json:"code,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Address string `json:"address,omitempty"`
+}
+
+func NewAirdropAlreadyClaimed(id uint32, address string) *airdropAlreadyClaimed {
+	return &airdropAlreadyClaimed{Code: strconv.Itoa(int(AirdropAlreadyClaimed)), ID: strconv.Itoa(int(id)), Address: address}
+}
+
 type unbondBlocked struct {
 	Code               string `json:"code,omitempty"`
 	BlockedUntilHeight string `json:"blocked_until_height"`
@@ -0,0 +1,70 @@
+package events
+
+import (
+	"encoding/binary"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	db "github.com/tendermint/tm-db"
+)
+
+const liquidityChangesPrefix = "liquidityOf"
+
+func pathLiquidityChanges(poolID, addressID uint32) []byte {
+	key := append([]byte(liquidityChangesPrefix), uint32ToBytes(poolID)...)
+	return append(key, uint32ToBytes(addressID)...)
+}
+
+// pathLiquidityChange is a key of the change in the index, changes of the provider in the pool are ordered by height
+func pathLiquidityChange(poolID, addressID, height uint32, index uint16) []byte {
+	key := append(pathLiquidityChanges(poolID, addressID), uint32ToBytes(height)...)
+	return append(key, uint16ToBytes(index)...)
+}
+
+func (store *eventsStore) saveLiquidityChanges(height uint32, changes []*liquidityChanged) error {
+	for i, change := range changes {
+		bytes, err := tmjson.Marshal(change)
+		if err != nil {
+			return err
+		}
+		if err := store.db.Set(pathLiquidityChange(change.PoolID, change.AddressID, height, uint16(i)), bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadLiquidityChanges returns liquidity added to the pool and removed from it by the address ordered by height
+func (store *eventsStore) LoadLiquidityChanges(poolID uint32, address types.Address) []*LiquidityChangedEvent {
+	store.loadCache()
+
+	store.RLock()
+	defer store.RUnlock()
+
+	addressID, ok := store.addressID[address]
+	if !ok {
+		return nil
+	}
+
+	start := pathLiquidityChanges(poolID, addressID)
+	iterator, err := store.db.Iterator(start, pathLiquidityChanges(poolID, addressID+1))
+	if err != nil {
+		panic(err)
+	}
+	defer func(iterator db.Iterator) {
+		_ = iterator.Close()
+	}(iterator)
+
+	var changes []*LiquidityChangedEvent
+	for ; iterator.Valid(); iterator.Next() {
+		var change liquidityChanged
+		if err := tmjson.Unmarshal(iterator.Value(), &change); err != nil {
+			panic(err)
+		}
+		event := change.compile(address).(*LiquidityChangedEvent)
+		event.Height = uint64(binary.BigEndian.Uint32(iterator.Key()[len(start):]))
+		changes = append(changes, event)
+	}
+
+	return changes
+}
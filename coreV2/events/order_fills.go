@@ -0,0 +1,83 @@
+package events
+
+import (
+	"encoding/binary"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	db "github.com/tendermint/tm-db"
+)
+
+const orderFillsPrefix = "fillsOfOrder"
+const addressOrderFillsPrefix = "fillsOfAddress"
+
+// pathOrderFill is a key of the fill in the index, fills of the same order or address are ordered by height
+func pathOrderFill(prefix string, id uint32, height uint32, index uint16) []byte {
+	key := append([]byte(prefix), uint32ToBytes(id)...)
+	key = append(key, uint32ToBytes(height)...)
+	return append(key, uint16ToBytes(index)...)
+}
+
+func (store *eventsStore) saveOrderFills(height uint32, fills []*orderFilled) error {
+	for i, fill := range fills {
+		bytes, err := tmjson.Marshal(fill)
+		if err != nil {
+			return err
+		}
+		if err := store.db.Set(pathOrderFill(orderFillsPrefix, fill.ID, height, uint16(i)), bytes); err != nil {
+			return err
+		}
+		if err := store.db.Set(pathOrderFill(addressOrderFillsPrefix, fill.AddressID, height, uint16(i)), bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadOrderFills returns all fills of the limit order ordered by height
+func (store *eventsStore) LoadOrderFills(id uint32) []*OrderFilledEvent {
+	store.loadCache()
+
+	return store.loadOrderFills(orderFillsPrefix, id)
+}
+
+// LoadAddressOrderFills returns fills of all limit orders of the address ordered by height
+func (store *eventsStore) LoadAddressOrderFills(address types.Address) []*OrderFilledEvent {
+	store.loadCache()
+
+	store.RLock()
+	id, ok := store.addressID[address]
+	store.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	return store.loadOrderFills(addressOrderFillsPrefix, id)
+}
+
+func (store *eventsStore) loadOrderFills(prefix string, id uint32) []*OrderFilledEvent {
+	start := append([]byte(prefix), uint32ToBytes(id)...)
+	iterator, err := store.db.Iterator(start, append([]byte(prefix), uint32ToBytes(id+1)...))
+	if err != nil {
+		panic(err)
+	}
+	defer func(iterator db.Iterator) {
+		_ = iterator.Close()
+	}(iterator)
+
+	store.RLock()
+	defer store.RUnlock()
+
+	var fills []*OrderFilledEvent
+	for ; iterator.Valid(); iterator.Next() {
+		var fill orderFilled
+		if err := tmjson.Unmarshal(iterator.Value(), &fill); err != nil {
+			panic(err)
+		}
+		event := fill.compile(store.idAddress[fill.AddressID]).(*OrderFilledEvent)
+		event.Height = uint64(binary.BigEndian.Uint32(iterator.Key()[len(start):]))
+		fills = append(fills, event)
+	}
+
+	return fills
+}
@@ -19,6 +19,13 @@ func init() {
 	tmjson.RegisterType(&move{}, "move")
 	tmjson.RegisterType(&orderExpired{}, "orderExpired")
 	tmjson.RegisterType(&unlock{}, "unlock")
+	tmjson.RegisterType(&stopOrderActivated{}, "stopOrderActivated")
+	tmjson.RegisterType(&stopOrderExpired{}, "stopOrderExpired")
+	tmjson.RegisterType(&orderFilled{}, "orderFilled")
+	tmjson.RegisterType(&liquidityChanged{}, "liquidityChanged")
+	tmjson.RegisterType(&miningReward{}, "miningReward")
+	tmjson.RegisterType(&treasuryProposal{}, "treasuryProposal")
+	tmjson.RegisterType(&airdropExpired{}, "airdropExpired")
 
 	tmjson.RegisterType(&RewardEvent{}, TypeRewardEvent)
 	tmjson.RegisterType(&SlashEvent{}, TypeSlashEvent)
@@ -32,12 +39,23 @@ func init() {
 	tmjson.RegisterType(&RemoveCandidateEvent{}, TypeRemoveCandidateEvent)
 	tmjson.RegisterType(&UpdatedBlockRewardEvent{}, TypeUpdatedBlockRewardEvent)
 	tmjson.RegisterType(&UnlockEvent{}, TypeUnlockEvent)
+	tmjson.RegisterType(&StopOrderActivatedEvent{}, TypeStopOrderActivatedEvent)
+	tmjson.RegisterType(&StopOrderExpiredEvent{}, TypeStopOrderExpiredEvent)
+	tmjson.RegisterType(&OrderFilledEvent{}, TypeOrderFilledEvent)
+	tmjson.RegisterType(&LiquidityChangedEvent{}, TypeLiquidityChangedEvent)
+	tmjson.RegisterType(&MiningRewardEvent{}, TypeMiningRewardEvent)
+	tmjson.RegisterType(&UpdateParameterEvent{}, TypeUpdateParameterEvent)
+	tmjson.RegisterType(&TreasuryProposalEvent{}, TypeTreasuryProposalEvent)
+	tmjson.RegisterType(&AirdropExpiredEvent{}, TypeAirdropExpiredEvent)
 }
 
 // IEventsDB is an interface of Events
 type IEventsDB interface {
 	AddEvent(event Event)
 	LoadEvents(height uint32) Events
+	LoadOrderFills(id uint32) []*OrderFilledEvent
+	LoadAddressOrderFills(address types.Address) []*OrderFilledEvent
+	LoadLiquidityChanges(poolID uint32, address types.Address) []*LiquidityChangedEvent
 	CommitEvents(uint32) error
 	Close() error
 }
@@ -48,8 +66,33 @@ type MockEvents struct {
 
 func (e *MockEvents) AddEvent(event Event)            { e.evnts = append(e.evnts, event) }
 func (e *MockEvents) LoadEvents(height uint32) Events { return e.evnts }
-func (e *MockEvents) CommitEvents(uint32) error       { return nil }
-func (e *MockEvents) Close() error                    { return nil }
+func (e *MockEvents) LoadOrderFills(id uint32) []*OrderFilledEvent {
+	return e.orderFills(func(fill *OrderFilledEvent) bool { return fill.ID == uint64(id) })
+}
+func (e *MockEvents) LoadAddressOrderFills(address types.Address) []*OrderFilledEvent {
+	return e.orderFills(func(fill *OrderFilledEvent) bool { return fill.Address == address })
+}
+func (e *MockEvents) LoadLiquidityChanges(poolID uint32, address types.Address) []*LiquidityChangedEvent {
+	var changes []*LiquidityChangedEvent
+	for _, event := range e.evnts {
+		if change, ok := event.(*LiquidityChangedEvent); ok && change.PoolID == uint64(poolID) && change.Address == address {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+func (e *MockEvents) CommitEvents(uint32) error { return nil }
+func (e *MockEvents) Close() error              { return nil }
+
+func (e *MockEvents) orderFills(filter func(fill *OrderFilledEvent) bool) []*OrderFilledEvent {
+	var fills []*OrderFilledEvent
+	for _, event := range e.evnts {
+		if fill, ok := event.(*OrderFilledEvent); ok && filter(fill) {
+			fills = append(fills, fill)
+		}
+	}
+	return fills
+}
 
 type eventsStore struct {
 	sync.RWMutex
@@ -130,6 +173,14 @@ func (store *eventsStore) LoadEvents(height uint32) Events {
 			resultEvents = append(resultEvents, stake.compile(p, store.idAddress[stake.addressID()]))
 		} else if c, ok := compactEvent.(*jail); ok {
 			resultEvents = append(resultEvents, c.compile(store.idPubKey[c.pubKeyID()]))
+		} else if c, ok := compactEvent.(*orderFilled); ok {
+			fill := c.compile(store.idAddress[c.addressID()]).(*OrderFilledEvent)
+			fill.Height = uint64(height)
+			resultEvents = append(resultEvents, fill)
+		} else if c, ok := compactEvent.(*liquidityChanged); ok {
+			change := c.compile(store.idAddress[c.addressID()]).(*LiquidityChangedEvent)
+			change.Height = uint64(height)
+			resultEvents = append(resultEvents, change)
 		} else if c, ok := compactEvent.(address); ok {
 			resultEvents = append(resultEvents, c.compile(store.idAddress[c.addressID()]))
 		} else if c, ok := compactEvent.(*removeCandidate); ok {
@@ -152,7 +203,21 @@ func (store *eventsStore) CommitEvents(height uint32) error {
 	store.pending.Lock()
 	defer store.pending.Unlock()
 	var data []compact
+	var fills []*orderFilled
+	var liquidityChanges []*liquidityChanged
 	for _, item := range store.pending.items {
+		if fill, ok := item.(*OrderFilledEvent); ok {
+			c := fill.convert(store.saveAddress(fill.address())).(*orderFilled)
+			fills = append(fills, c)
+			data = append(data, c)
+			continue
+		}
+		if change, ok := item.(*LiquidityChangedEvent); ok {
+			c := change.convert(store.saveAddress(change.address())).(*liquidityChanged)
+			liquidityChanges = append(liquidityChanges, c)
+			data = append(data, c)
+			continue
+		}
 		if stake, ok := item.(Stake); ok {
 			key := stake.validatorPubKey()
 			address := store.saveAddress(stake.address())
@@ -192,6 +257,12 @@ func (store *eventsStore) CommitEvents(height uint32) error {
 	if err := store.db.Set(uint32ToBytes(height), bytes); err != nil {
 		return err
 	}
+	if err := store.saveOrderFills(height, fills); err != nil {
+		return err
+	}
+	if err := store.saveLiquidityChanges(height, liquidityChanges); err != nil {
+		return err
+	}
 	store.pending.items = Events{}
 	return nil
 }
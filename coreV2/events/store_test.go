@@ -295,3 +295,132 @@ func TestIEventsNil(t *testing.T) {
 		t.Fatalf("not nil")
 	}
 }
+
+func TestIEventsDB_OrderFills(t *testing.T) {
+	store := NewEventsStore(db.NewMemDB())
+	owner := types.HexToAddress("Mx04bea23efb744dc93b4fda4c20bf4a21c6e195f1")
+
+	store.AddEvent(&OrderFilledEvent{
+		ID:            7,
+		Address:       owner,
+		TxHash:        "Mt4a2a6d5b5f3b5c4b0e0d0a2b7ec7c4ff5b1b9e1a8b1a2c6a2e5c1d1e1f1a2b3c",
+		CoinToSell:    1,
+		ValueSold:     "100",
+		CoinToBuy:     0,
+		ValueBought:   "50",
+		RemainingSell: "900",
+		RemainingBuy:  "450",
+	})
+	if err := store.CommitEvents(10); err != nil {
+		t.Fatal(err)
+	}
+	store.AddEvent(&OrderFilledEvent{
+		ID:            7,
+		Address:       owner,
+		CoinToSell:    1,
+		ValueSold:     "900",
+		CoinToBuy:     0,
+		ValueBought:   "450",
+		RemainingSell: "0",
+		RemainingBuy:  "0",
+	})
+	store.AddEvent(&OrderFilledEvent{
+		ID:            8,
+		Address:       types.HexToAddress("Mx18467bbb64a8edf890201d526c35957d82be3d95"),
+		CoinToSell:    0,
+		ValueSold:     "1",
+		CoinToBuy:     1,
+		ValueBought:   "2",
+		RemainingSell: "3",
+		RemainingBuy:  "6",
+	})
+	if err := store.CommitEvents(12); err != nil {
+		t.Fatal(err)
+	}
+
+	fills := store.LoadOrderFills(7)
+	if len(fills) != 2 {
+		t.Fatalf("want 2 fills of the order, got %d", len(fills))
+	}
+	if fills[0].Height != 10 || fills[0].TxHash != "Mt4a2a6d5b5f3b5c4b0e0d0a2b7ec7c4ff5b1b9e1a8b1a2c6a2e5c1d1e1f1a2b3c" || fills[0].RemainingSell != "900" {
+		t.Errorf("wrong first fill %#v", fills[0])
+	}
+	if fills[1].Height != 12 || fills[1].TxHash != "" || fills[1].ValueSold != "900" || fills[1].Address != owner {
+		t.Errorf("wrong second fill %#v", fills[1])
+	}
+
+	if fills := store.LoadAddressOrderFills(owner); len(fills) != 2 {
+		t.Errorf("want 2 fills of the address, got %d", len(fills))
+	}
+	if fills := store.LoadAddressOrderFills(types.Address{1}); len(fills) != 0 {
+		t.Errorf("want no fills of unknown address, got %d", len(fills))
+	}
+
+	loadEvents := store.LoadEvents(12)
+	if fill, ok := loadEvents[0].(*OrderFilledEvent); !ok || fill.Height != 12 || fill.RemainingSell != "0" {
+		t.Errorf("wrong event %#v", loadEvents[0])
+	}
+}
+
+func TestIEventsDB_LiquidityChanges(t *testing.T) {
+	store := NewEventsStore(db.NewMemDB())
+	provider := types.HexToAddress("Mx04bea23efb744dc93b4fda4c20bf4a21c6e195f1")
+
+	store.AddEvent(&LiquidityChangedEvent{
+		Address:     provider,
+		PoolID:      1,
+		Coin0:       0,
+		Volume0:     "1000",
+		Coin1:       1,
+		Volume1:     "2000",
+		Liquidity:   "1414",
+		Reserve0:    "1000",
+		Reserve1:    "2000",
+		TotalSupply: "1414",
+	})
+	store.AddEvent(&LiquidityChangedEvent{
+		Address:     provider,
+		PoolID:      2,
+		Coin0:       0,
+		Volume0:     "1",
+		Coin1:       2,
+		Volume1:     "1",
+		Liquidity:   "1",
+		Reserve0:    "1",
+		Reserve1:    "1",
+		TotalSupply: "1",
+	})
+	if err := store.CommitEvents(5); err != nil {
+		t.Fatal(err)
+	}
+	store.AddEvent(&LiquidityChangedEvent{
+		Address:     provider,
+		PoolID:      1,
+		Coin0:       1,
+		Volume0:     "200",
+		Coin1:       0,
+		Volume1:     "100",
+		Liquidity:   "141",
+		Remove:      true,
+		Reserve0:    "1800",
+		Reserve1:    "900",
+		TotalSupply: "1273",
+	})
+	if err := store.CommitEvents(7); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := store.LoadLiquidityChanges(1, provider)
+	if len(changes) != 2 {
+		t.Fatalf("want 2 changes, got %d", len(changes))
+	}
+	if changes[0].Height != 5 || changes[0].Remove || changes[0].Volume1 != "2000" {
+		t.Errorf("wrong first change %#v", changes[0])
+	}
+	if changes[1].Height != 7 || !changes[1].Remove || changes[1].Coin0 != 1 || changes[1].TotalSupply != "1273" {
+		t.Errorf("wrong second change %#v", changes[1])
+	}
+	if changes := store.LoadLiquidityChanges(1, types.Address{1}); len(changes) != 0 {
+		t.Errorf("want no changes of unknown provider, got %d", len(changes))
+	}
+}
@@ -1,6 +1,7 @@
 package events
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math/big"
 
@@ -21,6 +22,14 @@ const (
 	TypeOrderExpiredEvent       = "minter/OrderExpiredEvent"
 	TypeRemoveCandidateEvent    = "minter/RemoveCandidateEvent"
 	TypeUpdatedBlockRewardEvent = "minter/UpdatedBlockRewardEvent"
+	TypeStopOrderActivatedEvent = "minter/StopOrderActivatedEvent"
+	TypeStopOrderExpiredEvent   = "minter/StopOrderExpiredEvent"
+	TypeOrderFilledEvent        = "minter/OrderFilledEvent"
+	TypeLiquidityChangedEvent   = "minter/LiquidityChangedEvent"
+	TypeMiningRewardEvent       = "minter/MiningRewardEvent"
+	TypeUpdateParameterEvent    = "minter/UpdateParameterEvent"
+	TypeTreasuryProposalEvent   = "minter/TreasuryProposalEvent"
+	TypeAirdropExpiredEvent     = "minter/AirdropExpiredEvent"
 )
 
 type Stake interface {
@@ -294,6 +303,444 @@ func (oe *OrderExpiredEvent) convert(addressID uint32) compact {
 	return result
 }
 
+type stopOrderActivated struct {
+	AddressID    uint32
+	ID           uint32
+	CoinToSell   uint32
+	ValueToSell  []byte
+	CoinToBuy    uint32
+	ValueToBuy   []byte
+	LimitOrderID uint32
+	Refund       []byte
+}
+
+func (e *stopOrderActivated) addressID() uint32 {
+	return e.AddressID
+}
+
+func (e *stopOrderActivated) compile(address [20]byte) Event {
+	event := new(StopOrderActivatedEvent)
+	event.ID = uint64(e.ID)
+	event.Address = address
+	event.CoinToSell = uint64(e.CoinToSell)
+	event.ValueToSell = big.NewInt(0).SetBytes(e.ValueToSell).String()
+	event.CoinToBuy = uint64(e.CoinToBuy)
+	event.ValueToBuy = big.NewInt(0).SetBytes(e.ValueToBuy).String()
+	event.LimitOrderID = uint64(e.LimitOrderID)
+	event.Refund = big.NewInt(0).SetBytes(e.Refund).String()
+	return event
+}
+
+// StopOrderActivatedEvent is emitted when the price of the pool reaches the trigger of the stop order.
+// ValueToSell is sold immediately for ValueToBuy, the remainder is placed as the limit order LimitOrderID or refunded.
+type StopOrderActivatedEvent struct {
+	ID           uint64        `json:"id"`
+	Address      types.Address `json:"address"`
+	CoinToSell   uint64        `json:"coin_to_sell"`
+	ValueToSell  string        `json:"value_to_sell"`
+	CoinToBuy    uint64        `json:"coin_to_buy"`
+	ValueToBuy   string        `json:"value_to_buy"`
+	LimitOrderID uint64        `json:"limit_order_id,omitempty"`
+	Refund       string        `json:"refund"`
+}
+
+func (se *StopOrderActivatedEvent) AddressString() string {
+	return se.Address.String()
+}
+
+func (se *StopOrderActivatedEvent) address() types.Address {
+	return se.Address
+}
+
+func (se *StopOrderActivatedEvent) Type() string {
+	return TypeStopOrderActivatedEvent
+}
+
+func (se *StopOrderActivatedEvent) convert(addressID uint32) compact {
+	result := new(stopOrderActivated)
+	result.AddressID = addressID
+	result.ID = uint32(se.ID)
+	result.CoinToSell = uint32(se.CoinToSell)
+	valueToSell, _ := big.NewInt(0).SetString(se.ValueToSell, 10)
+	result.ValueToSell = valueToSell.Bytes()
+	result.CoinToBuy = uint32(se.CoinToBuy)
+	valueToBuy, _ := big.NewInt(0).SetString(se.ValueToBuy, 10)
+	result.ValueToBuy = valueToBuy.Bytes()
+	result.LimitOrderID = uint32(se.LimitOrderID)
+	refund, _ := big.NewInt(0).SetString(se.Refund, 10)
+	result.Refund = refund.Bytes()
+	return result
+}
+
+type stopOrderExpired struct {
+	AddressID uint32
+	Amount    []byte
+	Coin      uint32
+	ID        uint32
+}
+
+func (e *stopOrderExpired) addressID() uint32 {
+	return e.AddressID
+}
+
+func (e *stopOrderExpired) compile(address [20]byte) Event {
+	event := new(StopOrderExpiredEvent)
+	event.ID = uint64(e.ID)
+	event.Address = address
+	event.Coin = uint64(e.Coin)
+	event.Amount = big.NewInt(0).SetBytes(e.Amount).String()
+	return event
+}
+
+// StopOrderExpiredEvent is emitted when the stop order is not activated during the expiration period, Amount is returned to Address
+type StopOrderExpiredEvent struct {
+	ID      uint64        `json:"id"`
+	Address types.Address `json:"address"`
+	Coin    uint64        `json:"coin"`
+	Amount  string        `json:"amount"`
+}
+
+func (se *StopOrderExpiredEvent) AddressString() string {
+	return se.Address.String()
+}
+
+func (se *StopOrderExpiredEvent) address() types.Address {
+	return se.Address
+}
+
+func (se *StopOrderExpiredEvent) Type() string {
+	return TypeStopOrderExpiredEvent
+}
+
+func (se *StopOrderExpiredEvent) convert(addressID uint32) compact {
+	result := new(stopOrderExpired)
+	result.ID = uint32(se.ID)
+	result.Coin = uint32(se.Coin)
+	result.AddressID = addressID
+	bi, _ := big.NewInt(0).SetString(se.Amount, 10)
+	result.Amount = bi.Bytes()
+	return result
+}
+
+type orderFilled struct {
+	AddressID     uint32
+	ID            uint32
+	TxHash        []byte
+	CoinToSell    uint32
+	ValueSold     []byte
+	CoinToBuy     uint32
+	ValueBought   []byte
+	RemainingSell []byte
+	RemainingBuy  []byte
+}
+
+func (e *orderFilled) addressID() uint32 {
+	return e.AddressID
+}
+
+func (e *orderFilled) compile(address [20]byte) Event {
+	event := new(OrderFilledEvent)
+	event.ID = uint64(e.ID)
+	event.Address = address
+	if len(e.TxHash) != 0 {
+		event.TxHash = fmt.Sprintf("Mt%x", e.TxHash)
+	}
+	event.CoinToSell = uint64(e.CoinToSell)
+	event.ValueSold = big.NewInt(0).SetBytes(e.ValueSold).String()
+	event.CoinToBuy = uint64(e.CoinToBuy)
+	event.ValueBought = big.NewInt(0).SetBytes(e.ValueBought).String()
+	event.RemainingSell = big.NewInt(0).SetBytes(e.RemainingSell).String()
+	event.RemainingBuy = big.NewInt(0).SetBytes(e.RemainingBuy).String()
+	return event
+}
+
+// OrderFilledEvent is emitted for every limit order consumed by a swap, completely or partially.
+// TxHash is empty if the order is filled at the end of the block.
+type OrderFilledEvent struct {
+	ID            uint64        `json:"id"`
+	Address       types.Address `json:"address"`
+	Height        uint64        `json:"height,omitempty"`
+	TxHash        string        `json:"tx_hash,omitempty"`
+	CoinToSell    uint64        `json:"coin_to_sell"`
+	ValueSold     string        `json:"value_sold"`
+	CoinToBuy     uint64        `json:"coin_to_buy"`
+	ValueBought   string        `json:"value_bought"`
+	RemainingSell string        `json:"remaining_sell"`
+	RemainingBuy  string        `json:"remaining_buy"`
+}
+
+func (oe *OrderFilledEvent) AddressString() string {
+	return oe.Address.String()
+}
+
+func (oe *OrderFilledEvent) address() types.Address {
+	return oe.Address
+}
+
+func (oe *OrderFilledEvent) Type() string {
+	return TypeOrderFilledEvent
+}
+
+func (oe *OrderFilledEvent) convert(addressID uint32) compact {
+	result := new(orderFilled)
+	result.AddressID = addressID
+	result.ID = uint32(oe.ID)
+	if len(oe.TxHash) > 2 {
+		result.TxHash, _ = hex.DecodeString(oe.TxHash[2:])
+	}
+	result.CoinToSell = uint32(oe.CoinToSell)
+	valueSold, _ := big.NewInt(0).SetString(oe.ValueSold, 10)
+	result.ValueSold = valueSold.Bytes()
+	result.CoinToBuy = uint32(oe.CoinToBuy)
+	valueBought, _ := big.NewInt(0).SetString(oe.ValueBought, 10)
+	result.ValueBought = valueBought.Bytes()
+	remainingSell, _ := big.NewInt(0).SetString(oe.RemainingSell, 10)
+	result.RemainingSell = remainingSell.Bytes()
+	remainingBuy, _ := big.NewInt(0).SetString(oe.RemainingBuy, 10)
+	result.RemainingBuy = remainingBuy.Bytes()
+	return result
+}
+
+type liquidityChanged struct {
+	AddressID   uint32
+	PoolID      uint32
+	Coin0       uint32
+	Volume0     []byte
+	Coin1       uint32
+	Volume1     []byte
+	Liquidity   []byte
+	Remove      bool
+	Reserve0    []byte
+	Reserve1    []byte
+	TotalSupply []byte
+}
+
+func (e *liquidityChanged) addressID() uint32 {
+	return e.AddressID
+}
+
+func (e *liquidityChanged) compile(address [20]byte) Event {
+	event := new(LiquidityChangedEvent)
+	event.Address = address
+	event.PoolID = uint64(e.PoolID)
+	event.Coin0 = uint64(e.Coin0)
+	event.Volume0 = big.NewInt(0).SetBytes(e.Volume0).String()
+	event.Coin1 = uint64(e.Coin1)
+	event.Volume1 = big.NewInt(0).SetBytes(e.Volume1).String()
+	event.Liquidity = big.NewInt(0).SetBytes(e.Liquidity).String()
+	event.Remove = e.Remove
+	event.Reserve0 = big.NewInt(0).SetBytes(e.Reserve0).String()
+	event.Reserve1 = big.NewInt(0).SetBytes(e.Reserve1).String()
+	event.TotalSupply = big.NewInt(0).SetBytes(e.TotalSupply).String()
+	return event
+}
+
+// LiquidityChangedEvent is emitted when the provider adds liquidity to the pool or removes it.
+// Reserves of the pool and the total supply of its liquidity token are taken after the change.
+type LiquidityChangedEvent struct {
+	Address     types.Address `json:"address"`
+	Height      uint64        `json:"height,omitempty"`
+	PoolID      uint64        `json:"pool_id"`
+	Coin0       uint64        `json:"coin0"`
+	Volume0     string        `json:"volume0"`
+	Coin1       uint64        `json:"coin1"`
+	Volume1     string        `json:"volume1"`
+	Liquidity   string        `json:"liquidity"`
+	Remove      bool          `json:"remove,omitempty"`
+	Reserve0    string        `json:"reserve0"`
+	Reserve1    string        `json:"reserve1"`
+	TotalSupply string        `json:"total_supply"`
+}
+
+func (le *LiquidityChangedEvent) AddressString() string {
+	return le.Address.String()
+}
+
+func (le *LiquidityChangedEvent) address() types.Address {
+	return le.Address
+}
+
+func (le *LiquidityChangedEvent) Type() string {
+	return TypeLiquidityChangedEvent
+}
+
+func (le *LiquidityChangedEvent) convert(addressID uint32) compact {
+	result := new(liquidityChanged)
+	result.AddressID = addressID
+	result.PoolID = uint32(le.PoolID)
+	result.Coin0 = uint32(le.Coin0)
+	volume0, _ := big.NewInt(0).SetString(le.Volume0, 10)
+	result.Volume0 = volume0.Bytes()
+	result.Coin1 = uint32(le.Coin1)
+	volume1, _ := big.NewInt(0).SetString(le.Volume1, 10)
+	result.Volume1 = volume1.Bytes()
+	liquidity, _ := big.NewInt(0).SetString(le.Liquidity, 10)
+	result.Liquidity = liquidity.Bytes()
+	result.Remove = le.Remove
+	reserve0, _ := big.NewInt(0).SetString(le.Reserve0, 10)
+	result.Reserve0 = reserve0.Bytes()
+	reserve1, _ := big.NewInt(0).SetString(le.Reserve1, 10)
+	result.Reserve1 = reserve1.Bytes()
+	totalSupply, _ := big.NewInt(0).SetString(le.TotalSupply, 10)
+	result.TotalSupply = totalSupply.Bytes()
+	return result
+}
+
+type miningReward struct {
+	AddressID uint32
+	ProgramID uint32
+	Coin      uint32
+	Amount    []byte
+}
+
+func (e *miningReward) addressID() uint32 {
+	return e.AddressID
+}
+
+func (e *miningReward) compile(address [20]byte) Event {
+	event := new(MiningRewardEvent)
+	event.Address = address
+	event.ProgramID = uint64(e.ProgramID)
+	event.Coin = uint64(e.Coin)
+	event.Amount = big.NewInt(0).SetBytes(e.Amount).String()
+	return event
+}
+
+// MiningRewardEvent is emitted when the staker claims the reward of the liquidity mining program
+type MiningRewardEvent struct {
+	Address   types.Address `json:"address"`
+	ProgramID uint64        `json:"program_id"`
+	Coin      uint64        `json:"coin"`
+	Amount    string        `json:"amount"`
+}
+
+func (me *MiningRewardEvent) AddressString() string {
+	return me.Address.String()
+}
+
+func (me *MiningRewardEvent) address() types.Address {
+	return me.Address
+}
+
+func (me *MiningRewardEvent) Type() string {
+	return TypeMiningRewardEvent
+}
+
+func (me *MiningRewardEvent) convert(addressID uint32) compact {
+	result := new(miningReward)
+	result.AddressID = addressID
+	result.ProgramID = uint32(me.ProgramID)
+	result.Coin = uint32(me.Coin)
+	amount, _ := big.NewInt(0).SetString(me.Amount, 10)
+	result.Amount = amount.Bytes()
+	return result
+}
+
+type treasuryProposal struct {
+	AddressID  uint32
+	ProposalID uint32
+	Coin       uint32
+	Amount     []byte
+	Executed   bool
+}
+
+func (e *treasuryProposal) addressID() uint32 {
+	return e.AddressID
+}
+
+func (e *treasuryProposal) compile(address [20]byte) Event {
+	event := new(TreasuryProposalEvent)
+	event.Address = address
+	event.ProposalID = uint64(e.ProposalID)
+	event.Coin = uint64(e.Coin)
+	event.Amount = big.NewInt(0).SetBytes(e.Amount).String()
+	event.Executed = e.Executed
+	return event
+}
+
+// TreasuryProposalEvent is emitted at the end of voting for the treasury spending proposal, Address is its recipient.
+// Executed is false if the proposal is not approved or the DAO balance is insufficient.
+type TreasuryProposalEvent struct {
+	Address    types.Address `json:"address"`
+	ProposalID uint64        `json:"proposal_id"`
+	Coin       uint64        `json:"coin"`
+	Amount     string        `json:"amount"`
+	Executed   bool          `json:"executed"`
+}
+
+func (te *TreasuryProposalEvent) AddressString() string {
+	return te.Address.String()
+}
+
+func (te *TreasuryProposalEvent) address() types.Address {
+	return te.Address
+}
+
+func (te *TreasuryProposalEvent) Type() string {
+	return TypeTreasuryProposalEvent
+}
+
+func (te *TreasuryProposalEvent) convert(addressID uint32) compact {
+	result := new(treasuryProposal)
+	result.AddressID = addressID
+	result.ProposalID = uint32(te.ProposalID)
+	result.Coin = uint32(te.Coin)
+	amount, _ := big.NewInt(0).SetString(te.Amount, 10)
+	result.Amount = amount.Bytes()
+	result.Executed = te.Executed
+	return result
+}
+
+type airdropExpired struct {
+	AddressID uint32
+	AirdropID uint32
+	Coin      uint32
+	Amount    []byte
+}
+
+func (e *airdropExpired) addressID() uint32 {
+	return e.AddressID
+}
+
+func (e *airdropExpired) compile(address [20]byte) Event {
+	event := new(AirdropExpiredEvent)
+	event.Address = address
+	event.AirdropID = uint64(e.AirdropID)
+	event.Coin = uint64(e.Coin)
+	event.Amount = big.NewInt(0).SetBytes(e.Amount).String()
+	return event
+}
+
+// AirdropExpiredEvent is emitted at the expiration of the airdrop, Amount of unclaimed coins is returned to Address of its owner
+type AirdropExpiredEvent struct {
+	Address   types.Address `json:"address"`
+	AirdropID uint64        `json:"airdrop_id"`
+	Coin      uint64        `json:"coin"`
+	Amount    string        `json:"amount"`
+}
+
+func (ae *AirdropExpiredEvent) AddressString() string {
+	return ae.Address.String()
+}
+
+func (ae *AirdropExpiredEvent) address() types.Address {
+	return ae.Address
+}
+
+func (ae *AirdropExpiredEvent) Type() string {
+	return TypeAirdropExpiredEvent
+}
+
+func (ae *AirdropExpiredEvent) convert(addressID uint32) compact {
+	result := new(airdropExpired)
+	result.AddressID = addressID
+	result.AirdropID = uint32(ae.AirdropID)
+	result.Coin = uint32(ae.Coin)
+	amount, _ := big.NewInt(0).SetString(ae.Amount, 10)
+	result.Amount = amount.Bytes()
+	return result
+}
+
 type JailEvent struct {
 	//ValidatorID     uint32       `json:"validator_id"`
 	ValidatorPubKey types.Pubkey `json:"validator_pub_key"`
@@ -622,6 +1069,16 @@ func (un *UpdateNetworkEvent) Type() string {
 	return TypeUpdateNetworkEvent
 }
 
+// UpdateParameterEvent is emitted when the network parameter is changed by votes of validators
+type UpdateParameterEvent struct {
+	Parameter string `json:"parameter"`
+	Value     uint64 `json:"value"`
+}
+
+func (up *UpdateParameterEvent) Type() string {
+	return TypeUpdateParameterEvent
+}
+
 type removeCandidate struct {
 	PubKeyID uint16
 }
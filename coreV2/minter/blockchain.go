@@ -23,6 +23,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/config"
 	"github.com/MinterTeam/minter-go-node/coreV2/appdb"
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/performance"
 	"github.com/MinterTeam/minter-go-node/coreV2/rewards"
 	"github.com/MinterTeam/minter-go-node/coreV2/state"
 	"github.com/MinterTeam/minter-go-node/coreV2/statistics"
@@ -34,6 +35,7 @@ import (
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmNode "github.com/tendermint/tendermint/node"
 	rpc "github.com/tendermint/tendermint/rpc/client/local"
+	tmTypes "github.com/tendermint/tendermint/types"
 )
 
 // Statuses of validators
@@ -60,12 +62,13 @@ type Blockchain struct {
 	executor      transaction.ExecutorTx
 	statisticData *statistics.Data
 
-	appDB        *appdb.AppDB
-	eventsDB     eventsdb.IEventsDB
-	stateDeliver *state.State
-	stateCheck   *state.CheckState
-	height       uint64   // current Blockchain height
-	rewards      *big.Int // Rewards pool
+	appDB         *appdb.AppDB
+	eventsDB      eventsdb.IEventsDB
+	performanceDB performance.IPerformanceDB
+	stateDeliver  *state.State
+	stateCheck    *state.CheckState
+	height        uint64   // current Blockchain height
+	rewards       *big.Int // Rewards pool
 
 	lockValidators     sync.RWMutex
 	validatorsStatuses map[types.TmAddress]int8
@@ -113,10 +116,13 @@ func NewMinterBlockchain(storages *utils.Storage, cfg *config.Config, ctx contex
 		ctx = context.Background()
 	}
 	var eventsDB eventsdb.IEventsDB
+	var performanceDB performance.IPerformanceDB
 	if !cfg.ValidatorMode {
 		eventsDB = eventsdb.NewEventsStore(storages.EventDB())
+		performanceDB = performance.NewPerformanceStore(storages.PerformanceDB())
 	} else {
 		eventsDB = &eventsdb.MockEvents{}
+		performanceDB = &performance.MockPerformance{}
 	}
 	const updateStakesAndPayRewards = 720
 	if updateStakePeriod == 0 {
@@ -136,6 +142,7 @@ func NewMinterBlockchain(storages *utils.Storage, cfg *config.Config, ctx contex
 		appDB:                           applicationDB,
 		storages:                        storages,
 		eventsDB:                        eventsDB,
+		performanceDB:                   performanceDB,
 		currentMempool:                  &sync.Map{},
 		cfg:                             cfg,
 		stopChan:                        ctx,
@@ -148,6 +155,7 @@ func NewMinterBlockchain(storages *utils.Storage, cfg *config.Config, ctx contex
 			V310: {}, // hotfix
 			V320: {},
 			V330: {},
+			V340: {},
 		},
 		executor: GetExecutor(V3),
 	}
@@ -171,6 +179,8 @@ func GetExecutor(v string) transaction.ExecutorTx {
 	//	return transaction.NewExecutorV250(transaction.GetDataV250)
 	//case v230:
 	//	return transaction.NewExecutor(transaction.GetDataV230)
+	case V340:
+		return transaction.NewExecutorV3(transaction.GetDataV340)
 	default:
 		return transaction.NewExecutorV3(transaction.GetDataV3)
 	}
@@ -181,6 +191,7 @@ const ( // known update versions
 	V310 = "v310" // hotfix
 	V320 = "v320" // hotfix
 	V330 = "v330" // hotfix
+	V340 = "v340"
 )
 
 func (blockchain *Blockchain) initState() {
@@ -213,6 +224,10 @@ func (blockchain *Blockchain) initState() {
 		blockchain.executor = GetExecutor(v.Name)
 	}
 
+	if blockchain.isV340(currentHeight) {
+		blockchain.enableV340()
+	}
+	blockchain.applyParameters()
 }
 
 // InitChain initialize blockchain with validators and other info. Only called once.
@@ -233,10 +248,14 @@ func (blockchain *Blockchain) InitChain(req abciTypes.RequestInitChain) abciType
 		}
 	}
 	blockchain.initState()
+	if blockchain.appDB.GetVersionHeight(V340) > 0 {
+		blockchain.enableV340()
+	}
 
 	if err := blockchain.stateDeliver.Import(genesisState, genesisState.Version); err != nil {
 		panic(err)
 	}
+	blockchain.applyParameters()
 	if err := blockchain.stateDeliver.Check(); err != nil {
 		panic(err)
 	}
@@ -308,6 +327,7 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 		var address types.TmAddress
 		copy(address[:], v.Validator.Address)
 
+		snapshot := blockchain.snapshotValidator(address)
 		if v.SignedLastBlock {
 			blockchain.stateDeliver.Validators.SetValidatorPresent(height, address)
 			blockchain.validatorsStatuses[address] = ValidatorPresent
@@ -315,6 +335,7 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 			blockchain.stateDeliver.Validators.SetValidatorAbsent(height, address, blockchain.grace)
 			blockchain.validatorsStatuses[address] = ValidatorAbsent
 		}
+		blockchain.recordValidatorSignature(height, snapshot, v.SignedLastBlock)
 	}
 	blockchain.lockValidators.Unlock()
 
@@ -333,6 +354,12 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 		return abciTypes.ResponseBeginBlock{}
 	}
 
+	if h := blockchain.appDB.GetVersionHeight(V340); h > 0 && height == h {
+		blockchain.enableV340()
+		blockchain.stateDeliver.Accounts.MigrateIndexes()
+		blockchain.stateDeliver.Swapper().MigrateOrdersExpiration()
+	}
+
 	// give penalty to Byzantine validators
 	for _, byzVal := range req.ByzantineValidators {
 		var address types.TmAddress
@@ -344,9 +371,10 @@ func (blockchain *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTy
 			continue
 		}
 
-		blockchain.stateDeliver.FrozenFunds.PunishFrozenFundsWithID(height, height+types.GetUnbondPeriod(), candidate.ID)
+		blockchain.stateDeliver.FrozenFunds.PunishFrozenFundsWithID(height, height+blockchain.stateDeliver.Params.GetUnbondPeriod(), candidate.ID)
 		blockchain.stateDeliver.Validators.PunishByzantineValidator(address)
 		blockchain.stateDeliver.Candidates.PunishByzantineCandidate(height, address)
+		blockchain.performanceDB.AddEvent(&performance.Event{Height: height, PubKey: candidate.PubKey, Type: performance.TypeSlash})
 	}
 
 	// apply frozen funds (used for unbond stakes)
@@ -445,10 +473,19 @@ func (blockchain *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.
 		blockchain.stateDeliver.App.AddTotalSlashed(remainder)
 	}
 
-	// expire orders
-	if height > blockchain.expiredOrdersPeriod && height%blockchain.updateStakesAndPayRewardsPeriod == blockchain.updateStakesAndPayRewardsPeriod/2 {
+	// expire orders, after v340 all orders are expired by the expiration index
+	if !blockchain.isV340(height) && height > blockchain.expiredOrdersPeriod && height%blockchain.updateStakesAndPayRewardsPeriod == blockchain.updateStakesAndPayRewardsPeriod/2 {
 		blockchain.stateDeliver.Swapper().ExpireOrders(height - blockchain.expiredOrdersPeriod)
 	}
+	if blockchain.isV340(height) {
+		blockchain.stateDeliver.Swapper().ExpireOrdersAtHeight(height)
+		if height > blockchain.expiredOrdersPeriod {
+			blockchain.stateDeliver.Swapper().ExpireStopOrders(height - blockchain.expiredOrdersPeriod)
+		}
+
+		// activate stop orders triggered by prices of the previous block
+		blockchain.stateDeliver.Swapper().ActivateStopOrders(height)
+	}
 
 	// pay rewards
 	var moreRewards = big.NewInt(0)
@@ -555,6 +592,31 @@ func (blockchain *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.
 		blockchain.stateDeliver.Updates.Delete(height)
 	}
 
+	if blockchain.isV340(height) {
+		for _, v := range blockchain.isUpdateParametersBlock(height) {
+			blockchain.stateDeliver.Params.SetPending(v.Parameter, v.Value)
+		}
+		blockchain.stateDeliver.Params.Delete(height)
+
+		// accepted parameters are changed at the end of the period of stakes update, so periods are not changed in the middle
+		if height%blockchain.updateStakesAndPayRewardsPeriod == 0 {
+			parameters := blockchain.stateDeliver.Params.ApplyPending()
+			for _, parameter := range parameters {
+				value, _ := blockchain.stateDeliver.Params.Get(parameter)
+				blockchain.eventsDB.AddEvent(&eventsdb.UpdateParameterEvent{
+					Parameter: parameter.String(),
+					Value:     value,
+				})
+			}
+			if len(parameters) != 0 {
+				blockchain.applyParameters()
+			}
+		}
+
+		blockchain.executeTreasuryProposals(height)
+		blockchain.expireAirdrops(height)
+	}
+
 	hasChangedPublicKeys := false
 	if blockchain.stateDeliver.Candidates.IsChangedPublicKeys() {
 		blockchain.stateDeliver.Candidates.ResetIsChangedPublicKeys()
@@ -596,7 +658,9 @@ func (blockchain *Blockchain) Info(_ abciTypes.RequestInfo) (resInfo abciTypes.R
 
 // DeliverTx deliver a tx for full processing
 func (blockchain *Blockchain) DeliverTx(req abciTypes.RequestDeliverTx) abciTypes.ResponseDeliverTx {
+	blockchain.stateDeliver.Bus().SetTxHash(types.BytesToHash(tmTypes.Tx(req.Tx).Hash()))
 	response := blockchain.executor.RunTx(blockchain.stateDeliver, req.Tx, blockchain.rewards, blockchain.Height()+1, &sync.Map{}, 0, blockchain.cfg.ValidatorMode)
+	blockchain.stateDeliver.Bus().SetTxHash(types.Hash{})
 
 	return abciTypes.ResponseDeliverTx{
 		Code:      response.Code,
@@ -658,6 +722,11 @@ func (blockchain *Blockchain) Commit() abciTypes.ResponseCommit {
 		panic(err)
 	}
 
+	// Flush validators performance db
+	if err := blockchain.performanceDB.Commit(); err != nil {
+		panic(err)
+	}
+
 	// Committing Minter Blockchain state
 	hash, err := blockchain.stateDeliver.Commit()
 	if err != nil {
@@ -714,6 +783,9 @@ func (blockchain *Blockchain) Close() error {
 	if err := blockchain.storages.EventDB().Close(); err != nil {
 		return err
 	}
+	if err := blockchain.storages.PerformanceDB().Close(); err != nil {
+		return err
+	}
 	if err := blockchain.storages.SnapshotDB().Close(); err != nil {
 		return err
 	}
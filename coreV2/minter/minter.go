@@ -9,13 +9,17 @@ import (
 	"sync/atomic"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/appdb"
+	"github.com/MinterTeam/minter-go-node/coreV2/dao"
+	"github.com/MinterTeam/minter-go-node/coreV2/developers"
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/performance"
 	"github.com/MinterTeam/minter-go-node/coreV2/rewards"
 	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/treasury"
 	validators2 "github.com/MinterTeam/minter-go-node/coreV2/state/validators"
 	"github.com/MinterTeam/minter-go-node/coreV2/statistics"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
-	"github.com/MinterTeam/minter-go-node/coreV2/validators"
 	"github.com/syndtr/goleveldb/leveldb/filter"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	abciTypes "github.com/tendermint/tendermint/abci/types"
@@ -81,28 +85,40 @@ func (blockchain *Blockchain) WaitStop() error {
 
 // calculatePowers calculates total power of validators
 func (blockchain *Blockchain) calculatePowers(vals []*validators2.Validator) {
-	blockchain.validatorsPowers = map[types.Pubkey]*big.Int{}
-	blockchain.totalPower = big.NewInt(0)
+	blockchain.validatorsPowers, blockchain.totalPower = blockchain.VotingPowers(vals)
+}
+
+// VotingPowers returns powers of validators counted in votes for halts, commissions and updates, and their total power
+func (blockchain *Blockchain) VotingPowers(vals []*validators2.Validator) (map[types.Pubkey]*big.Int, *big.Int) {
+	powers := map[types.Pubkey]*big.Int{}
+	totalPower := big.NewInt(0)
 	for _, val := range vals {
 		// skip if candidate is not present
 		if val.IsToDrop() || blockchain.GetValidatorStatus(val.GetAddress()) != ValidatorPresent {
 			continue
 		}
 
-		blockchain.validatorsPowers[val.PubKey] = val.GetTotalBipStake()
-		blockchain.totalPower.Add(blockchain.totalPower, val.GetTotalBipStake())
+		powers[val.PubKey] = val.GetTotalBipStake()
+		totalPower.Add(totalPower, val.GetTotalBipStake())
 	}
 
-	if blockchain.totalPower.Sign() == 0 {
-		blockchain.totalPower = big.NewInt(1)
+	if totalPower.Sign() == 0 {
+		totalPower = big.NewInt(1)
 	}
+
+	return powers, totalPower
+}
+
+// VotingPowerConsensus returns the share of total power which votes should exceed to be accepted
+func VotingPowerConsensus() float64 {
+	return votingPowerConsensus
 }
 
 func (blockchain *Blockchain) updateValidators() []abciTypes.ValidatorUpdate {
 	height := blockchain.Height()
 
 	blockchain.stateDeliver.Candidates.RecalculateStakesV2(height)
-	valsCount := validators.GetValidatorsCountForBlock(height)
+	valsCount := blockchain.stateDeliver.Params.GetValidatorsCount(height)
 	newCandidates := blockchain.stateDeliver.Candidates.GetNewCandidates(valsCount)
 	if len(newCandidates) < valsCount {
 		valsCount = len(newCandidates)
@@ -128,7 +144,9 @@ func (blockchain *Blockchain) updateValidators() []abciTypes.ValidatorUpdate {
 	}
 
 	// update validators in state
+	oldValidators := blockchain.stateDeliver.Validators.GetValidators()
 	blockchain.stateDeliver.Validators.SetNewValidators(newCandidates)
+	blockchain.recordValidatorSetChanges(height, oldValidators, blockchain.stateDeliver.Validators.GetValidators())
 
 	activeValidators := blockchain.appDB.GetValidators()
 	blockchain.appDB.SetValidators(newValidators)
@@ -264,6 +282,11 @@ func (blockchain *Blockchain) GetEventsDB() eventsdb.IEventsDB {
 	return blockchain.eventsDB
 }
 
+// GetPerformanceDB returns validators performance history
+func (blockchain *Blockchain) GetPerformanceDB() performance.IPerformanceDB {
+	return blockchain.performanceDB
+}
+
 // SetStatisticData used for collection statistics about blockchain operations
 func (blockchain *Blockchain) SetStatisticData(statisticData *statistics.Data) *statistics.Data {
 	blockchain.statisticData = statisticData
@@ -416,6 +439,160 @@ func (blockchain *Blockchain) isUpdateNetworkBlockV2(height uint64) (string, boo
 	return "", false
 }
 
+// isUpdateParametersBlock returns parameter changes accepted by votes for the height, the option with the most power wins for each parameter
+func (blockchain *Blockchain) isUpdateParametersBlock(height uint64) []*params.Model {
+	votes := blockchain.stateDeliver.Params.GetVotes(height)
+	if len(votes) == 0 {
+		return nil
+	}
+
+	var accepted []*params.Model
+	for _, parameter := range params.Parameters() {
+		maxVotingResult := big.NewFloat(0)
+		var winner *params.Model
+		for _, v := range votes {
+			if v.Parameter != parameter {
+				continue
+			}
+			totalVotedPower := big.NewInt(0)
+			for _, vote := range v.Votes {
+				if power, ok := blockchain.validatorsPowers[vote]; ok {
+					totalVotedPower.Add(totalVotedPower, power)
+				}
+			}
+			votingResult := new(big.Float).Quo(
+				new(big.Float).SetInt(totalVotedPower),
+				new(big.Float).SetInt(blockchain.totalPower),
+			)
+
+			if maxVotingResult.Cmp(votingResult) == -1 {
+				maxVotingResult = votingResult
+				winner = v
+			}
+		}
+		if maxVotingResult.Cmp(big.NewFloat(votingPowerConsensus)) == 1 {
+			accepted = append(accepted, winner)
+		}
+	}
+
+	return accepted
+}
+
+// validatorsStakesPower returns bip values of stakes in validators by their owners and the total one
+func (blockchain *Blockchain) validatorsStakesPower() (map[types.Address]*big.Int, *big.Int) {
+	power, totalPower := map[types.Address]*big.Int{}, big.NewInt(0)
+	for _, val := range blockchain.stateDeliver.Validators.GetValidators() {
+		for _, stake := range blockchain.stateDeliver.Candidates.GetStakes(val.PubKey) {
+			totalPower.Add(totalPower, stake.BipValue)
+			if _, ok := power[stake.Owner]; !ok {
+				power[stake.Owner] = big.NewInt(0)
+			}
+			power[stake.Owner].Add(power[stake.Owner], stake.BipValue)
+		}
+	}
+	return power, totalPower
+}
+
+// isTreasuryProposalApproved returns true if stakes of voters for the proposal exceed the consensus share of stakes of validators
+func isTreasuryProposalApproved(proposal *treasury.Proposal, power map[types.Address]*big.Int, totalPower *big.Int) bool {
+	if totalPower.Sign() != 1 {
+		return false
+	}
+
+	votedPower := big.NewInt(0)
+	for _, vote := range proposal.Votes {
+		if value, ok := power[vote]; ok {
+			votedPower.Add(votedPower, value)
+		}
+	}
+
+	votingResult := new(big.Float).Quo(
+		new(big.Float).SetInt(votedPower),
+		new(big.Float).SetInt(totalPower),
+	)
+
+	return votingResult.Cmp(big.NewFloat(votingPowerConsensus)) == 1
+}
+
+// executeTreasuryProposals pays approved proposals with voting finished at the height from the DAO balance
+func (blockchain *Blockchain) executeTreasuryProposals(height uint64) {
+	proposals := blockchain.stateDeliver.Treasury.GetProposals(height)
+	if len(proposals) == 0 {
+		return
+	}
+
+	power, totalPower := blockchain.validatorsStakesPower()
+	for _, proposal := range proposals {
+		executed := isTreasuryProposalApproved(proposal, power, totalPower) &&
+			blockchain.stateDeliver.Accounts.GetBalance(dao.Address, proposal.Coin).Cmp(proposal.Value) != -1
+		if executed {
+			blockchain.stateDeliver.Accounts.SubBalance(dao.Address, proposal.Coin, proposal.Value)
+			blockchain.stateDeliver.Accounts.AddBalance(proposal.Recipient, proposal.Coin, proposal.Value)
+		}
+		blockchain.eventsDB.AddEvent(&eventsdb.TreasuryProposalEvent{
+			Address:    proposal.Recipient,
+			ProposalID: uint64(proposal.ID()),
+			Coin:       uint64(proposal.Coin),
+			Amount:     proposal.Value.String(),
+			Executed:   executed,
+		})
+		blockchain.stateDeliver.Treasury.Delete(proposal.ID())
+	}
+}
+
+// expireAirdrops returns unclaimed coins of airdrops expiring at the height to their owners
+func (blockchain *Blockchain) expireAirdrops(height uint64) {
+	for _, drop := range blockchain.stateDeliver.Airdrops.GetAirdrops(height) {
+		remainder := drop.Remainder()
+		if remainder.Sign() == 1 {
+			blockchain.stateDeliver.Accounts.AddBalance(drop.Owner, drop.Coin, remainder)
+		}
+		blockchain.eventsDB.AddEvent(&eventsdb.AirdropExpiredEvent{
+			Address:   drop.Owner,
+			AirdropID: uint64(drop.ID()),
+			Coin:      uint64(drop.Coin),
+			Amount:    remainder.String(),
+		})
+		blockchain.stateDeliver.Airdrops.Delete(drop.ID())
+	}
+}
+
+// isV340 returns true if the v340 network update is applied at the height
+func (blockchain *Blockchain) isV340(height uint64) bool {
+	h := blockchain.appDB.GetVersionHeight(V340)
+	return h > 0 && height >= h
+}
+
+// enableV340 starts state changes which are not made by transactions of the v340 network update:
+// accumulators of pool prices, expiration index of orders and indexes of accounts
+func (blockchain *Blockchain) enableV340() {
+	blockchain.stateDeliver.Swapper().EnablePriceOracle()
+	blockchain.stateDeliver.Swapper().EnableOrdersExpiration(blockchain.expiredOrdersPeriod)
+	blockchain.stateDeliver.Accounts.EnableIndexes()
+}
+
+// applyParameters overrides network parameters by values voted by validators
+func (blockchain *Blockchain) applyParameters() {
+	parameters := blockchain.stateDeliver.Params
+	if value, ok := parameters.Get(params.ExpiredOrdersPeriod); ok {
+		blockchain.expiredOrdersPeriod = value
+		// parameters are voted only after v340, so the expiration index is already enabled
+		blockchain.stateDeliver.Swapper().EnableOrdersExpiration(value)
+	}
+	if value, ok := parameters.Get(params.UpdateStakesPeriod); ok {
+		blockchain.updateStakesAndPayRewardsPeriod = value
+	}
+
+	daoCommission, developersCommission := uint64(dao.Commission), uint64(developers.Commission)
+	if value, ok := parameters.Get(params.DAOCommission); ok {
+		daoCommission = value
+	}
+	if value, ok := parameters.Get(params.DevelopersCommission); ok {
+		developersCommission = value
+	}
+	blockchain.stateDeliver.Validators.SetRewardCommissions(daoCommission, developersCommission)
+}
+
 func GetDbOpts(memLimit int) *opt.Options {
 	if memLimit < 1024 {
 		panic(fmt.Sprintf("Not enough memory given to StateDB. Expected >1024M, given %d", memLimit))
@@ -0,0 +1,89 @@
+package minter
+
+import (
+	"github.com/MinterTeam/minter-go-node/coreV2/performance"
+	validators2 "github.com/MinterTeam/minter-go-node/coreV2/state/validators"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// validatorSnapshot is a state of a validator before processing its signature,
+// used to find out punishments applied for a missed block
+type validatorSnapshot struct {
+	pubKey      types.Pubkey
+	toDrop      bool
+	jailedUntil uint64
+}
+
+func (blockchain *Blockchain) snapshotValidator(address types.TmAddress) *validatorSnapshot {
+	validator := blockchain.stateDeliver.Validators.GetByTmAddress(address)
+	if validator == nil {
+		return nil
+	}
+
+	snapshot := &validatorSnapshot{pubKey: validator.PubKey, toDrop: validator.IsToDrop()}
+	if candidate := blockchain.stateDeliver.Candidates.GetCandidate(validator.PubKey); candidate != nil {
+		snapshot.jailedUntil = candidate.JailedUntil
+	}
+	return snapshot
+}
+
+// recordValidatorSignature stores signature of the previous block to performance history
+// with jail and switching off of the validator if it was punished for missed block
+func (blockchain *Blockchain) recordValidatorSignature(height uint64, snapshot *validatorSnapshot, signed bool) {
+	if snapshot == nil {
+		return
+	}
+
+	blockchain.performanceDB.AddBlock(height-1, snapshot.pubKey, signed)
+	if signed {
+		return
+	}
+
+	if candidate := blockchain.stateDeliver.Candidates.GetCandidate(snapshot.pubKey); candidate != nil && candidate.JailedUntil > snapshot.jailedUntil {
+		blockchain.performanceDB.AddEvent(&performance.Event{
+			Height:      height,
+			PubKey:      snapshot.pubKey,
+			Type:        performance.TypeJail,
+			JailedUntil: candidate.JailedUntil,
+		})
+	}
+
+	if validator := blockchain.stateDeliver.Validators.GetByPublicKey(snapshot.pubKey); validator != nil && !snapshot.toDrop && validator.IsToDrop() {
+		blockchain.performanceDB.AddEvent(&performance.Event{
+			Height: height,
+			PubKey: snapshot.pubKey,
+			Type:   performance.TypeStatusOffline,
+		})
+	}
+}
+
+// recordValidatorSetChanges stores entering and leaving of the validators set to performance history
+func (blockchain *Blockchain) recordValidatorSetChanges(height uint64, oldValidators, newValidators []*validators2.Validator) {
+	old := make(map[types.Pubkey]struct{}, len(oldValidators))
+	for _, validator := range oldValidators {
+		old[validator.PubKey] = struct{}{}
+	}
+
+	for _, validator := range newValidators {
+		if _, ok := old[validator.PubKey]; ok {
+			delete(old, validator.PubKey)
+			continue
+		}
+		blockchain.performanceDB.AddEvent(&performance.Event{
+			Height: height,
+			PubKey: validator.PubKey,
+			Type:   performance.TypeSetEnter,
+		})
+	}
+
+	for _, validator := range oldValidators {
+		if _, ok := old[validator.PubKey]; !ok {
+			continue
+		}
+		blockchain.performanceDB.AddEvent(&performance.Event{
+			Height: height,
+			PubKey: validator.PubKey,
+			Type:   performance.TypeSetLeave,
+		})
+	}
+}
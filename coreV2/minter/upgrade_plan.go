@@ -0,0 +1,145 @@
+package minter
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// UpgradeType is a kind of the change planned for the node at a future height
+type UpgradeType int
+
+const (
+	// UpgradeHalt stops the node at the height
+	UpgradeHalt UpgradeType = iota
+	// UpgradeVersion switches the network to the new version after the height
+	UpgradeVersion
+)
+
+// PlannedUpgrade is a halt or an update of the network planned at a future height.
+// Consensus is true if votes of the current validators exceed the voting power consensus or the halt is set in the node config.
+type PlannedUpgrade struct {
+	Height     uint64
+	Type       UpgradeType
+	Version    string
+	VotedPower *big.Int
+	TotalPower *big.Int
+	Consensus  bool
+	FromConfig bool
+	Supported  bool
+}
+
+// IsKnownUpdate returns true if the node binary is able to run the version of the network
+func (blockchain *Blockchain) IsKnownUpdate(version string) bool {
+	_, ok := blockchain.knownUpdates[version]
+	return ok
+}
+
+// CurrentVersion returns the name of the network version at the current height
+func (blockchain *Blockchain) CurrentVersion() string {
+	return blockchain.appDB.GetVersionName(blockchain.Height())
+}
+
+// AverageBlockTime returns the average time between the latest blocks, zero if there is not enough blocks
+func (blockchain *Blockchain) AverageBlockTime() time.Duration {
+	delta, count := blockchain.appDB.GetLastBlockTimeDelta()
+	if delta <= 0 || count <= 0 {
+		return 0
+	}
+
+	return time.Duration(delta) * time.Second / time.Duration(count)
+}
+
+// UpgradePlan returns halts voted by validators or set in the node config and updates of the network
+// accepted by votes of the current validators after the current height, sorted by height
+func (blockchain *Blockchain) UpgradePlan() []*PlannedUpgrade {
+	height := blockchain.Height()
+	cState := blockchain.CurrentState()
+
+	appState := new(types.AppState)
+	cState.Halts().Export(appState)
+	cState.Updates().Export(appState)
+
+	powers, totalPower := blockchain.VotingPowers(cState.Validators().GetValidators())
+	votedPower := func(votes []types.Pubkey) *big.Int {
+		power := big.NewInt(0)
+		for _, pubkey := range votes {
+			if p, ok := powers[pubkey]; ok {
+				power.Add(power, p)
+			}
+		}
+		return power
+	}
+	isConsensus := func(power *big.Int) bool {
+		votingResult := new(big.Float).Quo(
+			new(big.Float).SetInt(power),
+			new(big.Float).SetInt(totalPower),
+		)
+		return votingResult.Cmp(big.NewFloat(votingPowerConsensus)) == 1
+	}
+
+	var plan []*PlannedUpgrade
+
+	halts := map[uint64][]types.Pubkey{}
+	for _, halt := range appState.HaltBlocks {
+		if halt.Height <= height {
+			continue
+		}
+		halts[halt.Height] = append(halts[halt.Height], halt.CandidateKey)
+	}
+	if blockchain.haltHeight > height {
+		if _, ok := halts[blockchain.haltHeight]; !ok {
+			halts[blockchain.haltHeight] = nil
+		}
+	}
+	for haltHeight, votes := range halts {
+		power := votedPower(votes)
+		fromConfig := blockchain.haltHeight == haltHeight
+		plan = append(plan, &PlannedUpgrade{
+			Height:     haltHeight,
+			Type:       UpgradeHalt,
+			VotedPower: power,
+			TotalPower: totalPower,
+			Consensus:  fromConfig || isConsensus(power),
+			FromConfig: fromConfig,
+			Supported:  true,
+		})
+	}
+
+	updates := map[uint64]*PlannedUpgrade{}
+	for _, vote := range appState.UpdateVotes {
+		if vote.Height <= height {
+			continue
+		}
+		power := votedPower(vote.Votes)
+		if !isConsensus(power) {
+			continue
+		}
+		if accepted, ok := updates[vote.Height]; ok && accepted.VotedPower.Cmp(power) != -1 {
+			continue
+		}
+		updates[vote.Height] = &PlannedUpgrade{
+			Height:     vote.Height,
+			Type:       UpgradeVersion,
+			Version:    vote.Version,
+			VotedPower: power,
+			TotalPower: totalPower,
+			Consensus:  true,
+			Supported:  blockchain.IsKnownUpdate(vote.Version),
+		}
+	}
+	for _, update := range updates {
+		plan = append(plan, update)
+	}
+
+	sort.SliceStable(plan, func(i, j int) bool {
+		if plan[i].Height == plan[j].Height {
+			return plan[i].Type < plan[j].Type
+		}
+		return plan[i].Height < plan[j].Height
+	})
+
+	return plan
+}
@@ -0,0 +1,212 @@
+package multisig
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// MaxProposalsPerMultisig is a limit of pending proposals of a multisig address
+const MaxProposalsPerMultisig = 64
+
+var (
+	ErrProposalNotFound   = errors.New("proposal not found")
+	ErrNotSigned          = errors.New("proposal is not signed by any member")
+	ErrTooManyProposals   = errors.New("too many proposals for multisig")
+	ErrAlreadySigned      = errors.New("proposal is already signed by the address")
+	ErrAlreadyBroadcasted = errors.New("proposal is already broadcasted")
+)
+
+// Signature is a signature of a proposal by one of the multisig members
+type Signature struct {
+	Signer types.Address
+	Sig    []byte
+}
+
+// Proposal is a multisig transaction collecting signatures before sending
+type Proposal struct {
+	// ID is the hash of the transaction which is signed by every member
+	ID         types.Hash
+	Multisig   types.Address
+	Nonce      uint64
+	Tx         []byte
+	Signatures []Signature
+	Height     uint64
+
+	Broadcasted bool
+	TxHash      string
+	Code        uint32
+	Log         string
+}
+
+// IsSignedBy returns true if the proposal has a signature of the address
+func (p *Proposal) IsSignedBy(address types.Address) bool {
+	for _, sig := range p.Signatures {
+		if sig.Signer == address {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Proposal) copy() *Proposal {
+	proposal := *p
+	proposal.Signatures = append([]Signature{}, p.Signatures...)
+	return &proposal
+}
+
+// Pool is an in-memory store of multisig proposals of the node
+type Pool struct {
+	proposals  map[types.Hash]*Proposal
+	byMultisig map[types.Address]map[types.Hash]struct{}
+
+	lock sync.RWMutex
+}
+
+// NewPool returns empty proposals pool
+func NewPool() *Pool {
+	return &Pool{
+		proposals:  map[types.Hash]*Proposal{},
+		byMultisig: map[types.Address]map[types.Hash]struct{}{},
+	}
+}
+
+// Add stores the proposal, existing proposal with the same ID is returned unchanged.
+// The proposal should be signed by at least one member, signatures are verified by the caller.
+func (p *Pool) Add(proposal *Proposal) (*Proposal, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if existing, ok := p.proposals[proposal.ID]; ok {
+		return existing.copy(), nil
+	}
+
+	if len(proposal.Signatures) == 0 {
+		return nil, ErrNotSigned
+	}
+	signatures := proposal.Signatures
+	proposal = proposal.copy()
+	proposal.Signatures = nil
+	for _, sig := range signatures {
+		if !proposal.IsSignedBy(sig.Signer) {
+			proposal.Signatures = append(proposal.Signatures, sig)
+		}
+	}
+
+	ids := p.byMultisig[proposal.Multisig]
+	if len(ids) >= MaxProposalsPerMultisig {
+		return nil, ErrTooManyProposals
+	}
+	if ids == nil {
+		ids = map[types.Hash]struct{}{}
+		p.byMultisig[proposal.Multisig] = ids
+	}
+
+	ids[proposal.ID] = struct{}{}
+	p.proposals[proposal.ID] = proposal.copy()
+
+	return proposal.copy(), nil
+}
+
+// Get returns a copy of the proposal
+func (p *Pool) Get(id types.Hash) *Proposal {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	proposal, ok := p.proposals[id]
+	if !ok {
+		return nil
+	}
+	return proposal.copy()
+}
+
+// AddSignature appends signature of the member to the proposal
+func (p *Pool) AddSignature(id types.Hash, signature Signature) (*Proposal, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	proposal, ok := p.proposals[id]
+	if !ok {
+		return nil, ErrProposalNotFound
+	}
+	if proposal.Broadcasted {
+		return nil, ErrAlreadyBroadcasted
+	}
+	if proposal.IsSignedBy(signature.Signer) {
+		return nil, ErrAlreadySigned
+	}
+
+	proposal.Signatures = append(proposal.Signatures, signature)
+
+	return proposal.copy(), nil
+}
+
+// MarkBroadcasted marks the proposal as sent, returns false if it is already marked
+func (p *Pool) MarkBroadcasted(id types.Hash) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	proposal, ok := p.proposals[id]
+	if !ok || proposal.Broadcasted {
+		return false
+	}
+
+	proposal.Broadcasted = true
+	return true
+}
+
+// SetResult stores the result of the proposal sending. Failed proposal can be broadcasted again with the next signature.
+func (p *Pool) SetResult(id types.Hash, txHash string, code uint32, log string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	proposal, ok := p.proposals[id]
+	if !ok {
+		return
+	}
+
+	proposal.TxHash = txHash
+	proposal.Code = code
+	proposal.Log = log
+	proposal.Broadcasted = code == 0
+}
+
+// List returns proposals of the multisig ordered by nonce
+func (p *Pool) List(multisig types.Address) []*Proposal {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	var proposals []*Proposal
+	for id := range p.byMultisig[multisig] {
+		proposals = append(proposals, p.proposals[id].copy())
+	}
+
+	sort.SliceStable(proposals, func(i, j int) bool {
+		if proposals[i].Nonce == proposals[j].Nonce {
+			return proposals[i].Height < proposals[j].Height
+		}
+		return proposals[i].Nonce < proposals[j].Nonce
+	})
+
+	return proposals
+}
+
+// Prune deletes proposals of the multisig with nonce which is already used
+func (p *Pool) Prune(multisig types.Address, nonce uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for id := range p.byMultisig[multisig] {
+		if p.proposals[id].Nonce > nonce {
+			continue
+		}
+		delete(p.proposals, id)
+		delete(p.byMultisig[multisig], id)
+	}
+
+	if len(p.byMultisig[multisig]) == 0 {
+		delete(p.byMultisig, multisig)
+	}
+}
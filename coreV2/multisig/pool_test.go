@@ -0,0 +1,88 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+func TestPool_AddSignature(t *testing.T) {
+	t.Parallel()
+	pool := NewPool()
+
+	address := types.Address{1}
+	if _, err := pool.Add(&Proposal{ID: types.Hash{1}, Multisig: address, Nonce: 1}); err != ErrNotSigned {
+		t.Fatalf("expected %s, got %v", ErrNotSigned, err)
+	}
+
+	signed := []Signature{{Signer: types.Address{4}}, {Signer: types.Address{4}}}
+	proposal, err := pool.Add(&Proposal{ID: types.Hash{1}, Multisig: address, Nonce: 1, Signatures: signed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proposal.Signatures) != 1 {
+		t.Fatal("duplicated signature is added")
+	}
+
+	if _, err := pool.Add(&Proposal{ID: proposal.ID, Multisig: address, Nonce: 2, Signatures: signed}); err != nil {
+		t.Fatal(err)
+	}
+	if len(pool.List(address)) != 1 {
+		t.Fatal("proposal with the same id is added twice")
+	}
+
+	if _, err := pool.AddSignature(proposal.ID, Signature{Signer: types.Address{2}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.AddSignature(proposal.ID, Signature{Signer: types.Address{2}}); err != ErrAlreadySigned {
+		t.Fatalf("expected %s, got %v", ErrAlreadySigned, err)
+	}
+	if _, err := pool.AddSignature(types.Hash{2}, Signature{Signer: types.Address{2}}); err != ErrProposalNotFound {
+		t.Fatalf("expected %s, got %v", ErrProposalNotFound, err)
+	}
+
+	if !pool.MarkBroadcasted(proposal.ID) || pool.MarkBroadcasted(proposal.ID) {
+		t.Fatal("proposal should be marked as broadcasted once")
+	}
+	pool.SetResult(proposal.ID, "", 1, "failed")
+	if !pool.MarkBroadcasted(proposal.ID) {
+		t.Fatal("failed proposal should be available for sending again")
+	}
+	pool.SetResult(proposal.ID, "Mt01", 0, "")
+	if _, err := pool.AddSignature(proposal.ID, Signature{Signer: types.Address{3}}); err != ErrAlreadyBroadcasted {
+		t.Fatalf("expected %s, got %v", ErrAlreadyBroadcasted, err)
+	}
+
+	if got := pool.Get(proposal.ID); len(got.Signatures) != 2 || !got.IsSignedBy(types.Address{2}) {
+		t.Fatal("wrong signatures of proposal")
+	}
+}
+
+func TestPool_Prune(t *testing.T) {
+	t.Parallel()
+	pool := NewPool()
+
+	address := types.Address{1}
+	for i := byte(1); i <= 3; i++ {
+		if _, err := pool.Add(&Proposal{ID: types.Hash{i}, Multisig: address, Nonce: uint64(i), Signatures: []Signature{{Signer: address}}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pool.Prune(address, 2)
+
+	proposals := pool.List(address)
+	if len(proposals) != 1 || proposals[0].Nonce != 3 {
+		t.Fatalf("wrong proposals after prune: %d", len(proposals))
+	}
+	if pool.Get(types.Hash{1}) != nil {
+		t.Fatal("pruned proposal is found")
+	}
+
+	for i := 0; i < MaxProposalsPerMultisig; i++ {
+		_, err := pool.Add(&Proposal{ID: types.Hash{0, byte(i)}, Multisig: address, Nonce: 10, Signatures: []Signature{{Signer: address}}})
+		if i == MaxProposalsPerMultisig-1 && err != ErrTooManyProposals {
+			t.Fatalf("expected %s, got %v", ErrTooManyProposals, err)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package performance
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	db "github.com/tendermint/tm-db"
+)
+
+const (
+	blocksPrefix = byte('b')
+	eventsPrefix = byte('e')
+)
+
+// chunkSize is a count of blocks stored under one key.
+// Each chunk keeps two bit arrays: blocks where the validator was in the set and blocks it signed.
+const (
+	chunkSize  = 1024
+	chunkBytes = chunkSize / 8
+)
+
+// IPerformanceDB is an interface of validators performance history
+type IPerformanceDB interface {
+	AddBlock(height uint64, pubKey types.Pubkey, signed bool)
+	AddEvent(event *Event)
+	Commit() error
+	Blocks(pubKey types.Pubkey, fromHeight, toHeight uint64) []*Block
+	Events(pubKey types.Pubkey, fromHeight, toHeight uint64) []*Event
+	Summary(pubKey types.Pubkey, fromHeight, toHeight uint64) *Summary
+	Close() error
+}
+
+type MockPerformance struct{}
+
+func (m *MockPerformance) AddBlock(uint64, types.Pubkey, bool)          {}
+func (m *MockPerformance) AddEvent(*Event)                              {}
+func (m *MockPerformance) Commit() error                                { return nil }
+func (m *MockPerformance) Blocks(types.Pubkey, uint64, uint64) []*Block { return nil }
+func (m *MockPerformance) Events(types.Pubkey, uint64, uint64) []*Event { return nil }
+func (m *MockPerformance) Close() error                                 { return nil }
+func (m *MockPerformance) Summary(_ types.Pubkey, from, to uint64) *Summary {
+	return &Summary{FromHeight: from, ToHeight: to}
+}
+
+type chunkKey struct {
+	pubKey types.Pubkey
+	index  uint64
+}
+
+type performanceStore struct {
+	db db.DB
+
+	lock   sync.Mutex
+	chunks map[chunkKey][]byte
+	events []*Event
+}
+
+// NewPerformanceStore creates new validators performance store in given DB
+func NewPerformanceStore(db db.DB) IPerformanceDB {
+	return &performanceStore{
+		db:     db,
+		chunks: map[chunkKey][]byte{},
+	}
+}
+
+func (store *performanceStore) Close() error {
+	return store.db.Close()
+}
+
+// AddBlock marks the validator as signed or missed the block at given height
+func (store *performanceStore) AddBlock(height uint64, pubKey types.Pubkey, signed bool) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	key := chunkKey{pubKey: pubKey, index: height / chunkSize}
+	chunk, ok := store.chunks[key]
+	if !ok {
+		chunk = store.loadChunk(key)
+		store.chunks[key] = chunk
+	}
+
+	offset := height % chunkSize
+	chunk[offset/8] |= 1 << (offset % 8)
+	if signed {
+		chunk[chunkBytes+offset/8] |= 1 << (offset % 8)
+	}
+}
+
+// AddEvent adds jail, slash or status change of the validator
+func (store *performanceStore) AddEvent(event *Event) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	store.events = append(store.events, event)
+}
+
+// Commit writes pending blocks and events to db
+func (store *performanceStore) Commit() error {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	for key, chunk := range store.chunks {
+		if err := batch.Set(blocksKey(key.pubKey, key.index), chunk); err != nil {
+			return err
+		}
+	}
+
+	for i, event := range store.events {
+		bytes, err := tmjson.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if err := batch.Set(eventKey(event.PubKey, event.Height, uint32(i)), bytes); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	store.chunks = map[chunkKey][]byte{}
+	store.events = nil
+	return nil
+}
+
+// Blocks returns signed and missed blocks of the validator in range of heights, inclusive.
+// Heights where the validator was not in the set are skipped.
+func (store *performanceStore) Blocks(pubKey types.Pubkey, fromHeight, toHeight uint64) []*Block {
+	var blocks []*Block
+	store.iterateChunks(pubKey, fromHeight, toHeight, func(index uint64, chunk []byte, from, to uint64) {
+		for offset := from; offset <= to; offset++ {
+			if chunk[offset/8]&(1<<(offset%8)) == 0 {
+				continue
+			}
+			blocks = append(blocks, &Block{
+				Height: index*chunkSize + offset,
+				Signed: chunk[chunkBytes+offset/8]&(1<<(offset%8)) != 0,
+			})
+		}
+	})
+	return blocks
+}
+
+// Events returns jails, slashes and status changes of the validator in range of heights, inclusive
+func (store *performanceStore) Events(pubKey types.Pubkey, fromHeight, toHeight uint64) []*Event {
+	if toHeight < fromHeight {
+		return nil
+	}
+	if toHeight == math.MaxUint64 {
+		toHeight--
+	}
+
+	iterator, err := store.db.Iterator(eventKey(pubKey, fromHeight, 0), eventKey(pubKey, toHeight+1, 0))
+	if err != nil {
+		panic(err)
+	}
+	defer iterator.Close()
+
+	var events []*Event
+	for ; iterator.Valid(); iterator.Next() {
+		event := new(Event)
+		if err := tmjson.Unmarshal(iterator.Value(), event); err != nil {
+			panic(err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// Summary returns counts of signed and missed blocks, jails and slashes of the validator in range of heights, inclusive
+func (store *performanceStore) Summary(pubKey types.Pubkey, fromHeight, toHeight uint64) *Summary {
+	summary := &Summary{FromHeight: fromHeight, ToHeight: toHeight}
+	store.iterateChunks(pubKey, fromHeight, toHeight, func(_ uint64, chunk []byte, from, to uint64) {
+		for offset := from; offset <= to; {
+			if offset%8 == 0 && offset+7 <= to {
+				tracked := chunk[offset/8]
+				signed := chunk[chunkBytes+offset/8]
+				summary.Signed += uint64(bits.OnesCount8(signed))
+				summary.Missed += uint64(bits.OnesCount8(tracked &^ signed))
+				offset += 8
+				continue
+			}
+			if chunk[offset/8]&(1<<(offset%8)) != 0 {
+				if chunk[chunkBytes+offset/8]&(1<<(offset%8)) != 0 {
+					summary.Signed++
+				} else {
+					summary.Missed++
+				}
+			}
+			offset++
+		}
+	})
+
+	for _, event := range store.Events(pubKey, fromHeight, toHeight) {
+		switch event.Type {
+		case TypeJail:
+			summary.Jails++
+		case TypeSlash:
+			summary.Slashes++
+		}
+	}
+
+	return summary
+}
+
+// iterateChunks calls fn for each stored chunk of the validator overlapping range of heights,
+// from and to are inclusive offsets of the range within the chunk
+func (store *performanceStore) iterateChunks(pubKey types.Pubkey, fromHeight, toHeight uint64, fn func(index uint64, chunk []byte, from, to uint64)) {
+	if toHeight < fromHeight {
+		return
+	}
+
+	fromIndex, toIndex := fromHeight/chunkSize, toHeight/chunkSize
+	iterator, err := store.db.Iterator(blocksKey(pubKey, fromIndex), blocksKey(pubKey, toIndex+1))
+	if err != nil {
+		panic(err)
+	}
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		key, chunk := iterator.Key(), iterator.Value()
+		if len(chunk) != 2*chunkBytes {
+			continue
+		}
+		index := binary.BigEndian.Uint64(key[len(key)-8:])
+
+		from, to := uint64(0), uint64(chunkSize-1)
+		if index == fromIndex {
+			from = fromHeight % chunkSize
+		}
+		if index == toIndex {
+			to = toHeight % chunkSize
+		}
+		fn(index, chunk, from, to)
+	}
+}
+
+func (store *performanceStore) loadChunk(key chunkKey) []byte {
+	chunk := make([]byte, 2*chunkBytes)
+	stored, err := store.db.Get(blocksKey(key.pubKey, key.index))
+	if err != nil {
+		panic(err)
+	}
+	copy(chunk, stored)
+	return chunk
+}
+
+func blocksKey(pubKey types.Pubkey, index uint64) []byte {
+	key := make([]byte, 0, 1+len(pubKey)+8)
+	key = append(key, blocksPrefix)
+	key = append(key, pubKey.Bytes()...)
+	return append(key, uint64ToBytes(index)...)
+}
+
+func eventKey(pubKey types.Pubkey, height uint64, seq uint32) []byte {
+	key := make([]byte, 0, 1+len(pubKey)+8+4)
+	key = append(key, eventsPrefix)
+	key = append(key, pubKey.Bytes()...)
+	key = append(key, uint64ToBytes(height)...)
+	return append(key, uint32ToBytes(seq)...)
+}
+
+func uint64ToBytes(v uint64) []byte {
+	var b = make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+func uint32ToBytes(v uint32) []byte {
+	var b = make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	return b
+}
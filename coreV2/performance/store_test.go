@@ -0,0 +1,62 @@
+package performance
+
+import (
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestPerformanceStore_Summary(t *testing.T) {
+	store := NewPerformanceStore(db.NewMemDB())
+	pubKey := types.HexToPubkey("Mp9e13f2f5468dd782b316444fbd66595e13dba7d7bd3efa1becd50b42045f58c6")
+	other := types.HexToPubkey("Mp738da41ba6a7b7d69b7294afa158b89c5a1b410cbf0c2443c85c5fe24ad1dd1c")
+
+	for height := uint64(1000); height < 3100; height++ {
+		store.AddBlock(height, pubKey, height%10 != 0)
+		store.AddBlock(height, other, true)
+	}
+	store.AddEvent(&Event{Height: 2000, PubKey: pubKey, Type: TypeJail, JailedUntil: 3000})
+	store.AddEvent(&Event{Height: 2000, PubKey: pubKey, Type: TypeStatusOffline})
+	store.AddEvent(&Event{Height: 2500, PubKey: other, Type: TypeSlash})
+
+	if err := store.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	summary := store.Summary(pubKey, 0, 5000)
+	if summary.Signed != 1890 || summary.Missed != 210 {
+		t.Fatalf("signed %d, missed %d", summary.Signed, summary.Missed)
+	}
+	if summary.Jails != 1 || summary.Slashes != 0 {
+		t.Fatalf("jails %d, slashes %d", summary.Jails, summary.Slashes)
+	}
+	if summary.Uptime() != "90.00" {
+		t.Fatalf("uptime %s", summary.Uptime())
+	}
+
+	summary = store.Summary(pubKey, 1005, 1014)
+	if summary.Signed != 9 || summary.Missed != 1 {
+		t.Fatalf("signed %d, missed %d", summary.Signed, summary.Missed)
+	}
+
+	if summary := store.Summary(other, 0, 999); summary.Total() != 0 || summary.Uptime() != "" {
+		t.Fatalf("unexpected blocks %d", summary.Total())
+	}
+
+	blocks := store.Blocks(pubKey, 2045, 2055)
+	if len(blocks) != 11 {
+		t.Fatalf("blocks %d", len(blocks))
+	}
+	if blocks[0].Height != 2045 || blocks[5].Signed || !blocks[6].Signed {
+		t.Fatalf("unexpected blocks %#v", blocks)
+	}
+
+	events := store.Events(pubKey, 0, 2000)
+	if len(events) != 2 || events[0].Type != TypeJail || events[0].JailedUntil != 3000 {
+		t.Fatalf("unexpected events %#v", events)
+	}
+	if events := store.Events(pubKey, 2001, 5000); len(events) != 0 {
+		t.Fatalf("unexpected events %#v", events)
+	}
+}
@@ -0,0 +1,56 @@
+package performance
+
+import (
+	"fmt"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Event type names
+const (
+	TypeJail          = "jail"
+	TypeSlash         = "slash"
+	TypeStatusOffline = "status_offline"
+	TypeSetEnter      = "validator_set_enter"
+	TypeSetLeave      = "validator_set_leave"
+)
+
+// Event is a record of validator's jail, slash or status change
+type Event struct {
+	Height uint64       `json:"height"`
+	PubKey types.Pubkey `json:"pub_key"`
+	Type   string       `json:"type"`
+	// JailedUntil is set for jail events only
+	JailedUntil uint64 `json:"jailed_until,omitempty"`
+}
+
+// Block is a record of validator's signature for a block
+type Block struct {
+	Height uint64 `json:"height"`
+	Signed bool   `json:"signed"`
+}
+
+// Summary is an aggregated performance of a validator over a range of blocks
+type Summary struct {
+	FromHeight uint64 `json:"from_height"`
+	ToHeight   uint64 `json:"to_height"`
+	Signed     uint64 `json:"signed"`
+	Missed     uint64 `json:"missed"`
+	Jails      uint64 `json:"jails"`
+	Slashes    uint64 `json:"slashes"`
+}
+
+// Total returns count of blocks the validator was expected to sign
+func (s *Summary) Total() uint64 {
+	return s.Signed + s.Missed
+}
+
+// Uptime returns share of signed blocks in percent with two decimal places, empty string if validator was not in set
+func (s *Summary) Uptime() string {
+	total := s.Total()
+	if total == 0 {
+		return ""
+	}
+	basisPoints := s.Signed * 10000 / total
+	return fmt.Sprintf("%d.%02d", basisPoints/100, basisPoints%100)
+}
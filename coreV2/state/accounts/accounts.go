@@ -2,6 +2,7 @@ package accounts
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"sync/atomic"
 
@@ -19,6 +20,17 @@ import (
 const mainPrefix = byte('a')
 const coinsPrefix = byte('c')
 const balancePrefix = byte('b')
+const multisigMemberPrefix = byte('m')
+const multisigHistoryPrefix = byte('h')
+const allowancePrefix = byte('l')
+
+// holders index is stored out of the accounts prefix, its keys are ordered by coins and balances
+const holdersPrefix = byte('o')
+const holderBalancePrefix = byte('b')
+const holdersCountPrefix = byte('c')
+
+// holderBalanceLength is a length of balances in keys of the holders index
+const holderBalanceLength = 32
 
 type RAccounts interface {
 	// Deprecated
@@ -31,12 +43,30 @@ type RAccounts interface {
 	GetBalance(address types.Address, coin types.CoinID) *big.Int
 	GetBalances(address types.Address) []Balance
 	ExistsMultisig(msigAddress types.Address) bool
+	GetMultisigsOfMember(member types.Address) []types.Address
+	GetMultisigHistory(msigAddress types.Address) []*MultisigEdit
+	GetAllowance(owner, spender types.Address, coin types.CoinID) *big.Int
+	GetAllowances(owner types.Address) []Allowance
+	GetHoldersCount(coin types.CoinID) uint64
+	IterateHolders(coin types.CoinID, fn func(address types.Address, balance *big.Int) bool)
 }
 
 type Accounts struct {
 	list  map[types.Address]*Model
 	dirty map[types.Address]struct{}
 
+	// members is a changes of the member to multisig index, false value is for removal
+	members map[multisigMember]bool
+	// edits is a previous multisig configurations not committed to history yet
+	edits map[types.Address][]*MultisigEdit
+	// allowances is a changes of allowances not committed yet, zero value is for removal
+	allowances map[allowance]*big.Int
+
+	// indexes is non-zero after the holders and multisig members indexes are enabled by the network update
+	indexes uint32
+	// migration is non-zero if the next commit should build the indexes from the committed accounts
+	migration uint32
+
 	db  atomic.Value
 	bus *bus.Bus
 
@@ -48,12 +78,27 @@ type Balance struct {
 	Value *big.Int
 }
 
+// Allowance is an amount of the coin which the spender is allowed to transfer from the owner
+type Allowance struct {
+	Spender types.Address
+	Coin    types.CoinID
+	Value   *big.Int
+}
+
 func NewAccounts(stateBus *bus.Bus, db *iavl.ImmutableTree) *Accounts {
 	immutableTree := atomic.Value{}
 	if db != nil {
 		immutableTree.Store(db)
 	}
-	accounts := &Accounts{db: immutableTree, bus: stateBus, list: map[types.Address]*Model{}, dirty: map[types.Address]struct{}{}}
+	accounts := &Accounts{
+		db:         immutableTree,
+		bus:        stateBus,
+		list:       map[types.Address]*Model{},
+		dirty:      map[types.Address]struct{}{},
+		members:    map[multisigMember]bool{},
+		edits:      map[types.Address][]*MultisigEdit{},
+		allowances: map[allowance]*big.Int{},
+	}
 	accounts.bus.SetAccounts(NewBus(accounts))
 
 	return accounts
@@ -71,7 +116,26 @@ func (a *Accounts) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
 	a.db.Store(immutableTree)
 }
 
+// EnableIndexes starts maintaining of the holders index, the multisig members index and the multisig history
+func (a *Accounts) EnableIndexes() {
+	atomic.StoreUint32(&a.indexes, 1)
+}
+
+func (a *Accounts) isIndexesEnabled() bool {
+	return atomic.LoadUint32(&a.indexes) == 1
+}
+
+// MigrateIndexes makes the next commit build the holders and multisig members indexes from accounts committed before the indexes were enabled
+func (a *Accounts) MigrateIndexes() {
+	atomic.StoreUint32(&a.migration, 1)
+}
+
 func (a *Accounts) Commit(db *iavl.MutableTree, version int64) error {
+	holdersCount := map[types.CoinID]uint64{}
+	if atomic.CompareAndSwapUint32(&a.migration, 1, 0) {
+		a.migrateIndexes(db, holdersCount)
+	}
+
 	accounts := a.getOrderedDirtyAccounts()
 	for _, address := range accounts {
 		account := a.getFromMap(address)
@@ -128,6 +192,12 @@ func (a *Accounts) Commit(db *iavl.MutableTree, version int64) error {
 				path = append(path, coin.Bytes()...)
 
 				balance := account.getBalance(coin)
+
+				// the index follows stored bytes of balances
+				if a.isIndexesEnabled() {
+					_, enc := db.Get(path)
+					a.updateHolder(db, holdersCount, address, coin, big.NewInt(0).SetBytes(enc), big.NewInt(0).SetBytes(balance.Bytes()))
+				}
 				switch balance.Sign() {
 				case 0:
 					db.Remove(path)
@@ -148,6 +218,64 @@ func (a *Accounts) Commit(db *iavl.MutableTree, version int64) error {
 		}
 	}
 
+	for _, member := range a.getOrderedDirtyMembers() {
+		a.lock.Lock()
+		add := a.members[member]
+		delete(a.members, member)
+		a.lock.Unlock()
+
+		if add {
+			db.Set(member.path(), []byte{0x1})
+		} else {
+			db.Remove(member.path())
+		}
+	}
+
+	for _, address := range a.getOrderedEditedMultisigs() {
+		a.lock.Lock()
+		edits := a.edits[address]
+		delete(a.edits, address)
+		a.lock.Unlock()
+
+		for _, edit := range edits {
+			edit.Height = uint64(version) + 1
+		}
+
+		data, err := rlp.EncodeToBytes(append(a.loadMultisigHistory(address), edits...))
+		if err != nil {
+			return fmt.Errorf("can't encode multisig history at %x: %v", address[:], err)
+		}
+		db.Set(multisigHistoryPath(address), data)
+	}
+
+	holdersCoins := make([]types.CoinID, 0, len(holdersCount))
+	for coin := range holdersCount {
+		holdersCoins = append(holdersCoins, coin)
+	}
+	sort.Slice(holdersCoins, func(i, j int) bool {
+		return holdersCoins[i] < holdersCoins[j]
+	})
+	for _, coin := range holdersCoins {
+		if count := holdersCount[coin]; count == 0 {
+			db.Remove(holdersCountPath(coin))
+		} else {
+			db.Set(holdersCountPath(coin), big.NewInt(0).SetUint64(count).Bytes())
+		}
+	}
+
+	for _, key := range a.getOrderedDirtyAllowances() {
+		a.lock.Lock()
+		value := a.allowances[key]
+		delete(a.allowances, key)
+		a.lock.Unlock()
+
+		if value.Sign() == 1 {
+			db.Set(key.path(), value.Bytes())
+		} else {
+			db.Remove(key.path())
+		}
+	}
+
 	return nil
 }
 
@@ -278,6 +406,10 @@ func (a *Accounts) CreateMultisig(weights []uint32, addresses []types.Address, t
 	account.isDirty = true
 	a.setToMap(address, account)
 
+	if a.isIndexesEnabled() {
+		a.setMultisigMembers(address, nil, addresses)
+	}
+
 	return address
 }
 
@@ -285,6 +417,11 @@ func (a *Accounts) EditMultisig(threshold uint32, weights []uint32, addresses []
 	account := a.get(address)
 
 	account.lock.Lock()
+	previous := &MultisigEdit{
+		Threshold: account.MultisigData.Threshold,
+		Weights:   account.MultisigData.Weights,
+		Addresses: account.MultisigData.Addresses,
+	}
 	account.MultisigData = Multisig{
 		Threshold: threshold,
 		Weights:   weights,
@@ -296,9 +433,293 @@ func (a *Accounts) EditMultisig(threshold uint32, weights []uint32, addresses []
 	account.markDirty(account.address)
 	a.setToMap(address, account)
 
+	if a.isIndexesEnabled() {
+		a.setMultisigMembers(address, previous.Addresses, addresses)
+		a.lock.Lock()
+		a.edits[address] = append(a.edits[address], previous)
+		a.lock.Unlock()
+	}
+
 	return address
 }
 
+// GetMultisigsOfMember returns addresses of multisigs with the member
+func (a *Accounts) GetMultisigsOfMember(member types.Address) []types.Address {
+	multisigs := map[types.Address]bool{}
+
+	start := multisigMember{member: member}.path()[:2+types.AddressLength]
+	end := append(append([]byte{}, start[:len(start)-1]...), multisigMemberPrefix+1)
+	a.immutableTree().IterateRange(start, end, true, func(key []byte, value []byte) bool {
+		multisigs[types.BytesToAddress(key[len(start):])] = true
+		return false
+	})
+
+	a.lock.RLock()
+	for m, add := range a.members {
+		if m.member == member {
+			multisigs[m.multisig] = add
+		}
+	}
+	a.lock.RUnlock()
+
+	var addresses []types.Address
+	for address, ok := range multisigs {
+		if ok {
+			addresses = append(addresses, address)
+		}
+	}
+
+	sort.SliceStable(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) == -1
+	})
+
+	return addresses
+}
+
+// GetMultisigHistory returns previous configurations of the multisig in order of editing
+func (a *Accounts) GetMultisigHistory(msigAddress types.Address) []*MultisigEdit {
+	return a.loadMultisigHistory(msigAddress)
+}
+
+func (a *Accounts) loadMultisigHistory(msigAddress types.Address) []*MultisigEdit {
+	_, enc := a.immutableTree().Get(multisigHistoryPath(msigAddress))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	var history []*MultisigEdit
+	if err := rlp.DecodeBytes(enc, &history); err != nil {
+		panic(fmt.Sprintf("failed to decode multisig history at address %s: %s", msigAddress.String(), err))
+	}
+
+	return history
+}
+
+func (a *Accounts) setMultisigMembers(msigAddress types.Address, previous, current []types.Address) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for _, member := range previous {
+		a.members[multisigMember{member: member, multisig: msigAddress}] = false
+	}
+	for _, member := range current {
+		a.members[multisigMember{member: member, multisig: msigAddress}] = true
+	}
+}
+
+func (a *Accounts) getOrderedDirtyMembers() []multisigMember {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	members := make([]multisigMember, 0, len(a.members))
+	for member := range a.members {
+		members = append(members, member)
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		return bytes.Compare(members[i].path(), members[j].path()) == 1
+	})
+
+	return members
+}
+
+func (a *Accounts) getOrderedEditedMultisigs() []types.Address {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	addresses := make([]types.Address, 0, len(a.edits))
+	for address := range a.edits {
+		addresses = append(addresses, address)
+	}
+
+	sort.SliceStable(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) == 1
+	})
+
+	return addresses
+}
+
+// GetAllowance returns the amount of the coin which the spender is allowed to transfer from the owner
+func (a *Accounts) GetAllowance(owner, spender types.Address, coin types.CoinID) *big.Int {
+	key := allowance{owner: owner, spender: spender, coin: coin}
+
+	a.lock.RLock()
+	value, ok := a.allowances[key]
+	a.lock.RUnlock()
+	if ok {
+		return big.NewInt(0).Set(value)
+	}
+
+	_, enc := a.immutableTree().Get(key.path())
+	return big.NewInt(0).SetBytes(enc)
+}
+
+// GetAllowances returns non-zero allowances of the owner sorted by spenders and coins
+func (a *Accounts) GetAllowances(owner types.Address) []Allowance {
+	values := map[allowance]*big.Int{}
+
+	start := allowance{owner: owner}.path()[:2+types.AddressLength]
+	end := append(append([]byte{}, start[:len(start)-1]...), allowancePrefix+1)
+	a.immutableTree().IterateRange(start, end, true, func(key []byte, value []byte) bool {
+		spender := types.BytesToAddress(key[len(start) : len(start)+types.AddressLength])
+		coin := types.CoinID(binary.LittleEndian.Uint32(key[len(start)+types.AddressLength:]))
+		values[allowance{owner: owner, spender: spender, coin: coin}] = big.NewInt(0).SetBytes(value)
+		return false
+	})
+
+	a.lock.RLock()
+	for key, value := range a.allowances {
+		if key.owner == owner {
+			values[key] = big.NewInt(0).Set(value)
+		}
+	}
+	a.lock.RUnlock()
+
+	var allowances []Allowance
+	for key, value := range values {
+		if value.Sign() == 1 {
+			allowances = append(allowances, Allowance{Spender: key.spender, Coin: key.coin, Value: value})
+		}
+	}
+
+	sort.SliceStable(allowances, func(i, j int) bool {
+		if allowances[i].Spender == allowances[j].Spender {
+			return allowances[i].Coin < allowances[j].Coin
+		}
+		return bytes.Compare(allowances[i].Spender.Bytes(), allowances[j].Spender.Bytes()) == -1
+	})
+
+	return allowances
+}
+
+// SetAllowance replaces the amount of the coin which the spender is allowed to transfer from the owner, zero value revokes it
+func (a *Accounts) SetAllowance(owner, spender types.Address, coin types.CoinID, value *big.Int) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.allowances[allowance{owner: owner, spender: spender, coin: coin}] = big.NewInt(0).Set(value)
+}
+
+func (a *Accounts) getOrderedDirtyAllowances() []allowance {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	keys := make([]allowance, 0, len(a.allowances))
+	for key := range a.allowances {
+		keys = append(keys, key)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].path(), keys[j].path()) == 1
+	})
+
+	return keys
+}
+
+// updateHolder moves the address in the holders index of the coin from the old balance to the new one,
+// holdersCount accumulates counts of holders changed during the commit
+func (a *Accounts) updateHolder(db *iavl.MutableTree, holdersCount map[types.CoinID]uint64, address types.Address, coin types.CoinID, oldBalance, newBalance *big.Int) {
+	if oldBalance.Cmp(newBalance) == 0 {
+		return
+	}
+
+	count, ok := holdersCount[coin]
+	if !ok {
+		_, enc := db.Get(holdersCountPath(coin))
+		count = big.NewInt(0).SetBytes(enc).Uint64()
+	}
+
+	// the count is changed only by keys which are really removed or added, so it can't underflow
+	if oldBalance.Sign() == 1 {
+		if _, removed := db.Remove(holderPath(coin, oldBalance, address)); removed && count > 0 {
+			count--
+		}
+	}
+	if newBalance.Sign() == 1 {
+		if updated := db.Set(holderPath(coin, newBalance, address), []byte{0x1}); !updated {
+			count++
+		}
+	}
+
+	holdersCount[coin] = count
+}
+
+// migrateIndexes builds the holders index of all committed balances and the members index of all committed multisigs,
+// counts of holders are recalculated from scratch
+func (a *Accounts) migrateIndexes(db *iavl.MutableTree, holdersCount map[types.CoinID]uint64) {
+	immutableTree := a.immutableTree()
+	if immutableTree == nil {
+		return
+	}
+
+	immutableTree.IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		address := types.BytesToAddress(key[1 : 1+types.AddressLength])
+		switch {
+		case len(key) == 1+types.AddressLength:
+			account := &Model{}
+			if err := rlp.DecodeBytes(value, account); err != nil {
+				panic(fmt.Sprintf("failed to decode account at address %s: %s", address.String(), err))
+			}
+			for _, member := range account.MultisigData.Addresses {
+				db.Set(multisigMember{member: member, multisig: address}.path(), []byte{0x1})
+			}
+		case len(key) == 2+types.AddressLength+4 && key[1+types.AddressLength] == balancePrefix:
+			balance := big.NewInt(0).SetBytes(value)
+			if balance.Sign() != 1 {
+				return false
+			}
+
+			coin := types.BytesToCoinID(key[2+types.AddressLength:])
+			db.Set(holderPath(coin, balance, address), []byte{0x1})
+			holdersCount[coin]++
+		}
+		return false
+	})
+}
+
+// GetHoldersCount returns the number of addresses with positive balance of the coin
+func (a *Accounts) GetHoldersCount(coin types.CoinID) uint64 {
+	_, enc := a.immutableTree().Get(holdersCountPath(coin))
+	return big.NewInt(0).SetBytes(enc).Uint64()
+}
+
+// IterateHolders calls fn for holders of the coin in descending order of their committed balances until it returns true
+func (a *Accounts) IterateHolders(coin types.CoinID, fn func(address types.Address, balance *big.Int) bool) {
+	start := holderPath(coin, big.NewInt(0), types.Address{})[:2+4]
+	end := holderPath(coin+1, big.NewInt(0), types.Address{})[:2+4]
+	if coin+1 == 0 {
+		end = []byte{holdersPrefix, holderBalancePrefix + 1}
+	}
+	a.immutableTree().IterateRange(start, end, false, func(key []byte, value []byte) bool {
+		balance := big.NewInt(0).SetBytes(key[len(start) : len(start)+holderBalanceLength])
+		return fn(types.BytesToAddress(key[len(start)+holderBalanceLength:]), balance)
+	})
+}
+
+func holderPath(coin types.CoinID, balance *big.Int, address types.Address) []byte {
+	path := []byte{holdersPrefix, holderBalancePrefix}
+	path = append(path, coinIndexBytes(coin)...)
+	path = append(path, balance.FillBytes(make([]byte, holderBalanceLength))...)
+	return append(path, address[:]...)
+}
+
+func holdersCountPath(coin types.CoinID) []byte {
+	return append([]byte{holdersPrefix, holdersCountPrefix}, coinIndexBytes(coin)...)
+}
+
+// coinIndexBytes returns big endian bytes of the coin id to keep coins of the index ordered
+func coinIndexBytes(coin types.CoinID) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, coin.Uint32())
+	return b
+}
+
+func multisigHistoryPath(msigAddress types.Address) []byte {
+	path := []byte{mainPrefix}
+	path = append(path, msigAddress[:]...)
+	return append(path, multisigHistoryPrefix)
+}
+
 func (a *Accounts) get(address types.Address) *Model {
 	if account := a.getFromMap(address); account != nil {
 		return account
@@ -436,6 +857,14 @@ func (a *Accounts) Export(state *types.AppState) {
 			LockStakeUntilBlock: account.LockStakeUntilBlock,
 		}
 
+		for _, al := range a.GetAllowances(account.address) {
+			acc.Allowances = append(acc.Allowances, types.Allowance{
+				Spender: al.Spender,
+				Coin:    uint64(al.Coin),
+				Value:   al.Value.String(),
+			})
+		}
+
 		if account.IsMultisig() {
 			var weights []uint64
 			for _, weight := range account.MultisigData.Weights {
@@ -448,7 +877,7 @@ func (a *Accounts) Export(state *types.AppState) {
 			}
 		}
 
-		if len(acc.Balance) == 0 && acc.Nonce == 0 && acc.MultisigData == nil {
+		if len(acc.Balance) == 0 && acc.Nonce == 0 && acc.MultisigData == nil && len(acc.Allowances) == 0 {
 			return false
 		}
 
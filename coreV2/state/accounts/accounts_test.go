@@ -344,3 +344,199 @@ func TestAccounts_Export(t *testing.T) {
 		t.Fatal("not equal JSON")
 	}
 }
+
+func TestAccounts_MultisigHistory(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	accounts := NewAccounts(b, mutableTree.GetLastImmutable())
+	accounts.EnableIndexes()
+
+	msigAddress := CreateMultisigAddress([20]byte{4}, 12)
+	_ = accounts.CreateMultisig([]uint32{3, 3}, []types.Address{[20]byte{1}, [20]byte{2}}, 6, msigAddress)
+
+	if multisigs := accounts.GetMultisigsOfMember([20]byte{1}); len(multisigs) != 1 || multisigs[0] != msigAddress {
+		t.Fatalf("wrong multisigs of member: %v", multisigs)
+	}
+
+	if _, _, err := mutableTree.Commit(accounts); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = accounts.EditMultisig(2, []uint32{1, 1}, []types.Address{[20]byte{2}, [20]byte{3}}, msigAddress)
+
+	if multisigs := accounts.GetMultisigsOfMember([20]byte{1}); len(multisigs) != 0 {
+		t.Fatalf("removed member has multisigs: %v", multisigs)
+	}
+
+	if _, _, err := mutableTree.Commit(accounts); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, member := range []types.Address{{2}, {3}} {
+		if multisigs := accounts.GetMultisigsOfMember(member); len(multisigs) != 1 || multisigs[0] != msigAddress {
+			t.Fatalf("wrong multisigs of member %s: %v", member.String(), multisigs)
+		}
+	}
+	if multisigs := accounts.GetMultisigsOfMember([20]byte{1}); len(multisigs) != 0 {
+		t.Fatalf("removed member has multisigs: %v", multisigs)
+	}
+
+	history := accounts.GetMultisigHistory(msigAddress)
+	if len(history) != 1 {
+		t.Fatalf("history length %d", len(history))
+	}
+	if history[0].Height != 2 || history[0].Threshold != 6 || len(history[0].Addresses) != 2 || history[0].Addresses[0] != [20]byte{1} {
+		t.Fatalf("wrong history %+v", history[0])
+	}
+}
+
+func TestAccounts_Allowances(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	accounts := NewAccounts(b, mutableTree.GetLastImmutable())
+
+	owner := types.Address{1}
+	accounts.SetNonce(owner, 1)
+	accounts.SetAllowance(owner, types.Address{3}, 0, big.NewInt(100))
+	accounts.SetAllowance(owner, types.Address{2}, 1, big.NewInt(200))
+	accounts.SetAllowance(owner, types.Address{2}, 0, big.NewInt(300))
+
+	_, _, err := mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts.SetAllowance(owner, types.Address{3}, 0, big.NewInt(0))
+	if allowance := accounts.GetAllowance(owner, types.Address{3}, 0); allowance.Sign() != 0 {
+		t.Fatalf("allowance %s, want 0", allowance)
+	}
+
+	allowances := accounts.GetAllowances(owner)
+	if len(allowances) != 2 || allowances[0].Coin != 0 || allowances[0].Value.Cmp(big.NewInt(300)) != 0 || allowances[1].Coin != 1 {
+		t.Fatalf("allowances %#v", allowances)
+	}
+
+	_, _, err = mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewAccounts(b, mutableTree.GetLastImmutable())
+	if allowance := loaded.GetAllowance(owner, types.Address{2}, 1); allowance.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("allowance %s, want 200", allowance)
+	}
+	if allowances := loaded.GetAllowances(owner); len(allowances) != 2 {
+		t.Fatalf("allowances %#v", allowances)
+	}
+
+	state := new(types.AppState)
+	loaded.Export(state)
+	if len(state.Accounts) != 1 || len(state.Accounts[0].Allowances) != 2 || state.Accounts[0].Allowances[1].Value != "200" {
+		t.Fatalf("exported %#v", state.Accounts)
+	}
+}
+
+func TestAccounts_Holders(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	accounts := NewAccounts(b, mutableTree.GetLastImmutable())
+	accounts.EnableIndexes()
+
+	accounts.SetBalance([20]byte{1}, 1, big.NewInt(100))
+	accounts.SetBalance([20]byte{2}, 1, big.NewInt(300))
+	accounts.SetBalance([20]byte{3}, 1, big.NewInt(200))
+	accounts.SetBalance([20]byte{3}, 2, big.NewInt(1))
+
+	_, _, err := mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts.SetBalance([20]byte{1}, 1, big.NewInt(400))
+	accounts.SetBalance([20]byte{2}, 1, big.NewInt(0))
+
+	_, _, err = mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts.SetImmutableTree(mutableTree.GetLastImmutable())
+	if count := accounts.GetHoldersCount(1); count != 2 {
+		t.Fatalf("holders count %d, want 2", count)
+	}
+	if count := accounts.GetHoldersCount(2); count != 1 {
+		t.Fatalf("holders count %d, want 1", count)
+	}
+
+	var holders []types.Address
+	var balances []string
+	accounts.IterateHolders(1, func(address types.Address, balance *big.Int) bool {
+		holders = append(holders, address)
+		balances = append(balances, balance.String())
+		return false
+	})
+	if len(holders) != 2 || holders[0] != [20]byte{1} || balances[0] != "400" || holders[1] != [20]byte{3} || balances[1] != "200" {
+		t.Fatalf("holders %v with balances %v", holders, balances)
+	}
+}
+
+func TestAccounts_MigrateIndexes(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	accounts := NewAccounts(b, mutableTree.GetLastImmutable())
+
+	accounts.SetBalance([20]byte{1}, 1, big.NewInt(100))
+	accounts.SetBalance([20]byte{2}, 1, big.NewInt(300))
+	accounts.CreateMultisig([]uint32{1, 1}, []types.Address{{1}, {2}}, 2, [20]byte{4})
+	accounts.CreateMultisig([]uint32{1}, []types.Address{{1}}, 1, [20]byte{5})
+
+	_, _, err := mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts.SetImmutableTree(mutableTree.GetLastImmutable())
+	if count := accounts.GetHoldersCount(1); count != 0 {
+		t.Fatalf("holders count %d before the indexes are enabled, want 0", count)
+	}
+
+	accounts.EnableIndexes()
+	accounts.MigrateIndexes()
+	accounts.SetBalance([20]byte{2}, 1, big.NewInt(0))
+	accounts.SetBalance([20]byte{3}, 1, big.NewInt(200))
+	accounts.EditMultisig(1, []uint32{1}, []types.Address{{2}}, [20]byte{5})
+
+	_, _, err = mutableTree.Commit(accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts.SetImmutableTree(mutableTree.GetLastImmutable())
+	if count := accounts.GetHoldersCount(1); count != 2 {
+		t.Fatalf("holders count %d, want 2", count)
+	}
+
+	var holders []types.Address
+	accounts.IterateHolders(1, func(address types.Address, balance *big.Int) bool {
+		holders = append(holders, address)
+		return false
+	})
+	if len(holders) != 2 || holders[0] != [20]byte{3} || holders[1] != [20]byte{1} {
+		t.Fatalf("holders %v", holders)
+	}
+
+	if multisigs := accounts.GetMultisigsOfMember([20]byte{1}); len(multisigs) != 1 || multisigs[0] != [20]byte{4} {
+		t.Fatalf("multisigs of the first member %v", multisigs)
+	}
+	if multisigs := accounts.GetMultisigsOfMember([20]byte{2}); len(multisigs) != 2 {
+		t.Fatalf("multisigs of the second member %v", multisigs)
+	}
+}
@@ -38,6 +38,42 @@ type Multisig struct {
 	lock sync.RWMutex
 }
 
+// MultisigEdit is a configuration of multisig which was replaced at Height
+type MultisigEdit struct {
+	Height    uint64
+	Threshold uint32
+	Weights   []uint32
+	Addresses []types.Address
+}
+
+// multisigMember is a key of the member to multisig index
+type multisigMember struct {
+	member   types.Address
+	multisig types.Address
+}
+
+func (m multisigMember) path() []byte {
+	path := []byte{mainPrefix}
+	path = append(path, m.member[:]...)
+	path = append(path, multisigMemberPrefix)
+	return append(path, m.multisig[:]...)
+}
+
+// allowance is a key of the amount of the coin which the spender is allowed to transfer from the owner
+type allowance struct {
+	owner   types.Address
+	spender types.Address
+	coin    types.CoinID
+}
+
+func (al allowance) path() []byte {
+	path := []byte{mainPrefix}
+	path = append(path, al.owner[:]...)
+	path = append(path, allowancePrefix)
+	path = append(path, al.spender[:]...)
+	return append(path, al.coin.Bytes()...)
+}
+
 func CreateMultisigAddress(owner types.Address, nonce uint64) types.Address {
 	b, err := rlp.EncodeToBytes(&struct {
 		Owner types.Address
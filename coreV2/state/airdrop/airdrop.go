@@ -0,0 +1,394 @@
+package airdrop
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('e')
+
+const (
+	airdropPrefix = byte('p')
+	heightPrefix  = byte('h')
+	claimPrefix   = byte('c')
+	nextIDPrefix  = byte('i')
+)
+
+type RAirdrop interface {
+	Export(state *types.AppState)
+	GetAirdrop(id uint32) *Airdrop
+	GetAirdrops(height uint64) []*Airdrop
+	IsClaimed(id uint32, address types.Address) bool
+}
+
+type claimKey struct {
+	airdropID uint32
+	address   types.Address
+}
+
+// Airdrops is a store of coins reserved for recipients of Merkle trees until the expiration
+type Airdrops struct {
+	airdrops     map[uint32]*Airdrop
+	claims       map[claimKey]struct{} // claims are kept until the commit
+	dirty        map[uint32]struct{}
+	deleted      map[uint32]struct{}
+	nextID       uint32
+	dirtyNextID  bool
+	loadedNextID bool
+
+	bus *bus.Bus
+	db  atomic.Value
+
+	lock sync.RWMutex
+}
+
+func NewAirdrops(stateBus *bus.Bus, db *iavl.ImmutableTree) *Airdrops {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &Airdrops{
+		bus:      stateBus,
+		db:       immutableTree,
+		airdrops: map[uint32]*Airdrop{},
+		claims:   map[claimKey]struct{}{},
+		dirty:    map[uint32]struct{}{},
+		deleted:  map[uint32]struct{}{},
+	}
+}
+
+func (a *Airdrops) immutableTree() *iavl.ImmutableTree {
+	db := a.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (a *Airdrops) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	a.db.Store(immutableTree)
+}
+
+func (a *Airdrops) Commit(db *iavl.MutableTree, version int64) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.dirtyNextID {
+		a.dirtyNextID = false
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, a.nextID)
+		db.Set([]byte{mainPrefix, nextIDPrefix}, b)
+	}
+
+	keys := make([]claimKey, 0, len(a.claims))
+	for key := range a.claims {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].airdropID != keys[j].airdropID {
+			return keys[i].airdropID < keys[j].airdropID
+		}
+		return bytes.Compare(keys[i].address.Bytes(), keys[j].address.Bytes()) == -1
+	})
+	for _, key := range keys {
+		if _, ok := a.deleted[key.airdropID]; ok {
+			continue
+		}
+		db.Set(pathClaim(key.airdropID, key.address), []byte{0x1})
+	}
+
+	ids := make([]uint32, 0, len(a.dirty))
+	for id := range a.dirty {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	for _, id := range ids {
+		airdrop := a.airdrops[id]
+		if _, ok := a.deleted[id]; ok {
+			for _, address := range a.getClaims(id) {
+				db.Remove(pathClaim(id, address))
+			}
+			delete(a.airdrops, id)
+			db.Remove(pathAirdrop(id))
+			db.Remove(pathHeight(airdrop.ExpireHeight, id))
+			continue
+		}
+
+		airdrop.lock.RLock()
+		data, err := rlp.EncodeToBytes(airdrop)
+		airdrop.lock.RUnlock()
+		if err != nil {
+			return fmt.Errorf("can't encode airdrop %d: %v", id, err)
+		}
+		db.Set(pathAirdrop(id), data)
+		db.Set(pathHeight(airdrop.ExpireHeight, id), []byte{0x1})
+	}
+	a.claims = map[claimKey]struct{}{}
+	a.dirty = map[uint32]struct{}{}
+	a.deleted = map[uint32]struct{}{}
+
+	return nil
+}
+
+// GetAirdrop returns the airdrop or nil if it does not exist
+func (a *Airdrops) GetAirdrop(id uint32) *Airdrop {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.getAirdrop(id)
+}
+
+func (a *Airdrops) getAirdrop(id uint32) *Airdrop {
+	if _, ok := a.deleted[id]; ok {
+		return nil
+	}
+	if airdrop, ok := a.airdrops[id]; ok {
+		return airdrop
+	}
+
+	_, data := a.immutableTree().Get(pathAirdrop(id))
+	if len(data) == 0 {
+		return nil
+	}
+
+	airdrop := &Airdrop{}
+	if err := rlp.DecodeBytes(data, airdrop); err != nil {
+		panic(fmt.Sprintf("failed to decode airdrop %d: %s", id, err))
+	}
+	airdrop.id = id
+	airdrop.markDirty = a.markDirty
+	a.airdrops[id] = airdrop
+
+	return airdrop
+}
+
+// GetAirdrops returns airdrops expiring at the height sorted by ids
+func (a *Airdrops) GetAirdrops(height uint64) []*Airdrop {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	from := pathHeight(height, 0)
+	a.immutableTree().IterateRange(from, pathHeight(height+1, 0), true, func(key []byte, value []byte) bool {
+		a.getAirdrop(binary.BigEndian.Uint32(key[len(from)-4:]))
+		return false
+	})
+
+	var airdrops []*Airdrop
+	for id, airdrop := range a.airdrops {
+		if _, ok := a.deleted[id]; !ok && airdrop.ExpireHeight == height {
+			airdrops = append(airdrops, airdrop)
+		}
+	}
+	sort.Slice(airdrops, func(i, j int) bool {
+		return airdrops[i].id < airdrops[j].id
+	})
+
+	return airdrops
+}
+
+// IsClaimed returns true if the address has claimed its value of the airdrop
+func (a *Airdrops) IsClaimed(id uint32, address types.Address) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.isClaimed(id, address)
+}
+
+func (a *Airdrops) isClaimed(id uint32, address types.Address) bool {
+	if _, ok := a.claims[claimKey{airdropID: id, address: address}]; ok {
+		return true
+	}
+
+	_, data := a.immutableTree().Get(pathClaim(id, address))
+	return len(data) != 0
+}
+
+// getClaims returns addresses which have claimed the airdrop sorted by bytes
+func (a *Airdrops) getClaims(id uint32) []types.Address {
+	claimed := map[types.Address]struct{}{}
+	from := pathClaim(id, types.Address{})
+	a.immutableTree().IterateRange(from, pathClaim(id+1, types.Address{}), true, func(key []byte, value []byte) bool {
+		claimed[types.BytesToAddress(key[len(from)-types.AddressLength:])] = struct{}{}
+		return false
+	})
+	for key := range a.claims {
+		if key.airdropID == id {
+			claimed[key.address] = struct{}{}
+		}
+	}
+
+	addresses := make([]types.Address, 0, len(claimed))
+	for address := range claimed {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) == -1
+	})
+
+	return addresses
+}
+
+// CreateAirdrop takes value of the coin to the new airdrop expiring at the height and returns its id
+func (a *Airdrops) CreateAirdrop(owner types.Address, coin types.CoinID, value *big.Int, merkleRoot types.Hash, expireHeight uint64) uint32 {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	id := a.getNextID()
+	a.nextID = id + 1
+	a.dirtyNextID = true
+
+	a.airdrops[id] = &Airdrop{
+		Owner:        owner,
+		Coin:         coin,
+		Value:        new(big.Int).Set(value),
+		MerkleRoot:   merkleRoot,
+		ExpireHeight: expireHeight,
+		Claimed:      big.NewInt(0),
+		id:           id,
+		markDirty:    a.markDirty,
+	}
+	a.markDirty(id)
+	a.bus.Checker().AddCoin(coin, value)
+
+	return id
+}
+
+// Claim pays value of the airdrop to the address and marks it as claimed
+func (a *Airdrops) Claim(id uint32, address types.Address, value *big.Int) types.CoinID {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	airdrop := a.getAirdrop(id)
+	airdrop.claim(value)
+	key := claimKey{airdropID: id, address: address}
+	a.claims[key] = struct{}{}
+	a.bus.Checker().AddCoin(airdrop.Coin, new(big.Int).Neg(value))
+
+	return airdrop.Coin
+}
+
+// Delete removes the airdrop with its claims, unclaimed coins should be returned to the owner
+func (a *Airdrops) Delete(id uint32) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	airdrop := a.getAirdrop(id)
+	if airdrop == nil {
+		return
+	}
+	a.deleted[id] = struct{}{}
+	a.markDirty(id)
+	a.bus.Checker().AddCoin(airdrop.Coin, new(big.Int).Neg(airdrop.Remainder()))
+}
+
+func (a *Airdrops) getNextID() uint32 {
+	if a.loadedNextID {
+		return a.nextID
+	}
+	a.loadedNextID = true
+
+	_, data := a.immutableTree().Get([]byte{mainPrefix, nextIDPrefix})
+	if len(data) == 0 {
+		a.nextID = 1
+	} else {
+		a.nextID = binary.BigEndian.Uint32(data)
+	}
+
+	return a.nextID
+}
+
+func (a *Airdrops) markDirty(id uint32) {
+	a.dirty[id] = struct{}{}
+}
+
+func (a *Airdrops) Export(state *types.AppState) {
+	var ids []uint32
+	a.immutableTree().IterateRange([]byte{mainPrefix, airdropPrefix}, []byte{mainPrefix, airdropPrefix + 1}, true, func(key []byte, value []byte) bool {
+		ids = append(ids, binary.BigEndian.Uint32(key[2:]))
+		return false
+	})
+
+	for _, id := range ids {
+		airdrop := a.GetAirdrop(id)
+		if airdrop == nil {
+			continue
+		}
+		a.lock.Lock()
+		claims := a.getClaims(id)
+		a.lock.Unlock()
+		state.Airdrops = append(state.Airdrops, types.Airdrop{
+			ID:           uint64(id),
+			Owner:        airdrop.Owner,
+			Coin:         uint64(airdrop.Coin),
+			Value:        airdrop.Value.String(),
+			MerkleRoot:   airdrop.MerkleRoot,
+			ExpireHeight: airdrop.ExpireHeight,
+			Claimed:      airdrop.Claimed.String(),
+			Claims:       claims,
+		})
+	}
+}
+
+func (a *Airdrops) Import(state *types.AppState) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.getNextID()
+	for _, exported := range state.Airdrops {
+		id := uint32(exported.ID)
+		airdrop := &Airdrop{
+			Owner:        exported.Owner,
+			Coin:         types.CoinID(exported.Coin),
+			Value:        helpers.StringToBigInt(exported.Value),
+			MerkleRoot:   exported.MerkleRoot,
+			ExpireHeight: exported.ExpireHeight,
+			Claimed:      helpers.StringToBigInt(exported.Claimed),
+			id:           id,
+			markDirty:    a.markDirty,
+		}
+		a.airdrops[id] = airdrop
+		a.markDirty(id)
+		for _, address := range exported.Claims {
+			a.claims[claimKey{airdropID: id, address: address}] = struct{}{}
+		}
+
+		a.bus.Checker().AddCoin(airdrop.Coin, new(big.Int).Sub(airdrop.Value, airdrop.Claimed))
+
+		if id >= a.nextID {
+			a.nextID = id + 1
+			a.dirtyNextID = true
+		}
+	}
+}
+
+func pathAirdrop(id uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, id)
+	return append([]byte{mainPrefix, airdropPrefix}, b...)
+}
+
+func pathHeight(height uint64, id uint32) []byte {
+	b := make([]byte, 12)
+	binary.BigEndian.PutUint64(b, height)
+	binary.BigEndian.PutUint32(b[8:], id)
+	return append([]byte{mainPrefix, heightPrefix}, b...)
+}
+
+func pathClaim(id uint32, address types.Address) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, id)
+	return append(append([]byte{mainPrefix, claimPrefix}, b...), address.Bytes()...)
+}
@@ -0,0 +1,130 @@
+package airdrop
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestVerifyProof(t *testing.T) {
+	t.Parallel()
+	leaves := []types.Hash{
+		LeafHash(types.Address{1}, big.NewInt(100)),
+		LeafHash(types.Address{2}, big.NewInt(200)),
+		LeafHash(types.Address{3}, big.NewInt(300)),
+	}
+	left := NodeHash(leaves[0], leaves[1])
+	root := NodeHash(left, leaves[2])
+
+	if !VerifyProof(root, leaves[0], []types.Hash{leaves[1], leaves[2]}) {
+		t.Error("proof of the first leaf is not verified")
+	}
+	if !VerifyProof(root, leaves[2], []types.Hash{left}) {
+		t.Error("proof of the last leaf is not verified")
+	}
+	if VerifyProof(root, LeafHash(types.Address{1}, big.NewInt(101)), []types.Hash{leaves[1], leaves[2]}) {
+		t.Error("proof of the wrong value is verified")
+	}
+	if VerifyProof(root, leaves[0], make([]types.Hash, MaxProofLength+1)) {
+		t.Error("too long proof is verified")
+	}
+}
+
+func TestAirdrops_ClaimAndDelete(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	a := NewAirdrops(b, mutableTree.GetLastImmutable())
+
+	owner, alice := types.Address{1}, types.Address{2}
+	id := a.CreateAirdrop(owner, 1, big.NewInt(1000), types.Hash{1}, 100)
+	if id != 1 {
+		t.Fatalf("airdrop id %d, want 1", id)
+	}
+
+	a.Claim(id, alice, big.NewInt(300))
+	if !a.IsClaimed(id, alice) {
+		t.Error("claim is not marked before commit")
+	}
+
+	_, _, err := mutableTree.Commit(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !a.IsClaimed(id, alice) {
+		t.Error("claim is not marked after commit")
+	}
+	if a.IsClaimed(id, owner) {
+		t.Error("owner is marked as claimed")
+	}
+	if remainder := a.GetAirdrop(id).Remainder(); remainder.Cmp(big.NewInt(700)) != 0 {
+		t.Errorf("remainder %s, want 700", remainder)
+	}
+	if airdrops := a.GetAirdrops(100); len(airdrops) != 1 || airdrops[0].ID() != id {
+		t.Fatalf("airdrops expiring at 100: %#v", airdrops)
+	}
+
+	a.Delete(id)
+	_, _, err = mutableTree.Commit(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.GetAirdrop(id) != nil {
+		t.Error("airdrop is not deleted")
+	}
+	if a.IsClaimed(id, alice) {
+		t.Error("claim of the deleted airdrop is not removed")
+	}
+	if airdrops := a.GetAirdrops(100); len(airdrops) != 0 {
+		t.Errorf("deleted airdrop expires at 100: %#v", airdrops)
+	}
+}
+
+func TestAirdrops_ExportImport(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	a := NewAirdrops(b, mutableTree.GetLastImmutable())
+
+	owner, alice := types.Address{1}, types.Address{2}
+	id := a.CreateAirdrop(owner, 1, big.NewInt(1000), types.Hash{1}, 100)
+	a.Claim(id, alice, big.NewInt(300))
+
+	_, _, err := mutableTree.Commit(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := new(types.AppState)
+	a.Export(state)
+	if len(state.Airdrops) != 1 || len(state.Airdrops[0].Claims) != 1 {
+		t.Fatalf("exported %#v", state.Airdrops)
+	}
+
+	importedTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	imported := NewAirdrops(b, importedTree.GetLastImmutable())
+	imported.Import(state)
+	_, _, err = importedTree.Commit(imported)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !imported.IsClaimed(id, alice) {
+		t.Error("claim is not imported")
+	}
+	if remainder := imported.GetAirdrop(id).Remainder(); remainder.Cmp(big.NewInt(700)) != 0 {
+		t.Errorf("remainder after import %s, want 700", remainder)
+	}
+	if next := imported.CreateAirdrop(owner, 1, big.NewInt(1), types.Hash{1}, 100); next != id+1 {
+		t.Errorf("next airdrop id %d, want %d", next, id+1)
+	}
+}
@@ -0,0 +1,44 @@
+package airdrop
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Airdrop is Value of Coin reserved by Owner for recipients of the Merkle tree with MerkleRoot.
+// Each recipient claims its value once with the proof of inclusion, unclaimed coins are returned to Owner at ExpireHeight.
+type Airdrop struct {
+	Owner        types.Address
+	Coin         types.CoinID
+	Value        *big.Int
+	MerkleRoot   types.Hash
+	ExpireHeight uint64
+	Claimed      *big.Int
+
+	id        uint32
+	markDirty func(id uint32)
+
+	lock sync.RWMutex
+}
+
+func (a *Airdrop) ID() uint32 {
+	return a.id
+}
+
+// Remainder returns coins of the airdrop which are not claimed yet
+func (a *Airdrop) Remainder() *big.Int {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return new(big.Int).Sub(a.Value, a.Claimed)
+}
+
+func (a *Airdrop) claim(value *big.Int) {
+	a.lock.Lock()
+	a.Claimed = new(big.Int).Add(a.Claimed, value)
+	a.lock.Unlock()
+
+	a.markDirty(a.id)
+}
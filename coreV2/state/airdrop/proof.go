@@ -0,0 +1,41 @@
+package airdrop
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+)
+
+// MaxProofLength is the maximum number of hashes in the proof, enough for a tree of 2^32 recipients
+const MaxProofLength = 32
+
+// LeafHash returns the leaf of the airdrop Merkle tree for value of the coin to the address,
+// it is keccak256 of the address followed by the value as 32 bytes big-endian
+func LeafHash(address types.Address, value *big.Int) types.Hash {
+	return types.BytesToHash(crypto.Keccak256(address.Bytes(), value.FillBytes(make([]byte, 32))))
+}
+
+// NodeHash returns the parent of two nodes of the Merkle tree, nodes are hashed in ascending order
+func NodeHash(a, b types.Hash) types.Hash {
+	if bytes.Compare(a.Bytes(), b.Bytes()) == 1 {
+		a, b = b, a
+	}
+	return types.BytesToHash(crypto.Keccak256(a.Bytes(), b.Bytes()))
+}
+
+// VerifyProof returns true if the leaf is included in the tree with the root,
+// the proof is a list of sibling nodes from the leaf to the root
+func VerifyProof(root, leaf types.Hash, proof []types.Hash) bool {
+	if len(proof) > MaxProofLength {
+		return false
+	}
+
+	node := leaf
+	for _, sibling := range proof {
+		node = NodeHash(node, sibling)
+	}
+
+	return node == root
+}
@@ -1,6 +1,9 @@
 package bus
 
-import eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+import (
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
 
 type Bus struct {
 	coins       Coins
@@ -13,6 +16,9 @@ type Bus struct {
 	events      eventsdb.IEventsDB
 	checker     Checker
 	validators  Validators
+	params      Params
+
+	txHash types.Hash
 }
 
 func NewBus() *Bus {
@@ -98,3 +104,20 @@ func (b *Bus) SetChecker(checker Checker) {
 func (b *Bus) Checker() Checker {
 	return b.checker
 }
+
+func (b *Bus) SetParams(params Params) {
+	b.params = params
+}
+
+func (b *Bus) Params() Params {
+	return b.params
+}
+
+// SetTxHash sets the hash of the transaction being delivered, zero hash means changes are made outside of transactions
+func (b *Bus) SetTxHash(hash types.Hash) {
+	b.txHash = hash
+}
+
+func (b *Bus) TxHash() types.Hash {
+	return b.txHash
+}
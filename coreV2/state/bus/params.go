@@ -0,0 +1,5 @@
+package bus
+
+type Params interface {
+	GetUnbondPeriod() uint64
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/MinterTeam/minter-go-node/coreV2/state/app"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/coins"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/waitlist"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
@@ -720,6 +721,7 @@ func TestCandidates_PunishByzantineCandidate(t *testing.T) {
 
 	b.SetApp(appBus)
 	b.SetChecker(checker.NewChecker(b))
+	params.New(b, mutableTree.GetLastImmutable())
 	candidates := NewCandidates(b, mutableTree.GetLastImmutable())
 
 	coinsState := coins.NewCoins(b, mutableTree.GetLastImmutable())
@@ -970,6 +972,42 @@ func TestCandidates_GetNewCandidates(t *testing.T) {
 	}
 }
 
+func TestCandidates_ValidatorStakeThreshold(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	candidates := NewCandidates(b, mutableTree.GetLastImmutable())
+
+	for pubkey, value := range map[byte]string{4: "2000000000000000000000", 5: "5000000000000000000000", 6: "1500000000000000000000"} {
+		candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{pubkey}, 10, 0, 0)
+		candidates.SetStakes([32]byte{pubkey}, []types.Stake{
+			{
+				Owner:    [20]byte{1},
+				Coin:     0,
+				Value:    value,
+				BipValue: value,
+			},
+		}, nil)
+	}
+	candidates.SetOnline([32]byte{4})
+	candidates.SetOnline([32]byte{6})
+
+	candidates.RecalculateStakes(1)
+
+	_, _, err := mutableTree.Commit(candidates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if threshold := candidates.ValidatorStakeThreshold(1); threshold.String() != "2000000000000000000000" {
+		t.Errorf("threshold of one validator is %s, offline candidate is counted", threshold)
+	}
+	if threshold := candidates.ValidatorStakeThreshold(3); threshold.Cmp(MinValidatorBipStake()) != 0 {
+		t.Errorf("threshold of free validators slots is %s, want minimal validator stake", threshold)
+	}
+}
+
 func TestCandidate_GetFilteredUpdates(t *testing.T) {
 	t.Parallel()
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
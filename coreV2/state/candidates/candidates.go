@@ -56,7 +56,9 @@ type RCandidates interface {
 	PubKey(id uint32) types.Pubkey
 	Count() int
 	IsNewCandidateStakeSufficient(coin types.CoinID, stake *big.Int, limit int) bool
+	ValidatorStakeThreshold(valCount int) *big.Int
 	IsDelegatorStakeSufficient(address types.Address, pubkey types.Pubkey, coin types.CoinID, amount *big.Int) bool
+	BipValue(coin types.CoinID, amount *big.Int) *big.Int
 	IsDelegatorStakeAllowed(address types.Address, pubkey types.Pubkey, coin types.CoinID, amount *big.Int) (low, big bool)
 	GetStakeValueOfAddress(pubkey types.Pubkey, address types.Address, coin types.CoinID) *big.Int
 	GetCandidateOwner(pubkey types.Pubkey) types.Address
@@ -371,6 +373,11 @@ func (c *Candidates) Commit(db *iavl.MutableTree, version int64) error {
 	return nil
 }
 
+// MinValidatorBipStake returns minimal total bip stake of a candidate to become a validator
+func MinValidatorBipStake() *big.Int {
+	return big.NewInt(0).Set(minValidatorBipStake)
+}
+
 // GetNewCandidates returns list of candidates that can be the new validators
 // Skips offline candidates and candidates with stake less than minValidatorBipStake
 // Result is sorted by candidates stakes and limited to valCount
@@ -463,7 +470,7 @@ func (c *Candidates) PunishByzantineCandidate(height uint64, tmAddress types.TmA
 		})
 
 		c.bus.Checker().AddCoin(stake.Coin, big.NewInt(0).Neg(newValue))
-		c.bus.FrozenFunds().AddFrozenFund(height+types.GetUnbondPeriod(), stake.Owner, &candidate.PubKey, candidate.ID, stake.Coin, newValue)
+		c.bus.FrozenFunds().AddFrozenFund(height+c.bus.Params().GetUnbondPeriod(), stake.Owner, &candidate.PubKey, candidate.ID, stake.Coin, newValue)
 		stake.setValue(big.NewInt(0))
 	}
 }
@@ -651,6 +658,17 @@ func (c *Candidates) IsNewCandidateStakeSufficient(coin types.CoinID, stake *big
 	return false
 }
 
+// ValidatorStakeThreshold returns the bip value which total stake of an online candidate should exceed
+// to be selected by GetNewCandidates with given validators count
+func (c *Candidates) ValidatorStakeThreshold(valCount int) *big.Int {
+	newCandidates := c.GetNewCandidates(valCount)
+	if len(newCandidates) < valCount || valCount <= 0 {
+		return big.NewInt(0).Set(minValidatorBipStake)
+	}
+
+	return newCandidates[len(newCandidates)-1].GetTotalBipStake()
+}
+
 // GetCandidate returns candidate by a public key
 func (c *Candidates) GetCandidate(pubkey types.Pubkey) *Candidate {
 	return c.getFromMap(pubkey)
@@ -673,6 +691,11 @@ func (c *Candidates) IsDelegatorStakeSufficient(address types.Address, pubkey ty
 	return false
 }
 
+// BipValue returns the bip value of given amount of coin as it would be calculated for a new stake
+func (c *Candidates) BipValue(coin types.CoinID, amount *big.Int) *big.Int {
+	return c.calculateBipValue(coin, amount, true, true, nil)
+}
+
 // IsDelegatorStakeAllowed determines if given stake is sufficient to add it to a candidate
 func (c *Candidates) IsDelegatorStakeAllowed(address types.Address, pubkey types.Pubkey, coin types.CoinID, amount *big.Int) (low, b bool) {
 	low = true
@@ -1447,7 +1470,7 @@ func (c *Candidates) DeleteCandidate(height uint64, candidate *Candidate) {
 			Coin:            uint64(s.Coin),
 			ValidatorPubKey: &candidate.PubKey,
 		})
-		c.bus.FrozenFunds().AddFrozenFund(height+types.GetUnbondPeriod(), s.Owner, &candidate.PubKey, candidate.ID, s.Coin, s.Value)
+		c.bus.FrozenFunds().AddFrozenFund(height+c.bus.Params().GetUnbondPeriod(), s.Owner, &candidate.PubKey, candidate.ID, s.Coin, s.Value)
 		c.bus.Checker().AddCoin(s.Coin, big.NewInt(0).Neg(s.Value))
 		s.setValue(big.NewInt(0))
 	}
@@ -1461,7 +1484,7 @@ func (c *Candidates) DeleteCandidate(height uint64, candidate *Candidate) {
 			Coin:            uint64(u.Coin),
 			ValidatorPubKey: &candidate.PubKey,
 		})
-		c.bus.FrozenFunds().AddFrozenFund(height+types.GetUnbondPeriod(), u.Owner, &candidate.PubKey, candidate.ID, u.Coin, u.Value)
+		c.bus.FrozenFunds().AddFrozenFund(height+c.bus.Params().GetUnbondPeriod(), u.Owner, &candidate.PubKey, candidate.ID, u.Coin, u.Value)
 		c.bus.Checker().AddCoin(u.Coin, big.NewInt(0).Neg(u.Value))
 		u.setValue(big.NewInt(0))
 	}
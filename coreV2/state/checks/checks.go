@@ -14,13 +14,24 @@ import (
 
 const mainPrefix = byte('t')
 
+const (
+	revokedPrefix      = byte('k')
+	revokedHashPrefix  = byte('h')
+	revokedNoncePrefix = byte('n')
+)
+
 type RChecks interface {
 	Export(state *types.AppState)
 	IsCheckUsed(check *check.Check) bool
+	IsCheckHashUsed(hash types.Hash) bool
+	IsCheckRevoked(issuer types.Address, check *check.Check) bool
+	IsCheckHashRevoked(issuer types.Address, hash types.Hash) bool
+	IsCheckNonceRevoked(issuer types.Address, nonce []byte) bool
 }
 
 type Checks struct {
-	usedChecks map[types.Hash]struct{}
+	usedChecks    map[types.Hash]struct{}
+	revokedChecks map[string]struct{}
 
 	db atomic.Value
 
@@ -32,7 +43,7 @@ func NewChecks(db *iavl.ImmutableTree) *Checks {
 	if db != nil {
 		immutableTree.Store(db)
 	}
-	return &Checks{db: immutableTree, usedChecks: map[types.Hash]struct{}{}}
+	return &Checks{db: immutableTree, usedChecks: map[types.Hash]struct{}{}, revokedChecks: map[string]struct{}{}}
 }
 
 func (c *Checks) immutableTree() *iavl.ImmutableTree {
@@ -58,22 +69,87 @@ func (c *Checks) Commit(db *iavl.MutableTree, version int64) error {
 		db.Set(trieHash, []byte{0x1})
 	}
 
+	for _, key := range c.getOrderedRevokedKeys() {
+		c.lock.Lock()
+		delete(c.revokedChecks, key)
+		c.lock.Unlock()
+
+		db.Set([]byte(key), []byte{0x1})
+	}
+
 	return nil
 }
 
 func (c *Checks) IsCheckUsed(check *check.Check) bool {
+	return c.IsCheckHashUsed(check.Hash())
+}
+
+func (c *Checks) IsCheckHashUsed(hash types.Hash) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if _, has := c.usedChecks[hash]; has {
+		return true
+	}
+
+	_, data := c.immutableTree().Get(append([]byte{mainPrefix}, hash.Bytes()...))
+
+	return len(data) != 0
+}
+
+// IsCheckRevoked returns true if the check was revoked by its issuer either by hash or by nonce
+func (c *Checks) IsCheckRevoked(issuer types.Address, check *check.Check) bool {
+	return c.IsCheckHashRevoked(issuer, check.Hash()) || c.IsCheckNonceRevoked(issuer, check.Nonce)
+}
+
+func (c *Checks) IsCheckHashRevoked(issuer types.Address, hash types.Hash) bool {
+	return c.isRevoked(revokedHashKey(issuer, hash))
+}
+
+func (c *Checks) IsCheckNonceRevoked(issuer types.Address, nonce []byte) bool {
+	return c.isRevoked(revokedNonceKey(issuer, nonce))
+}
+
+func (c *Checks) isRevoked(key []byte) bool {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
 
-	if _, has := c.usedChecks[check.Hash()]; has {
+	if _, has := c.revokedChecks[string(key)]; has {
 		return true
 	}
 
-	_, data := c.immutableTree().Get(append([]byte{mainPrefix}, check.Hash().Bytes()...))
+	_, data := c.immutableTree().Get(key)
 
 	return len(data) != 0
 }
 
+// RevokeCheckHash revokes the check with given hash issued by issuer
+func (c *Checks) RevokeCheckHash(issuer types.Address, hash types.Hash) {
+	c.revoke(revokedHashKey(issuer, hash))
+}
+
+// RevokeCheckNonce revokes all checks with given nonce issued by issuer
+func (c *Checks) RevokeCheckNonce(issuer types.Address, nonce []byte) {
+	c.revoke(revokedNonceKey(issuer, nonce))
+}
+
+func (c *Checks) revoke(key []byte) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.revokedChecks[string(key)] = struct{}{}
+}
+
+func revokedHashKey(issuer types.Address, hash types.Hash) []byte {
+	key := append([]byte{revokedPrefix, revokedHashPrefix}, issuer.Bytes()...)
+	return append(key, hash.Bytes()...)
+}
+
+func revokedNonceKey(issuer types.Address, nonce []byte) []byte {
+	key := append([]byte{revokedPrefix, revokedNoncePrefix}, issuer.Bytes()...)
+	return append(key, nonce...)
+}
+
 func (c *Checks) UseCheck(check *check.Check) {
 	c.UseCheckHash(check.Hash())
 }
@@ -90,6 +166,18 @@ func (c *Checks) Export(state *types.AppState) {
 		state.UsedChecks = append(state.UsedChecks, types.UsedCheck(fmt.Sprintf("%x", key[1:])))
 		return false
 	})
+
+	c.immutableTree().IterateRange([]byte{revokedPrefix}, []byte{revokedPrefix + 1}, true, func(key []byte, value []byte) bool {
+		revoked := types.RevokedCheck{Issuer: types.BytesToAddress(key[2 : 2+types.AddressLength])}
+		switch key[1] {
+		case revokedHashPrefix:
+			revoked.Hash = fmt.Sprintf("%x", key[2+types.AddressLength:])
+		case revokedNoncePrefix:
+			revoked.Nonce = fmt.Sprintf("%x", key[2+types.AddressLength:])
+		}
+		state.RevokedChecks = append(state.RevokedChecks, revoked)
+		return false
+	})
 }
 
 func (c *Checks) getOrderedHashes() []types.Hash {
@@ -107,3 +195,17 @@ func (c *Checks) getOrderedHashes() []types.Hash {
 
 	return keys
 }
+
+func (c *Checks) getOrderedRevokedKeys() []string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	keys := make([]string, 0, len(c.revokedChecks))
+	for key := range c.revokedChecks {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -1,6 +1,7 @@
 package coins
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"sort"
@@ -15,9 +16,13 @@ import (
 )
 
 const (
-	mainPrefix   = byte('q')
-	infoPrefix   = byte('i')
-	symbolPrefix = byte('s')
+	mainPrefix     = byte('q')
+	infoPrefix     = byte('i')
+	symbolPrefix   = byte('s')
+	metadataPrefix = byte('m')
+
+	freezablePrefix = byte('z')
+	frozenPrefix    = byte('f')
 
 	BaseVersion types.CoinVersion = 0
 )
@@ -38,6 +43,8 @@ type RCoins interface {
 	GetCoin(id types.CoinID) *Model
 	GetCoinBySymbol(symbol types.CoinSymbol, version types.CoinVersion) *Model
 	GetSymbolInfo(symbol types.CoinSymbol) *SymbolInfo
+	IsFrozen(id types.CoinID, address types.Address) bool
+	GetFrozenAddresses(id types.CoinID) []types.Address
 }
 
 // Coins represents coins state in blockchain.
@@ -85,6 +92,9 @@ type Coins struct {
 	symbolsList     map[types.CoinSymbol][]types.CoinID
 	symbolsInfoList map[types.CoinSymbol]*SymbolInfo
 
+	// frozen is a changes of frozen addresses not committed yet, false value is for unfreezing
+	frozen map[frozenAddress]bool
+
 	bus *bus.Bus
 	db  atomic.Value
 
@@ -103,6 +113,7 @@ func NewCoins(stateBus *bus.Bus, db *iavl.ImmutableTree) *Coins {
 		dirty:           map[types.CoinID]struct{}{},
 		symbolsList:     map[types.CoinSymbol][]types.CoinID{},
 		symbolsInfoList: map[types.CoinSymbol]*SymbolInfo{},
+		frozen:          map[frozenAddress]bool{},
 	}
 	coins.bus.SetCoins(NewBus(coins))
 
@@ -170,6 +181,29 @@ func (c *Coins) Commit(db *iavl.MutableTree, version int64) error {
 			db.Set(getCoinInfoPath(id), data)
 		}
 
+		if coin.IsMetadataDirty() {
+			coin.lock.RLock()
+			coin.metadata.lock.Lock()
+			coin.metadata.isDirty = false
+			data, err := rlp.EncodeToBytes(coin.metadata)
+			coin.metadata.lock.Unlock()
+			coin.lock.RUnlock()
+
+			if err != nil {
+				return fmt.Errorf("can't encode object at %d: %v", id, err)
+			}
+
+			db.Set(getCoinMetadataPath(id), data)
+		}
+
+		if coin.IsFreezableDirty() {
+			coin.lock.Lock()
+			coin.isFreezableDirty = false
+			coin.lock.Unlock()
+
+			db.Set(getCoinFreezablePath(id), []byte{0x1})
+		}
+
 		if coin.IsSymbolInfoDirty() {
 			coin.lock.RLock()
 			coin.symbolInfo.lock.Lock()
@@ -186,6 +220,19 @@ func (c *Coins) Commit(db *iavl.MutableTree, version int64) error {
 		}
 	}
 
+	for _, key := range c.getOrderedFrozen() {
+		c.lock.Lock()
+		frozen := c.frozen[key]
+		delete(c.frozen, key)
+		c.lock.Unlock()
+
+		if frozen {
+			db.Set(key.path(), []byte{0x1})
+		} else {
+			db.Remove(key.path())
+		}
+	}
+
 	return nil
 }
 
@@ -497,6 +544,117 @@ func (c *Coins) ChangeOwner(symbol types.CoinSymbol, owner types.Address) {
 	c.markDirty(coin.ID())
 }
 
+// SetMetadata replaces the metadata of the coin
+func (c *Coins) SetMetadata(id types.CoinID, description, url string, logoHash types.Hash) {
+	coin := c.get(id)
+	coin.lock.Lock()
+	coin.metadata = &Metadata{
+		Description: description,
+		URL:         url,
+		LogoHash:    logoHash,
+		isDirty:     true,
+	}
+	coin.lock.Unlock()
+
+	c.markDirty(id)
+}
+
+// SetFreezable allows the owner of the token to freeze addresses, it is chosen at the creation of the token
+func (c *Coins) SetFreezable(id types.CoinID) {
+	coin := c.get(id)
+	coin.lock.Lock()
+	coin.freezable = true
+	coin.isFreezableDirty = true
+	coin.lock.Unlock()
+
+	c.markDirty(id)
+}
+
+// Freeze forbids the address to transfer the coin
+func (c *Coins) Freeze(id types.CoinID, address types.Address) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.frozen[frozenAddress{coin: id, address: address}] = true
+}
+
+// Unfreeze allows the frozen address to transfer the coin again
+func (c *Coins) Unfreeze(id types.CoinID, address types.Address) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.frozen[frozenAddress{coin: id, address: address}] = false
+}
+
+// IsFrozen returns true if the coin is freezable and the address is frozen by the owner
+func (c *Coins) IsFrozen(id types.CoinID, address types.Address) bool {
+	coin := c.get(id)
+	if coin == nil || !coin.IsFreezable() {
+		return false
+	}
+
+	key := frozenAddress{coin: id, address: address}
+
+	c.lock.RLock()
+	frozen, ok := c.frozen[key]
+	c.lock.RUnlock()
+	if ok {
+		return frozen
+	}
+
+	_, enc := c.immutableTree().Get(key.path())
+	return len(enc) != 0
+}
+
+// GetFrozenAddresses returns addresses frozen for the coin sorted by bytes
+func (c *Coins) GetFrozenAddresses(id types.CoinID) []types.Address {
+	frozen := map[types.Address]bool{}
+
+	start := frozenAddress{coin: id}.path()[:len(getCoinPath(id))+1]
+	end := append(append([]byte{}, start[:len(start)-1]...), frozenPrefix+1)
+	c.immutableTree().IterateRange(start, end, true, func(key []byte, value []byte) bool {
+		frozen[types.BytesToAddress(key[len(start):])] = true
+		return false
+	})
+
+	c.lock.RLock()
+	for key, value := range c.frozen {
+		if key.coin == id {
+			frozen[key.address] = value
+		}
+	}
+	c.lock.RUnlock()
+
+	var addresses []types.Address
+	for address, ok := range frozen {
+		if ok {
+			addresses = append(addresses, address)
+		}
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) == -1
+	})
+
+	return addresses
+}
+
+func (c *Coins) getOrderedFrozen() []frozenAddress {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	keys := make([]frozenAddress, 0, len(c.frozen))
+	for key := range c.frozen {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].path(), keys[j].path()) == -1
+	})
+
+	return keys
+}
+
 func (c *Coins) get(id types.CoinID) *Model {
 	if id.IsBaseCoin() {
 		return &Model{
@@ -542,6 +700,27 @@ func (c *Coins) get(id types.CoinID) *Model {
 		coin.lock.Unlock()
 	}
 
+	// load metadata
+	_, enc = c.immutableTree().Get(getCoinMetadataPath(id))
+	if len(enc) != 0 {
+		var metadata Metadata
+		if err := rlp.DecodeBytes(enc, &metadata); err != nil {
+			panic(fmt.Sprintf("failed to decode coin metadata %d: %s", id, err))
+		}
+
+		coin.lock.Lock()
+		coin.metadata = &metadata
+		coin.lock.Unlock()
+	}
+
+	// load freezable flag
+	_, enc = c.immutableTree().Get(getCoinFreezablePath(id))
+	if len(enc) != 0 {
+		coin.lock.Lock()
+		coin.freezable = true
+		coin.lock.Unlock()
+	}
+
 	c.setToMap(id, coin)
 
 	return coin
@@ -626,6 +805,15 @@ func (c *Coins) Export(state *types.AppState) {
 			owner = info.OwnerAddress()
 		}
 
+		var metadata *types.CoinMetadata
+		if m := coin.Metadata(); m != nil {
+			metadata = &types.CoinMetadata{
+				Description: m.Description,
+				URL:         m.URL,
+				LogoHash:    m.LogoHash,
+			}
+		}
+
 		state.Coins = append(state.Coins, types.Coin{
 			ID:           uint64(coin.ID()),
 			Name:         coin.Name(),
@@ -638,6 +826,9 @@ func (c *Coins) Export(state *types.AppState) {
 			Mintable:     coin.Mintable,
 			Burnable:     coin.Burnable,
 			OwnerAddress: owner,
+			Metadata:     metadata,
+			Freezable:    coin.IsFreezable(),
+			Frozen:       c.GetFrozenAddresses(coin.ID()),
 		})
 
 		return false
@@ -710,3 +901,22 @@ func getCoinPath(id types.CoinID) []byte {
 func getCoinInfoPath(id types.CoinID) []byte {
 	return append(getCoinPath(id), infoPrefix)
 }
+
+func getCoinMetadataPath(id types.CoinID) []byte {
+	return append(getCoinPath(id), metadataPrefix)
+}
+
+func getCoinFreezablePath(id types.CoinID) []byte {
+	return append(getCoinPath(id), freezablePrefix)
+}
+
+// frozenAddress is a key of the address frozen by the owner of the coin
+type frozenAddress struct {
+	coin    types.CoinID
+	address types.Address
+}
+
+func (f frozenAddress) path() []byte {
+	path := append(getCoinPath(f.coin), frozenPrefix)
+	return append(path, f.address[:]...)
+}
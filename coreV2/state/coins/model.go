@@ -23,6 +23,10 @@ type Model struct {
 	id         types.CoinID
 	info       *Info
 	symbolInfo *SymbolInfo
+	metadata   *Metadata
+
+	freezable        bool
+	isFreezableDirty bool
 
 	markDirty func(symbol types.CoinID)
 	lock      sync.RWMutex
@@ -249,6 +253,54 @@ func (m *Model) IsSymbolInfoDirty() bool {
 	return m.symbolInfo.isDirty
 }
 
+func (m *Model) IsMetadataDirty() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if m.metadata == nil {
+		return false
+	}
+
+	m.metadata.lock.RLock()
+	defer m.metadata.lock.RUnlock()
+
+	return m.metadata.isDirty
+}
+
+// IsFreezable returns true if the owner of the token is allowed to freeze addresses
+func (m *Model) IsFreezable() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.freezable
+}
+
+func (m *Model) IsFreezableDirty() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.isFreezableDirty
+}
+
+// Metadata returns the metadata attached to the coin by its owner or nil
+func (m *Model) Metadata() *Metadata {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if m.metadata == nil {
+		return nil
+	}
+
+	m.metadata.lock.RLock()
+	defer m.metadata.lock.RUnlock()
+
+	return &Metadata{
+		Description: m.metadata.Description,
+		URL:         m.metadata.URL,
+		LogoHash:    m.metadata.LogoHash,
+	}
+}
+
 func (m *Model) IsDirty() bool {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -286,6 +338,16 @@ type Info struct {
 	lock    sync.RWMutex
 }
 
+// Metadata is a description, a website URL and a hash of the logo of the coin set by its owner
+type Metadata struct {
+	Description string
+	URL         string
+	LogoHash    types.Hash
+
+	isDirty bool
+	lock    sync.RWMutex
+}
+
 type SymbolInfo struct {
 	COwnerAddress *types.Address
 
@@ -8,6 +8,33 @@ import (
 	"github.com/MinterTeam/minter-go-node/rlp"
 )
 
+// Positions of the prices of transactions added after the fixed fields in the tail of the price
+const (
+	revokeCheckIndex = iota
+	addStopOrderIndex
+	removeStopOrderIndex
+	createMiningProgramIndex
+	stakeMiningIndex
+	unstakeMiningIndex
+	claimMiningRewardIndex
+	voteParameterIndex
+	createTreasuryProposalIndex
+	voteTreasuryProposalIndex
+	setCoinMetadataIndex
+	approveIndex
+	transferFromIndex
+	freezeAddressIndex
+	unfreezeAddressIndex
+	createAirdropIndex
+	claimAirdropIndex
+
+	// swapFeeTiersIndex is the position of the first swap fee tier in the tail of the price
+	swapFeeTiersIndex
+)
+
+// MaxMoreLen is the maximum length of the tail of the price: prices of new transactions and swap fee tiers
+const MaxMoreLen = swapFeeTiersIndex + 3
+
 type Price struct {
 	Coin                    types.CoinID
 	PayloadByte             *big.Int
@@ -72,6 +99,107 @@ func (d *Price) Encode() []byte {
 	return bytes
 }
 
+// morePrice returns the voted price from the tail or the fallback price until it is voted
+func (d *Price) morePrice(index int, fallback *big.Int) *big.Int {
+	if len(d.More) > index {
+		return d.More[index]
+	}
+	return fallback
+}
+
+// RevokeCheckPrice returns price of check revocation, the price of redeem check is used until it is voted
+func (d *Price) RevokeCheckPrice() *big.Int {
+	return d.morePrice(revokeCheckIndex, d.RedeemCheck)
+}
+
+// AddStopOrderPrice returns price of placing stop order, the price of limit order is used until it is voted
+func (d *Price) AddStopOrderPrice() *big.Int {
+	return d.morePrice(addStopOrderIndex, d.AddLimitOrder)
+}
+
+// RemoveStopOrderPrice returns price of stop order removal, the price of limit order removal is used until it is voted
+func (d *Price) RemoveStopOrderPrice() *big.Int {
+	return d.morePrice(removeStopOrderIndex, d.RemoveLimitOrder)
+}
+
+// CreateMiningProgramPrice returns price of mining program creation, the price of swap pool creation is used until it is voted
+func (d *Price) CreateMiningProgramPrice() *big.Int {
+	return d.morePrice(createMiningProgramIndex, d.CreateSwapPool)
+}
+
+// StakeMiningPrice returns price of staking of liquidity to mining program, the price of liquidity adding is used until it is voted
+func (d *Price) StakeMiningPrice() *big.Int {
+	return d.morePrice(stakeMiningIndex, d.AddLiquidity)
+}
+
+// UnstakeMiningPrice returns price of unstaking of liquidity from mining program, the price of liquidity removal is used until it is voted
+func (d *Price) UnstakeMiningPrice() *big.Int {
+	return d.morePrice(unstakeMiningIndex, d.RemoveLiquidity)
+}
+
+// ClaimMiningRewardPrice returns price of claiming of mining reward, the price of liquidity removal is used until it is voted
+func (d *Price) ClaimMiningRewardPrice() *big.Int {
+	return d.morePrice(claimMiningRewardIndex, d.RemoveLiquidity)
+}
+
+// VoteParameterPrice returns price of network parameter vote, the price of update vote is used until it is voted
+func (d *Price) VoteParameterPrice() *big.Int {
+	return d.morePrice(voteParameterIndex, d.VoteUpdate)
+}
+
+// CreateTreasuryProposalPrice returns price of treasury proposal creation, the price of swap pool creation is used until it is voted
+func (d *Price) CreateTreasuryProposalPrice() *big.Int {
+	return d.morePrice(createTreasuryProposalIndex, d.CreateSwapPool)
+}
+
+// VoteTreasuryProposalPrice returns price of treasury proposal vote, the price of update vote is used until it is voted
+func (d *Price) VoteTreasuryProposalPrice() *big.Int {
+	return d.morePrice(voteTreasuryProposalIndex, d.VoteUpdate)
+}
+
+// SetCoinMetadataPrice returns price of coin metadata setting, the price of ticker owner change is used until it is voted
+func (d *Price) SetCoinMetadataPrice() *big.Int {
+	return d.morePrice(setCoinMetadataIndex, d.EditTickerOwner)
+}
+
+// ApprovePrice returns price of allowance approval, the price of send is used until it is voted
+func (d *Price) ApprovePrice() *big.Int {
+	return d.morePrice(approveIndex, d.Send)
+}
+
+// TransferFromPrice returns price of transfer by allowance, the price of send is used until it is voted
+func (d *Price) TransferFromPrice() *big.Int {
+	return d.morePrice(transferFromIndex, d.Send)
+}
+
+// FreezeAddressPrice returns price of address freezing, the price of ticker owner change is used until it is voted
+func (d *Price) FreezeAddressPrice() *big.Int {
+	return d.morePrice(freezeAddressIndex, d.EditTickerOwner)
+}
+
+// UnfreezeAddressPrice returns price of address unfreezing, the price of ticker owner change is used until it is voted
+func (d *Price) UnfreezeAddressPrice() *big.Int {
+	return d.morePrice(unfreezeAddressIndex, d.EditTickerOwner)
+}
+
+// CreateAirdropPrice returns price of airdrop creation, the price of swap pool creation is used until it is voted
+func (d *Price) CreateAirdropPrice() *big.Int {
+	return d.morePrice(createAirdropIndex, d.CreateSwapPool)
+}
+
+// ClaimAirdropPrice returns price of airdrop claim, the price of send is used until it is voted
+func (d *Price) ClaimAirdropPrice() *big.Int {
+	return d.morePrice(claimAirdropIndex, d.Send)
+}
+
+// SwapFeeTiers returns voted fee tiers of swap pools in basis points, pools are created with the default fee until tiers are voted
+func (d *Price) SwapFeeTiers() []*big.Int {
+	if len(d.More) > swapFeeTiersIndex {
+		return d.More[swapFeeTiersIndex:]
+	}
+	return nil
+}
+
 //
 //func (d *Price) FailedTxPrice() *big.Int {
 //	if len(d.More) > 0 {
@@ -0,0 +1,502 @@
+package mining
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('m')
+
+const (
+	programPrefix = byte('p')
+	stakePrefix   = byte('s')
+	nextIDPrefix  = byte('i')
+)
+
+type RMining interface {
+	Export(state *types.AppState)
+	GetProgram(id uint32) *Program
+	GetStake(id uint32, owner types.Address) *Stake
+	GetStakes(id uint32) []*Stake
+	Earned(id uint32, owner types.Address, height uint64) *big.Int
+}
+
+type stakeKey struct {
+	programID uint32
+	owner     types.Address
+}
+
+type Mining struct {
+	programs     map[uint32]*Program
+	stakes       map[stakeKey]*Stake
+	dirty        map[uint32]struct{}
+	dirtyStakes  map[stakeKey]struct{}
+	nextID       uint32
+	dirtyNextID  bool
+	loadedNextID bool
+
+	bus *bus.Bus
+	db  atomic.Value
+
+	lock sync.RWMutex
+}
+
+func NewMining(stateBus *bus.Bus, db *iavl.ImmutableTree) *Mining {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &Mining{
+		bus:         stateBus,
+		db:          immutableTree,
+		programs:    map[uint32]*Program{},
+		stakes:      map[stakeKey]*Stake{},
+		dirty:       map[uint32]struct{}{},
+		dirtyStakes: map[stakeKey]struct{}{},
+	}
+}
+
+func (m *Mining) immutableTree() *iavl.ImmutableTree {
+	db := m.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (m *Mining) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	m.db.Store(immutableTree)
+}
+
+func (m *Mining) Commit(db *iavl.MutableTree, version int64) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.dirtyNextID {
+		m.dirtyNextID = false
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, m.nextID)
+		db.Set([]byte{mainPrefix, nextIDPrefix}, b)
+	}
+
+	ids := make([]uint32, 0, len(m.dirty))
+	for id := range m.dirty {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	for _, id := range ids {
+		program := m.programs[id]
+		program.lock.RLock()
+		data, err := rlp.EncodeToBytes(program)
+		program.lock.RUnlock()
+		if err != nil {
+			return fmt.Errorf("can't encode mining program %d: %v", id, err)
+		}
+		db.Set(pathProgram(id), data)
+	}
+	m.dirty = map[uint32]struct{}{}
+
+	keys := make([]stakeKey, 0, len(m.dirtyStakes))
+	for key := range m.dirtyStakes {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].programID != keys[j].programID {
+			return keys[i].programID < keys[j].programID
+		}
+		return bytes.Compare(keys[i].owner.Bytes(), keys[j].owner.Bytes()) == -1
+	})
+	for _, key := range keys {
+		stake := m.stakes[key]
+		if stake.isEmpty() {
+			delete(m.stakes, key)
+			db.Remove(pathStake(key.programID, key.owner))
+			continue
+		}
+		data, err := rlp.EncodeToBytes(stake)
+		if err != nil {
+			return fmt.Errorf("can't encode mining stake %d of %s: %v", key.programID, key.owner.String(), err)
+		}
+		db.Set(pathStake(key.programID, key.owner), data)
+	}
+	m.dirtyStakes = map[stakeKey]struct{}{}
+
+	return nil
+}
+
+// GetProgram returns the mining program or nil if it does not exist
+func (m *Mining) GetProgram(id uint32) *Program {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.getProgram(id)
+}
+
+func (m *Mining) getProgram(id uint32) *Program {
+	if program, ok := m.programs[id]; ok {
+		return program
+	}
+
+	_, data := m.immutableTree().Get(pathProgram(id))
+	if len(data) == 0 {
+		return nil
+	}
+
+	program := &Program{}
+	if err := rlp.DecodeBytes(data, program); err != nil {
+		panic(fmt.Sprintf("failed to decode mining program %d: %s", id, err))
+	}
+	program.id = id
+	program.markDirty = m.markDirty
+	m.programs[id] = program
+
+	return program
+}
+
+// GetStake returns LP tokens of the owner staked in the program or nil
+func (m *Mining) GetStake(id uint32, owner types.Address) *Stake {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.getStake(id, owner)
+}
+
+func (m *Mining) getStake(id uint32, owner types.Address) *Stake {
+	key := stakeKey{programID: id, owner: owner}
+	if stake, ok := m.stakes[key]; ok {
+		return stake
+	}
+
+	_, data := m.immutableTree().Get(pathStake(id, owner))
+	if len(data) == 0 {
+		return nil
+	}
+
+	stake := &Stake{}
+	if err := rlp.DecodeBytes(data, stake); err != nil {
+		panic(fmt.Sprintf("failed to decode mining stake %d of %s: %s", id, owner.String(), err))
+	}
+	stake.programID = id
+	stake.owner = owner
+	stake.markDirty = m.markDirtyStake
+	m.stakes[key] = stake
+
+	return stake
+}
+
+func (m *Mining) getOrNewStake(id uint32, owner types.Address) *Stake {
+	stake := m.getStake(id, owner)
+	if stake != nil {
+		return stake
+	}
+
+	stake = &Stake{
+		Value:              big.NewInt(0),
+		RewardPerTokenPaid: big.NewInt(0),
+		Reward:             big.NewInt(0),
+		programID:          id,
+		owner:              owner,
+		markDirty:          m.markDirtyStake,
+	}
+	m.stakes[stakeKey{programID: id, owner: owner}] = stake
+
+	return stake
+}
+
+// GetStakes returns all stakes of the program sorted by owners
+func (m *Mining) GetStakes(id uint32) []*Stake {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.getStakes(id)
+}
+
+func (m *Mining) getStakes(id uint32) []*Stake {
+	var stakes []*Stake
+	from := pathStake(id, types.Address{})
+	to := pathStake(id+1, types.Address{})
+	m.immutableTree().IterateRange(from, to, true, func(key []byte, value []byte) bool {
+		owner := types.BytesToAddress(key[len(from)-types.AddressLength:])
+		if _, ok := m.stakes[stakeKey{programID: id, owner: owner}]; !ok {
+			m.getStake(id, owner)
+		}
+		return false
+	})
+	for key, stake := range m.stakes {
+		if key.programID == id && !stake.isEmpty() {
+			stakes = append(stakes, stake)
+		}
+	}
+	sort.Slice(stakes, func(i, j int) bool {
+		return bytes.Compare(stakes[i].owner.Bytes(), stakes[j].owner.Bytes()) == -1
+	})
+
+	return stakes
+}
+
+// Earned returns the reward of the owner in the program which can be claimed at the height
+func (m *Mining) Earned(id uint32, owner types.Address, height uint64) *big.Int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	program := m.getProgram(id)
+	if program == nil {
+		return big.NewInt(0)
+	}
+
+	program.lock.RLock()
+	defer program.lock.RUnlock()
+
+	rewardPerToken, unallocated := program.accumulated(height)
+	earned := big.NewInt(0)
+	if stake := m.getStake(id, owner); stake != nil {
+		earned = stake.earned(rewardPerToken)
+	}
+	if owner == program.Owner && height >= program.EndHeight {
+		earned.Add(earned, unallocated)
+		earned.Add(earned, m.dust(program, rewardPerToken, unallocated))
+	}
+
+	return earned
+}
+
+// CreateProgram takes value of the reward coin to the new program and returns its id
+func (m *Mining) CreateProgram(owner types.Address, liquidityCoin, rewardCoin types.CoinID, value *big.Int, startHeight, endHeight uint64) uint32 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	id := m.getNextID()
+	m.nextID = id + 1
+	m.dirtyNextID = true
+
+	m.programs[id] = &Program{
+		Owner:          owner,
+		LiquidityCoin:  liquidityCoin,
+		RewardCoin:     rewardCoin,
+		Value:          new(big.Int).Set(value),
+		StartHeight:    startHeight,
+		EndHeight:      endHeight,
+		TotalStaked:    big.NewInt(0),
+		RewardPerToken: big.NewInt(0),
+		LastHeight:     startHeight,
+		Unallocated:    big.NewInt(0),
+		Claimed:        big.NewInt(0),
+		id:             id,
+		markDirty:      m.markDirty,
+	}
+	m.dirty[id] = struct{}{}
+	m.bus.Checker().AddCoin(rewardCoin, value)
+
+	return id
+}
+
+// Stake takes LP tokens of the owner to the program
+func (m *Mining) Stake(id uint32, owner types.Address, value *big.Int, height uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	program := m.getProgram(id)
+	program.lock.Lock()
+	defer program.lock.Unlock()
+
+	program.update(height)
+	stake := m.getOrNewStake(id, owner)
+	stake.settle(program.RewardPerToken)
+	stake.Value = new(big.Int).Add(stake.Value, value)
+	program.TotalStaked = new(big.Int).Add(program.TotalStaked, value)
+	program.markDirty(id)
+
+	m.bus.Checker().AddCoin(program.LiquidityCoin, value)
+}
+
+// Unstake returns LP tokens to the owner, accrued reward stays in the program until it is claimed
+func (m *Mining) Unstake(id uint32, owner types.Address, value *big.Int, height uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	program := m.getProgram(id)
+	program.lock.Lock()
+	defer program.lock.Unlock()
+
+	program.update(height)
+	stake := m.getStake(id, owner)
+	stake.settle(program.RewardPerToken)
+	stake.Value = new(big.Int).Sub(stake.Value, value)
+	program.TotalStaked = new(big.Int).Sub(program.TotalStaked, value)
+	program.markDirty(id)
+
+	m.bus.Checker().AddCoin(program.LiquidityCoin, new(big.Int).Neg(value))
+}
+
+// Claim returns the reward accrued to the owner in the program.
+// After the end of the program its owner also gets back the reward of blocks without stakes and the dust of rounding.
+func (m *Mining) Claim(id uint32, owner types.Address, height uint64) (types.CoinID, *big.Int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	program := m.getProgram(id)
+	program.lock.Lock()
+	defer program.lock.Unlock()
+
+	program.update(height)
+	if owner == program.Owner && height >= program.EndHeight {
+		program.Unallocated = new(big.Int).Add(program.Unallocated, m.dust(program, program.RewardPerToken, program.Unallocated))
+	}
+	reward := big.NewInt(0)
+	if stake := m.getStake(id, owner); stake != nil {
+		stake.settle(program.RewardPerToken)
+		reward.Set(stake.Reward)
+		stake.Reward = big.NewInt(0)
+	}
+	if owner == program.Owner && height >= program.EndHeight {
+		reward.Add(reward, program.Unallocated)
+		program.Unallocated = big.NewInt(0)
+	}
+	program.Claimed = new(big.Int).Add(program.Claimed, reward)
+	program.markDirty(id)
+
+	m.bus.Checker().AddCoin(program.RewardCoin, new(big.Int).Neg(reward))
+
+	return program.RewardCoin, reward
+}
+
+// dust returns the reward of the ended program lost by rounding of reward per token,
+// it is the value which is neither claimed, unallocated nor earned by stakes
+func (m *Mining) dust(program *Program, rewardPerToken, unallocated *big.Int) *big.Int {
+	dust := new(big.Int).Sub(program.Value, program.Claimed)
+	dust.Sub(dust, unallocated)
+	for _, stake := range m.getStakes(program.id) {
+		dust.Sub(dust, stake.earned(rewardPerToken))
+	}
+	if dust.Sign() != 1 {
+		return big.NewInt(0)
+	}
+
+	return dust
+}
+
+func (m *Mining) getNextID() uint32 {
+	if m.loadedNextID {
+		return m.nextID
+	}
+	m.loadedNextID = true
+
+	_, data := m.immutableTree().Get([]byte{mainPrefix, nextIDPrefix})
+	if len(data) == 0 {
+		m.nextID = 1
+	} else {
+		m.nextID = binary.BigEndian.Uint32(data)
+	}
+
+	return m.nextID
+}
+
+func (m *Mining) markDirty(id uint32) {
+	m.dirty[id] = struct{}{}
+}
+
+func (m *Mining) markDirtyStake(programID uint32, owner types.Address) {
+	m.dirtyStakes[stakeKey{programID: programID, owner: owner}] = struct{}{}
+}
+
+func (m *Mining) Export(state *types.AppState) {
+	var ids []uint32
+	m.immutableTree().IterateRange([]byte{mainPrefix, programPrefix}, []byte{mainPrefix, programPrefix + 1}, true, func(key []byte, value []byte) bool {
+		ids = append(ids, binary.BigEndian.Uint32(key[2:]))
+		return false
+	})
+
+	for _, id := range ids {
+		program := m.GetProgram(id)
+		exported := types.MiningProgram{
+			ID:             uint64(id),
+			Owner:          program.Owner,
+			LiquidityCoin:  uint64(program.LiquidityCoin),
+			RewardCoin:     uint64(program.RewardCoin),
+			Value:          program.Value.String(),
+			StartHeight:    program.StartHeight,
+			EndHeight:      program.EndHeight,
+			RewardPerToken: program.RewardPerToken.String(),
+			LastHeight:     program.LastHeight,
+			Unallocated:    program.Unallocated.String(),
+			Claimed:        program.Claimed.String(),
+		}
+		for _, stake := range m.GetStakes(id) {
+			exported.Stakes = append(exported.Stakes, types.MiningStake{
+				Owner:              stake.owner,
+				Value:              stake.Value.String(),
+				RewardPerTokenPaid: stake.RewardPerTokenPaid.String(),
+				Reward:             stake.Reward.String(),
+			})
+		}
+		state.MiningPrograms = append(state.MiningPrograms, exported)
+	}
+}
+
+func (m *Mining) Import(state *types.AppState) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.getNextID()
+	for _, exported := range state.MiningPrograms {
+		id := uint32(exported.ID)
+		program := &Program{
+			Owner:          exported.Owner,
+			LiquidityCoin:  types.CoinID(exported.LiquidityCoin),
+			RewardCoin:     types.CoinID(exported.RewardCoin),
+			Value:          helpers.StringToBigInt(exported.Value),
+			StartHeight:    exported.StartHeight,
+			EndHeight:      exported.EndHeight,
+			TotalStaked:    big.NewInt(0),
+			RewardPerToken: helpers.StringToBigInt(exported.RewardPerToken),
+			LastHeight:     exported.LastHeight,
+			Unallocated:    helpers.StringToBigInt(exported.Unallocated),
+			Claimed:        helpers.StringToBigInt(exported.Claimed),
+			id:             id,
+			markDirty:      m.markDirty,
+		}
+		for _, exportedStake := range exported.Stakes {
+			stake := m.getOrNewStake(id, exportedStake.Owner)
+			stake.Value = helpers.StringToBigInt(exportedStake.Value)
+			stake.RewardPerTokenPaid = helpers.StringToBigInt(exportedStake.RewardPerTokenPaid)
+			stake.Reward = helpers.StringToBigInt(exportedStake.Reward)
+			m.markDirtyStake(id, exportedStake.Owner)
+			program.TotalStaked.Add(program.TotalStaked, stake.Value)
+		}
+		m.programs[id] = program
+		m.markDirty(id)
+
+		m.bus.Checker().AddCoin(program.LiquidityCoin, program.TotalStaked)
+		m.bus.Checker().AddCoin(program.RewardCoin, new(big.Int).Sub(program.Value, program.Claimed))
+
+		if id >= m.nextID {
+			m.nextID = id + 1
+			m.dirtyNextID = true
+		}
+	}
+}
+
+func pathProgram(id uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, id)
+	return append([]byte{mainPrefix, programPrefix}, b...)
+}
+
+func pathStake(id uint32, owner types.Address) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, id)
+	return append(append([]byte{mainPrefix, stakePrefix}, b...), owner.Bytes()...)
+}
@@ -0,0 +1,119 @@
+package mining
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestMining_RewardsProRata(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	m := NewMining(b, mutableTree.GetLastImmutable())
+
+	owner, alice, bob := types.Address{1}, types.Address{2}, types.Address{3}
+	id := m.CreateProgram(owner, 10, 1, big.NewInt(1000), 100, 200)
+	if id != 1 {
+		t.Fatalf("program id %d, want 1", id)
+	}
+
+	// blocks 100-110 have no stakes and return to the owner
+	m.Stake(id, alice, big.NewInt(100), 110)
+	m.Stake(id, bob, big.NewInt(300), 150)
+
+	_, _, err := mutableTree.Commit(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if earned := m.Earned(id, alice, 150); earned.Cmp(big.NewInt(400)) != 0 {
+		t.Errorf("alice earned %s, want 400", earned)
+	}
+	// blocks 150-200 are shared 1:3
+	if earned := m.Earned(id, alice, 250); earned.Cmp(big.NewInt(525)) != 0 {
+		t.Errorf("alice earned %s, want 525", earned)
+	}
+	if earned := m.Earned(id, bob, 250); earned.Cmp(big.NewInt(375)) != 0 {
+		t.Errorf("bob earned %s, want 375", earned)
+	}
+	if earned := m.Earned(id, owner, 150); earned.Sign() != 0 {
+		t.Errorf("owner earned %s before the end of program, want 0", earned)
+	}
+
+	m.Unstake(id, alice, big.NewInt(100), 160)
+	if _, reward := m.Claim(id, alice, 250); reward.Cmp(big.NewInt(425)) != 0 {
+		t.Errorf("alice claimed %s, want 425", reward)
+	}
+	if _, reward := m.Claim(id, alice, 260); reward.Sign() != 0 {
+		t.Errorf("alice claimed %s twice", reward)
+	}
+	// reward per token of blocks 160-200 is 4/3 and rounded down
+	if _, reward := m.Claim(id, bob, 250); reward.Cmp(big.NewInt(474)) != 0 {
+		t.Errorf("bob claimed %s, want 474", reward)
+	}
+	// the owner gets back the unallocated reward and the dust of rounding
+	if earned := m.Earned(id, owner, 250); earned.Cmp(big.NewInt(101)) != 0 {
+		t.Errorf("owner earned %s, want 101", earned)
+	}
+	if _, reward := m.Claim(id, owner, 250); reward.Cmp(big.NewInt(101)) != 0 {
+		t.Errorf("owner claimed %s, want 101", reward)
+	}
+
+	_, _, err = mutableTree.Commit(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stake := m.GetStake(id, alice); stake != nil {
+		t.Error("empty stake is not deleted")
+	}
+	if program := m.GetProgram(id); program.Claimed.Cmp(program.Value) != 0 {
+		t.Errorf("claimed %s of %s", program.Claimed, program.Value)
+	}
+}
+
+func TestMining_ExportImport(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	m := NewMining(b, mutableTree.GetLastImmutable())
+
+	owner, alice := types.Address{1}, types.Address{2}
+	id := m.CreateProgram(owner, 10, 1, big.NewInt(1000), 100, 200)
+	m.Stake(id, alice, big.NewInt(100), 120)
+	m.Unstake(id, alice, big.NewInt(50), 130)
+
+	_, _, err := mutableTree.Commit(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := new(types.AppState)
+	m.Export(state)
+	if len(state.MiningPrograms) != 1 || len(state.MiningPrograms[0].Stakes) != 1 {
+		t.Fatalf("exported %#v", state.MiningPrograms)
+	}
+
+	importedTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	imported := NewMining(b, importedTree.GetLastImmutable())
+	imported.Import(state)
+	_, _, err = importedTree.Commit(imported)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if earned, want := imported.Earned(id, alice, 200), m.Earned(id, alice, 200); earned.Cmp(want) != 0 {
+		t.Errorf("earned after import %s, want %s", earned, want)
+	}
+	if next := imported.CreateProgram(owner, 10, 1, big.NewInt(1), 100, 200); next != id+1 {
+		t.Errorf("next program id %d, want %d", next, id+1)
+	}
+}
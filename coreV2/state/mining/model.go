@@ -0,0 +1,110 @@
+package mining
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// rewardPerTokenResolution is the number of fractional bits of accumulated reward per staked token
+const rewardPerTokenResolution = 128
+
+// MaxProgramDuration is the maximum number of blocks of a program, about a year of 5 second blocks
+const MaxProgramDuration = 6307200
+
+// Program is a liquidity mining program. Value of RewardCoin is distributed evenly by blocks after StartHeight
+// up to EndHeight including it, reward of each block is shared pro-rata to LiquidityCoin tokens staked in the program.
+type Program struct {
+	Owner         types.Address
+	LiquidityCoin types.CoinID
+	RewardCoin    types.CoinID
+	Value         *big.Int
+	StartHeight   uint64
+	EndHeight     uint64
+
+	TotalStaked    *big.Int
+	RewardPerToken *big.Int
+	LastHeight     uint64
+	Unallocated    *big.Int
+	Claimed        *big.Int
+
+	id        uint32
+	markDirty func(id uint32)
+
+	lock sync.RWMutex
+}
+
+// Stake is LP tokens of the owner staked in the program and the reward accrued to them
+type Stake struct {
+	Value              *big.Int
+	RewardPerTokenPaid *big.Int
+	Reward             *big.Int
+
+	programID uint32
+	owner     types.Address
+	markDirty func(programID uint32, owner types.Address)
+}
+
+func (p *Program) ID() uint32 {
+	return p.id
+}
+
+func (s *Stake) Owner() types.Address {
+	return s.owner
+}
+
+// scheduled returns the reward of all blocks of the program up to the height
+func (p *Program) scheduled(height uint64) *big.Int {
+	if height <= p.StartHeight {
+		return big.NewInt(0)
+	}
+	if height >= p.EndHeight {
+		return new(big.Int).Set(p.Value)
+	}
+	elapsed := new(big.Int).SetUint64(height - p.StartHeight)
+	duration := new(big.Int).SetUint64(p.EndHeight - p.StartHeight)
+	return new(big.Int).Quo(new(big.Int).Mul(p.Value, elapsed), duration)
+}
+
+// accumulated returns reward per token and unallocated reward of blocks without stakes as of the height
+func (p *Program) accumulated(height uint64) (rewardPerToken, unallocated *big.Int) {
+	rewardPerToken, unallocated = new(big.Int).Set(p.RewardPerToken), new(big.Int).Set(p.Unallocated)
+	if height <= p.LastHeight {
+		return rewardPerToken, unallocated
+	}
+
+	reward := new(big.Int).Sub(p.scheduled(height), p.scheduled(p.LastHeight))
+	if p.TotalStaked.Sign() != 1 {
+		return rewardPerToken, unallocated.Add(unallocated, reward)
+	}
+	reward.Lsh(reward, rewardPerTokenResolution)
+	return rewardPerToken.Add(rewardPerToken, reward.Quo(reward, p.TotalStaked)), unallocated
+}
+
+// update distributes reward of blocks since the last change of the program
+func (p *Program) update(height uint64) {
+	if height <= p.LastHeight {
+		return
+	}
+	p.RewardPerToken, p.Unallocated = p.accumulated(height)
+	p.LastHeight = height
+	p.markDirty(p.id)
+}
+
+// earned returns the reward of the stake by the accumulated reward per token
+func (s *Stake) earned(rewardPerToken *big.Int) *big.Int {
+	reward := new(big.Int).Mul(s.Value, new(big.Int).Sub(rewardPerToken, s.RewardPerTokenPaid))
+	return reward.Add(reward.Rsh(reward, rewardPerTokenResolution), s.Reward)
+}
+
+// settle moves the reward earned by the stake since its last change to the accrued reward
+func (s *Stake) settle(rewardPerToken *big.Int) {
+	s.Reward = s.earned(rewardPerToken)
+	s.RewardPerTokenPaid = new(big.Int).Set(rewardPerToken)
+	s.markDirty(s.programID, s.owner)
+}
+
+func (s *Stake) isEmpty() bool {
+	return s.Value.Sign() == 0 && s.Reward.Sign() == 0
+}
@@ -0,0 +1,13 @@
+package params
+
+type Bus struct {
+	params *Params
+}
+
+func (b *Bus) GetUnbondPeriod() uint64 {
+	return b.params.GetUnbondPeriod()
+}
+
+func NewBus(params *Params) *Bus {
+	return &Bus{params: params}
+}
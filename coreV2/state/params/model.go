@@ -0,0 +1,101 @@
+package params
+
+import (
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Parameter is a network parameter which can be changed by votes of validators
+type Parameter byte
+
+const (
+	UnbondPeriod Parameter = iota + 1
+	ExpiredOrdersPeriod
+	UpdateStakesPeriod
+	ValidatorsCount
+	DAOCommission
+	DevelopersCommission
+)
+
+type bounds struct {
+	name     string
+	min, max uint64
+}
+
+var parameters = map[Parameter]bounds{
+	UnbondPeriod:         {name: "unbond_period", max: 1 << 22},
+	ExpiredOrdersPeriod:  {name: "expired_orders_period", min: 1, max: 1 << 24},
+	UpdateStakesPeriod:   {name: "update_stakes_period", min: 2, max: 17280},
+	ValidatorsCount:      {name: "validators_count", min: 1, max: 192},
+	DAOCommission:        {name: "dao_commission", min: 0, max: 20},
+	DevelopersCommission: {name: "developers_commission", min: 0, max: 20},
+}
+
+// Parameters returns all parameters which can be changed by votes
+func Parameters() []Parameter {
+	return []Parameter{UnbondPeriod, ExpiredOrdersPeriod, UpdateStakesPeriod, ValidatorsCount, DAOCommission, DevelopersCommission}
+}
+
+// ParameterByName returns the parameter by its name
+func ParameterByName(name string) (Parameter, bool) {
+	for parameter, b := range parameters {
+		if b.name == name {
+			return parameter, true
+		}
+	}
+	return 0, false
+}
+
+func (p Parameter) String() string {
+	if b, ok := parameters[p]; ok {
+		return b.name
+	}
+	return "unknown"
+}
+
+// IsValid returns false for unknown parameters
+func (p Parameter) IsValid() bool {
+	_, ok := parameters[p]
+	return ok
+}
+
+func (p Parameter) bounds() (bounds, bool) {
+	b, ok := parameters[p]
+	if p == UnbondPeriod {
+		// stakes should stay slashable for the whole default period, so the period can only be extended
+		b.min = types.GetUnbondPeriod()
+	}
+	return b, ok
+}
+
+// Bounds returns allowed values of the parameter including both ends
+func (p Parameter) Bounds() (min, max uint64) {
+	b, _ := p.bounds()
+	return b.min, b.max
+}
+
+// IsValidValue returns true if the value is allowed for the parameter
+func (p Parameter) IsValidValue(value uint64) bool {
+	b, ok := p.bounds()
+	return ok && value >= b.min && value <= b.max
+}
+
+// Model is an option of the parameter change at the height and validators voted for it
+type Model struct {
+	Votes     []types.Pubkey
+	Parameter Parameter
+	Value     uint64
+
+	height    uint64
+	markDirty func()
+
+	lock sync.Mutex
+}
+
+func (m *Model) addVote(pubkey types.Pubkey) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.Votes = append(m.Votes, pubkey)
+	m.markDirty()
+}
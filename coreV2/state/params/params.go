@@ -0,0 +1,443 @@
+package params
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/coreV2/validators"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('g')
+
+const (
+	votesPrefix   = byte('v')
+	valuesPrefix  = byte('p')
+	pendingPrefix = byte('n')
+)
+
+type RParams interface {
+	Export(state *types.AppState)
+	GetVotes(height uint64) []*Model
+	IsVoteExists(height uint64, pubkey types.Pubkey, parameter Parameter) bool
+	Get(parameter Parameter) (uint64, bool)
+	GetPending(parameter Parameter) (uint64, bool)
+	GetUnbondPeriod() uint64
+	GetExpiredOrdersPeriod() uint64
+	GetValidatorsCount(height uint64) int
+}
+
+// Params is a store of voted network parameters and votes for their changes
+type Params struct {
+	list      map[uint64][]*Model
+	dirty     map[uint64]struct{}
+	forDelete uint64
+
+	values      map[Parameter]uint64
+	dirtyValues map[Parameter]struct{}
+
+	// pending is a voted values which are not applied yet, removed values are dirty and absent in the map
+	pending      map[Parameter]uint64
+	dirtyPending map[Parameter]struct{}
+
+	db   atomic.Value
+	lock sync.RWMutex
+}
+
+func New(stateBus *bus.Bus, db *iavl.ImmutableTree) *Params {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	params := &Params{
+		db:           immutableTree,
+		list:         map[uint64][]*Model{},
+		dirty:        map[uint64]struct{}{},
+		values:       map[Parameter]uint64{},
+		dirtyValues:  map[Parameter]struct{}{},
+		pending:      map[Parameter]uint64{},
+		dirtyPending: map[Parameter]struct{}{},
+	}
+	stateBus.SetParams(NewBus(params))
+
+	return params
+}
+
+func (p *Params) immutableTree() *iavl.ImmutableTree {
+	db := p.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (p *Params) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	p.db.Store(immutableTree)
+}
+
+func (p *Params) Export(state *types.AppState) {
+	p.immutableTree().IterateRange([]byte{mainPrefix, votesPrefix}, []byte{mainPrefix, votesPrefix + 1}, true, func(key []byte, value []byte) bool {
+		if len(key) < 10 {
+			return false
+		}
+		height := binary.LittleEndian.Uint64(key[2:])
+		for _, vote := range p.get(height) {
+			state.ParameterVotes = append(state.ParameterVotes, types.ParameterVote{
+				Height:    height,
+				Votes:     vote.Votes,
+				Parameter: vote.Parameter.String(),
+				Value:     vote.Value,
+			})
+		}
+		return false
+	})
+
+	for _, parameter := range Parameters() {
+		if value, ok := p.Get(parameter); ok {
+			state.Parameters = append(state.Parameters, types.Parameter{
+				Name:  parameter.String(),
+				Value: value,
+			})
+		}
+		if value, ok := p.GetPending(parameter); ok {
+			state.Parameters = append(state.Parameters, types.Parameter{
+				Name:    parameter.String(),
+				Value:   value,
+				Pending: true,
+			})
+		}
+	}
+}
+
+func (p *Params) Import(state *types.AppState) {
+	for _, vote := range state.ParameterVotes {
+		parameter, ok := ParameterByName(vote.Parameter)
+		if !ok {
+			continue
+		}
+		for _, pubkey := range vote.Votes {
+			p.AddVote(vote.Height, pubkey, parameter, vote.Value)
+		}
+	}
+	for _, value := range state.Parameters {
+		parameter, ok := ParameterByName(value.Name)
+		if !ok {
+			continue
+		}
+		if value.Pending {
+			p.SetPending(parameter, value.Value)
+		} else {
+			p.Set(parameter, value.Value)
+		}
+	}
+}
+
+func (p *Params) Commit(db *iavl.MutableTree, version int64) error {
+	p.lock.RLock()
+	dirties := p.getOrderedDirty()
+	p.lock.RUnlock()
+	for _, height := range dirties {
+		models := p.getFromMap(height)
+
+		p.lock.Lock()
+		delete(p.dirty, height)
+		p.lock.Unlock()
+
+		data, err := rlp.EncodeToBytes(models)
+		if err != nil {
+			return fmt.Errorf("can't encode object at %d: %v", height, err)
+		}
+
+		db.Set(getPath(height), data)
+	}
+
+	if p.forDelete != 0 {
+		db.Remove(getPath(p.forDelete))
+		p.lock.Lock()
+		delete(p.list, p.forDelete)
+		p.forDelete = 0
+		p.lock.Unlock()
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, parameter := range Parameters() {
+		if _, ok := p.dirtyValues[parameter]; !ok {
+			continue
+		}
+		data, err := rlp.EncodeToBytes(p.values[parameter])
+		if err != nil {
+			return fmt.Errorf("can't encode parameter %s: %v", parameter, err)
+		}
+		db.Set(getValuePath(parameter), data)
+	}
+	p.dirtyValues = map[Parameter]struct{}{}
+
+	for _, parameter := range Parameters() {
+		if _, ok := p.dirtyPending[parameter]; !ok {
+			continue
+		}
+		value, ok := p.pending[parameter]
+		if !ok {
+			db.Remove(getPendingPath(parameter))
+			continue
+		}
+		data, err := rlp.EncodeToBytes(value)
+		if err != nil {
+			return fmt.Errorf("can't encode pending parameter %s: %v", parameter, err)
+		}
+		db.Set(getPendingPath(parameter), data)
+	}
+	p.dirtyPending = map[Parameter]struct{}{}
+
+	return nil
+}
+
+// Get returns the voted value of the parameter, false means the default value is used
+func (p *Params) Get(parameter Parameter) (uint64, bool) {
+	p.lock.RLock()
+	value, ok := p.values[parameter]
+	p.lock.RUnlock()
+	if ok {
+		return value, true
+	}
+
+	_, data := p.immutableTree().Get(getValuePath(parameter))
+	if len(data) == 0 {
+		return 0, false
+	}
+	if err := rlp.DecodeBytes(data, &value); err != nil {
+		panic(fmt.Sprintf("failed to decode parameter %s: %s", parameter, err))
+	}
+
+	p.lock.Lock()
+	p.values[parameter] = value
+	p.lock.Unlock()
+
+	return value, true
+}
+
+// Set changes the value of the parameter
+func (p *Params) Set(parameter Parameter, value uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.values[parameter] = value
+	p.dirtyValues[parameter] = struct{}{}
+}
+
+// GetUnbondPeriod returns the number of blocks stakes are frozen for after unbond, voted value can only extend the default one
+func (p *Params) GetUnbondPeriod() uint64 {
+	period := types.GetUnbondPeriod()
+	if value, ok := p.Get(UnbondPeriod); ok && value > period {
+		return value
+	}
+	return period
+}
+
+// GetExpiredOrdersPeriod returns the number of blocks limit orders rest in the book for, voted value overrides the default one
+func (p *Params) GetExpiredOrdersPeriod() uint64 {
+	if value, ok := p.Get(ExpiredOrdersPeriod); ok {
+		return value
+	}
+	return types.GetExpireOrdersPeriod()
+}
+
+// GetValidatorsCount returns available validators slots, voted value overrides the default one and is limited by candidates slots
+func (p *Params) GetValidatorsCount(height uint64) int {
+	count := validators.GetValidatorsCountForBlock(height)
+	if value, ok := p.Get(ValidatorsCount); ok {
+		count = int(value)
+	}
+	if maxCount := validators.GetCandidatesCountForBlock(height); count > maxCount {
+		count = maxCount
+	}
+	return count
+}
+
+// GetPending returns the voted value of the parameter which is not applied yet
+func (p *Params) GetPending(parameter Parameter) (uint64, bool) {
+	p.lock.RLock()
+	value, ok := p.pending[parameter]
+	_, isDirty := p.dirtyPending[parameter]
+	p.lock.RUnlock()
+	if ok || isDirty {
+		return value, ok
+	}
+
+	_, data := p.immutableTree().Get(getPendingPath(parameter))
+	if len(data) == 0 {
+		return 0, false
+	}
+	if err := rlp.DecodeBytes(data, &value); err != nil {
+		panic(fmt.Sprintf("failed to decode pending parameter %s: %s", parameter, err))
+	}
+
+	return value, true
+}
+
+// SetPending schedules the change of the parameter, it is applied by ApplyPending
+func (p *Params) SetPending(parameter Parameter, value uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.pending[parameter] = value
+	p.dirtyPending[parameter] = struct{}{}
+}
+
+// ApplyPending changes values of parameters to the scheduled ones and returns the changed parameters
+func (p *Params) ApplyPending() []Parameter {
+	var applied []Parameter
+	for _, parameter := range Parameters() {
+		value, ok := p.GetPending(parameter)
+		if !ok {
+			continue
+		}
+
+		p.Set(parameter, value)
+
+		p.lock.Lock()
+		delete(p.pending, parameter)
+		p.dirtyPending[parameter] = struct{}{}
+		p.lock.Unlock()
+
+		applied = append(applied, parameter)
+	}
+
+	return applied
+}
+
+func (p *Params) GetVotes(height uint64) []*Model {
+	return p.get(height)
+}
+
+func (p *Params) getOrNew(height uint64, parameter Parameter, value uint64) *Model {
+	models := p.get(height)
+
+	for _, model := range models {
+		if model.Parameter == parameter && model.Value == value {
+			return model
+		}
+	}
+
+	model := &Model{
+		height:    height,
+		Parameter: parameter,
+		Value:     value,
+		markDirty: p.markDirty(height),
+	}
+	p.setToMap(height, append(models, model))
+	return model
+}
+
+func (p *Params) get(height uint64) []*Model {
+	if models := p.getFromMap(height); models != nil {
+		return models
+	}
+
+	_, enc := p.immutableTree().Get(getPath(height))
+	if len(enc) == 0 {
+		return nil
+	}
+
+	var voteBlock []*Model
+	if err := rlp.DecodeBytes(enc, &voteBlock); err != nil {
+		panic(fmt.Sprintf("failed to decode parameter votes at height %d: %s", height, err))
+	}
+
+	for _, vote := range voteBlock {
+		vote.markDirty = p.markDirty(height)
+		vote.height = height
+	}
+
+	p.setToMap(height, voteBlock)
+
+	return voteBlock
+}
+
+func (p *Params) markDirty(height uint64) func() {
+	return func() {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		p.dirty[height] = struct{}{}
+	}
+}
+
+func (p *Params) getOrderedDirty() []uint64 {
+	keys := make([]uint64, 0, len(p.dirty))
+	for k := range p.dirty {
+		keys = append(keys, k)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+// IsVoteExists returns true if the validator has voted for a change of the parameter at the height
+func (p *Params) IsVoteExists(height uint64, pubkey types.Pubkey, parameter Parameter) bool {
+	for _, model := range p.get(height) {
+		if model.Parameter != parameter {
+			continue
+		}
+		for _, vote := range model.Votes {
+			if vote == pubkey {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (p *Params) AddVote(height uint64, pubkey types.Pubkey, parameter Parameter, value uint64) {
+	p.getOrNew(height, parameter, value).addVote(pubkey)
+}
+
+func (p *Params) Delete(height uint64) {
+	if len(p.get(height)) == 0 {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.forDelete = height
+}
+
+func (p *Params) getFromMap(height uint64) []*Model {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.list[height]
+}
+
+func (p *Params) setToMap(height uint64, model []*Model) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.list[height] = model
+}
+
+func getPath(height uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, height)
+
+	return append([]byte{mainPrefix, votesPrefix}, b...)
+}
+
+func getValuePath(parameter Parameter) []byte {
+	return []byte{mainPrefix, valuesPrefix, byte(parameter)}
+}
+
+func getPendingPath(parameter Parameter) []byte {
+	return []byte{mainPrefix, pendingPrefix, byte(parameter)}
+}
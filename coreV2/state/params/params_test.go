@@ -0,0 +1,147 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestParams_VotesAndValues(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	p := New(bus.NewBus(), mutableTree.GetLastImmutable())
+
+	height := uint64(10)
+	p.AddVote(height, types.Pubkey{1}, ValidatorsCount, 100)
+	p.AddVote(height, types.Pubkey{2}, ValidatorsCount, 100)
+	p.AddVote(height, types.Pubkey{3}, ValidatorsCount, 50)
+
+	_, _, err := mutableTree.Commit(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if votes := p.GetVotes(height); len(votes) != 2 || len(votes[0].Votes) != 2 {
+		t.Fatalf("votes %#v", votes)
+	}
+	if !p.IsVoteExists(height, types.Pubkey{3}, ValidatorsCount) {
+		t.Error("vote not found")
+	}
+	if p.IsVoteExists(height, types.Pubkey{3}, UnbondPeriod) {
+		t.Error("vote found for another parameter")
+	}
+
+	if _, ok := p.Get(ValidatorsCount); ok {
+		t.Error("parameter is set before voting")
+	}
+	p.Set(ValidatorsCount, 100)
+	p.Delete(height)
+
+	_, _, err = mutableTree.Commit(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.GetVotes(height) != nil {
+		t.Error("votes not deleted")
+	}
+
+	loaded := New(bus.NewBus(), mutableTree.GetLastImmutable())
+	if value, ok := loaded.Get(ValidatorsCount); !ok || value != 100 {
+		t.Errorf("parameter %d, want 100", value)
+	}
+}
+
+func TestParams_ExportImport(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	p := New(bus.NewBus(), mutableTree.GetLastImmutable())
+
+	p.AddVote(20, types.Pubkey{1}, DAOCommission, 5)
+	p.Set(UnbondPeriod, 1000)
+
+	_, _, err := mutableTree.Commit(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := new(types.AppState)
+	p.Export(state)
+	if len(state.ParameterVotes) != 1 || state.ParameterVotes[0].Parameter != "dao_commission" {
+		t.Fatalf("exported votes %#v", state.ParameterVotes)
+	}
+	if len(state.Parameters) != 1 || state.Parameters[0].Name != "unbond_period" {
+		t.Fatalf("exported parameters %#v", state.Parameters)
+	}
+
+	importedTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	imported := New(bus.NewBus(), importedTree.GetLastImmutable())
+	imported.Import(state)
+	_, _, err = importedTree.Commit(imported)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !imported.IsVoteExists(20, types.Pubkey{1}, DAOCommission) {
+		t.Error("vote not imported")
+	}
+	if value, ok := imported.Get(UnbondPeriod); !ok || value != 1000 {
+		t.Errorf("parameter %d, want 1000", value)
+	}
+}
+
+func TestParameter_IsValidValue(t *testing.T) {
+	t.Parallel()
+	if ValidatorsCount.IsValidValue(0) || !ValidatorsCount.IsValidValue(64) || ValidatorsCount.IsValidValue(193) {
+		t.Error("wrong validators count bounds")
+	}
+	if UnbondPeriod.IsValidValue(1) || UnbondPeriod.IsValidValue(types.GetUnbondPeriod()-1) || !UnbondPeriod.IsValidValue(types.GetUnbondPeriod()) {
+		t.Error("unbond period can be voted below the default one")
+	}
+	if Parameter(0).IsValid() {
+		t.Error("unknown parameter is valid")
+	}
+	if parameter, ok := ParameterByName("update_stakes_period"); !ok || parameter != UpdateStakesPeriod {
+		t.Error("parameter not found by name")
+	}
+}
+
+func TestParams_ApplyPending(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	p := New(bus.NewBus(), mutableTree.GetLastImmutable())
+
+	p.SetPending(UpdateStakesPeriod, 720)
+
+	_, _, err := mutableTree.Commit(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := p.Get(UpdateStakesPeriod); ok {
+		t.Error("pending parameter is applied before ApplyPending")
+	}
+	if value, ok := p.GetPending(UpdateStakesPeriod); !ok || value != 720 {
+		t.Errorf("pending parameter %d, want 720", value)
+	}
+
+	if applied := p.ApplyPending(); len(applied) != 1 || applied[0] != UpdateStakesPeriod {
+		t.Fatalf("applied parameters %v", applied)
+	}
+
+	_, _, err = mutableTree.Commit(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := New(bus.NewBus(), mutableTree.GetLastImmutable())
+	if value, ok := loaded.Get(UpdateStakesPeriod); !ok || value != 720 {
+		t.Errorf("parameter %d, want 720", value)
+	}
+	if _, ok := loaded.GetPending(UpdateStakesPeriod); ok {
+		t.Error("applied parameter is still pending")
+	}
+}
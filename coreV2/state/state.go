@@ -8,6 +8,7 @@ import (
 
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/airdrop"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/app"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/candidates"
@@ -17,7 +18,10 @@ import (
 	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/frozenfunds"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/halts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/mining"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/params"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/treasury"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/update"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/validators"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/waitlist"
@@ -56,6 +60,10 @@ func (cs *CheckState) Export() types.AppState {
 	cs.Swap().Export(appState)
 	cs.Commission().Export(appState)
 	cs.Updates().Export(appState)
+	cs.Mining().Export(appState)
+	cs.Params().Export(appState)
+	cs.Treasury().Export(appState)
+	cs.Airdrops().Export(appState)
 
 	return *appState
 }
@@ -105,6 +113,22 @@ func (cs *CheckState) Commission() commission.RCommission {
 	return cs.state.Commission
 }
 
+func (cs *CheckState) Mining() mining.RMining {
+	return cs.state.Mining
+}
+
+func (cs *CheckState) Params() params.RParams {
+	return cs.state.Params
+}
+
+func (cs *CheckState) Treasury() treasury.RTreasury {
+	return cs.state.Treasury
+}
+
+func (cs *CheckState) Airdrops() airdrop.RAirdrop {
+	return cs.state.Airdrops
+}
+
 type State struct {
 	App         *app.App
 	Validators  *validators.Validators
@@ -120,6 +144,10 @@ type State struct {
 	SwapV2      *swap.SwapV2
 	Commission  *commission.Commission
 	Updates     *update.Update
+	Mining      *mining.Mining
+	Params      *params.Params
+	Treasury    *treasury.Treasury
+	Airdrops    *airdrop.Airdrops
 
 	db     db.DB
 	events eventsdb.IEventsDB
@@ -218,13 +246,23 @@ func (s *State) Swapper() interface {
 	PairSellWithOrders(coin0, coin1 types.CoinID, amount0In, minAmount1Out *big.Int) (*big.Int, *big.Int, uint32, *swap.ChangeDetailsWithOrders, []*swap.OrderDetail)
 	PairBuyWithOrders(coin0, coin1 types.CoinID, maxAmount0In, amount1Out *big.Int) (*big.Int, *big.Int, uint32, *swap.ChangeDetailsWithOrders, []*swap.OrderDetail)
 	PairAddOrder(coinWantBuy, coinWantSell types.CoinID, wantBuyAmount, wantSellAmount *big.Int, sender types.Address, block uint64) (uint32, uint32)
+	PairAddOrderWithExpire(coinWantBuy, coinWantSell types.CoinID, wantBuyAmount, wantSellAmount *big.Int, sender types.Address, block uint64, expireHeight uint64) (uint32, uint32)
 	PairBuy(coin0, coin1 types.CoinID, maxAmount0In, amount1Out *big.Int) (*big.Int, *big.Int, uint32)
 	PairSell(coin0, coin1 types.CoinID, amount0In, minAmount1Out *big.Int) (*big.Int, *big.Int, uint32)
 	PairMint(coin0, coin1 types.CoinID, amount0, maxAmount1, totalSupply *big.Int) (*big.Int, *big.Int, *big.Int)
 	PairCreate(coin0, coin1 types.CoinID, amount0, amount1 *big.Int) (*big.Int, *big.Int, *big.Int, uint32)
+	PairSetSwapFee(coin0, coin1 types.CoinID, fee uint32)
 	PairBurn(coin0, coin1 types.CoinID, liquidity, minAmount0, minAmount1, totalSupply *big.Int) (*big.Int, *big.Int)
 	PairRemoveLimitOrder(id uint32) (types.CoinID, *big.Int)
 	ExpireOrders(beforeHeight uint64)
+	ExpireOrdersAtHeight(height uint64)
+	EnableOrdersExpiration(period uint64)
+	MigrateOrdersExpiration()
+	PairAddStopOrder(coinToSell, coinToBuy types.CoinID, valueToSell, triggerValue, minimumValueToBuy *big.Int, owner types.Address, block uint64) (uint32, uint32)
+	PairRemoveStopOrder(id uint32) (types.CoinID, *big.Int)
+	ActivateStopOrders(height uint64)
+	ExpireStopOrders(beforeHeight uint64)
+	EnablePriceOracle()
 	Import(state *types.AppState)
 	GetSwapper(coinA, coinB types.CoinID) swap.EditableChecker
 	SwapPool(coinA, coinB types.CoinID) (reserve0, reserve1 *big.Int, id uint32)
@@ -267,6 +305,10 @@ func (s *State) Commit() ([]byte, error) {
 		s.GetSwap(),
 		s.Commission,
 		s.Updates,
+		s.Mining,
+		s.Params,
+		s.Treasury,
+		s.Airdrops,
 	)
 	if err != nil {
 		return hash, err
@@ -315,6 +357,9 @@ func (s *State) Import(state types.AppState, version string) error {
 			coinID := types.CoinID(b.Coin)
 			s.Accounts.SetBalance(a.Address, coinID, balance)
 		}
+		for _, al := range a.Allowances {
+			s.Accounts.SetAllowance(a.Address, al.Spender, types.CoinID(al.Coin), helpers.StringToBigInt(al.Value))
+		}
 	}
 
 	for _, c := range state.Coins {
@@ -327,6 +372,15 @@ func (s *State) Import(state types.AppState, version string) error {
 			reserve := helpers.StringToBigInt(c.Reserve)
 			s.Coins.ImportCoin(coinID, c.Symbol, c.Name, volume, uint32(c.Crr), reserve, maxSupply, c.OwnerAddress, c.Version)
 		}
+		if c.Metadata != nil {
+			s.Coins.SetMetadata(coinID, c.Metadata.Description, c.Metadata.URL, c.Metadata.LogoHash)
+		}
+		if c.Freezable {
+			s.Coins.SetFreezable(coinID)
+		}
+		for _, address := range c.Frozen {
+			s.Coins.Freeze(coinID, address)
+		}
 	}
 
 	var vals []*validators.Validator
@@ -376,6 +430,16 @@ func (s *State) Import(state types.AppState, version string) error {
 		s.Checks.UseCheckHash(hash)
 	}
 
+	for _, revoked := range state.RevokedChecks {
+		if revoked.Hash != "" {
+			bytes, _ := hex.DecodeString(revoked.Hash)
+			s.Checks.RevokeCheckHash(revoked.Issuer, types.BytesToHash(bytes))
+			continue
+		}
+		nonce, _ := hex.DecodeString(revoked.Nonce)
+		s.Checks.RevokeCheckNonce(revoked.Issuer, nonce)
+	}
+
 	for _, ff := range state.FrozenFunds {
 		coinID := types.CoinID(ff.Coin)
 		value := helpers.StringToBigInt(ff.Value)
@@ -384,6 +448,14 @@ func (s *State) Import(state types.AppState, version string) error {
 
 	s.Swapper().Import(&state)
 
+	s.Mining.Import(&state)
+
+	s.Params.Import(&state)
+
+	s.Treasury.Import(&state)
+
+	s.Airdrops.Import(&state)
+
 	c := state.Commission
 	com := &commission.Price{
 		Coin:                    types.CoinID(c.Coin),
@@ -591,6 +663,14 @@ func newStateForTree(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsD
 
 	update := update.New(immutableTree)
 
+	miningState := mining.NewMining(stateBus, immutableTree)
+
+	paramsState := params.New(stateBus, immutableTree)
+
+	treasuryState := treasury.NewTreasury(immutableTree)
+
+	airdropsState := airdrop.NewAirdrops(stateBus, immutableTree)
+
 	state := &State{
 		Validators:  validatorsState,
 		App:         appState,
@@ -605,6 +685,10 @@ func newStateForTree(immutableTree *iavl.ImmutableTree, events eventsdb.IEventsD
 		Swap:        pool,
 		Commission:  commission,
 		Updates:     update,
+		Mining:      miningState,
+		Params:      paramsState,
+		Treasury:    treasuryState,
+		Airdrops:    airdropsState,
 
 		height:         immutableTree.Version(),
 		bus:            stateBus,
@@ -645,6 +729,14 @@ func newStateForTreeV2(immutableTree *iavl.ImmutableTree, events eventsdb.IEvent
 
 	update := update.New(immutableTree)
 
+	miningState := mining.NewMining(stateBus, immutableTree)
+
+	paramsState := params.New(stateBus, immutableTree)
+
+	treasuryState := treasury.NewTreasury(immutableTree)
+
+	airdropsState := airdrop.NewAirdrops(stateBus, immutableTree)
+
 	state := &State{
 		Validators:  validatorsState,
 		App:         appState,
@@ -659,6 +751,10 @@ func newStateForTreeV2(immutableTree *iavl.ImmutableTree, events eventsdb.IEvent
 		SwapV2:      poolV2,
 		Commission:  commission,
 		Updates:     update,
+		Mining:      miningState,
+		Params:      paramsState,
+		Treasury:    treasuryState,
+		Airdrops:    airdropsState,
 
 		height:         immutableTree.Version(),
 		bus:            stateBus,
@@ -0,0 +1,77 @@
+package swap
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const swapFeePrefix = 'f'
+
+// SwapFeeDenominator is the denominator of fee tiers of pools, tiers are set in basis points
+const SwapFeeDenominator = 10000
+
+// MaxSwapFee is the highest fee tier of a pool in basis points
+const MaxSwapFee = 1000
+
+func pathSwapFee(key PairKey) []byte {
+	return append([]byte{mainPrefix, swapFeePrefix}, key.bytes()...)
+}
+
+// swapFee returns the liquidity provider commission of the pool and its denominator.
+// Pools created without a fee tier keep the commission of 2 per mille.
+func (pd *pairData) swapFee() (fee, denominator *big.Int) {
+	if pd.fee == 0 {
+		return big.NewInt(commission), big.NewInt(1000)
+	}
+	return big.NewInt(int64(pd.fee)), big.NewInt(SwapFeeDenominator)
+}
+
+// SwapFee returns the fee tier of the pool in basis points, zero is the default commission of 0.2%
+func (pd *pairData) SwapFee() uint32 {
+	return pd.fee
+}
+
+func (pd *pairData) setSwapFee(fee uint32) {
+	pd.fee = fee
+	pd.dirtyFee = true
+	pd.markDirty()
+}
+
+func (pd *pairData) loadSwapFee(immutableTree *iavl.ImmutableTree, key PairKey) {
+	_, data := immutableTree.Get(pathSwapFee(key))
+	if len(data) == 0 {
+		return
+	}
+	if err := rlp.DecodeBytes(data, &pd.fee); err != nil {
+		panic(err)
+	}
+}
+
+func (pd *pairData) commitSwapFee(db *iavl.MutableTree, key PairKey) error {
+	if !pd.dirtyFee {
+		return nil
+	}
+	pd.dirtyFee = false
+
+	data, err := rlp.EncodeToBytes(pd.fee)
+	if err != nil {
+		return err
+	}
+	db.Set(pathSwapFee(key), data)
+	return nil
+}
+
+// PairSetSwapFee sets the fee tier of the pool in basis points
+func (s *SwapV2) PairSetSwapFee(coin0, coin1 types.CoinID, fee uint32) {
+	key := PairKey{Coin0: coin0, Coin1: coin1}.sort()
+	s.Pair(key.Coin0, key.Coin1).setSwapFee(fee)
+}
+
+// PairSetSwapFee sets the fee tier of the pool in basis points
+func (s *Swap) PairSetSwapFee(coin0, coin1 types.CoinID, fee uint32) {
+	key := PairKey{Coin0: coin0, Coin1: coin1}.sort()
+	s.Pair(key.Coin0, key.Coin1).setSwapFee(fee)
+}
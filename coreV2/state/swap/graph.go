@@ -0,0 +1,174 @@
+package swap
+
+import (
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// poolGraph is an adjacency list of swap pools by coin with cached pools of routes between coins
+type poolGraph struct {
+	mu     sync.RWMutex
+	edges  map[types.CoinID]map[types.CoinID]struct{}
+	routes map[routeKey][]PairKey
+}
+
+type routeKey struct {
+	coinIn, coinOut types.CoinID
+	maxHops         int32
+}
+
+func newPoolGraph() *poolGraph {
+	return &poolGraph{
+		edges:  map[types.CoinID]map[types.CoinID]struct{}{},
+		routes: map[routeKey][]PairKey{},
+	}
+}
+
+// add links coins of the pool, returns false if the pool is already in the graph
+func (g *poolGraph) add(key PairKey) bool {
+	if _, ok := g.edges[key.Coin0][key.Coin1]; ok {
+		return false
+	}
+
+	for _, link := range [][2]types.CoinID{{key.Coin0, key.Coin1}, {key.Coin1, key.Coin0}} {
+		neighbours, ok := g.edges[link[0]]
+		if !ok {
+			neighbours = map[types.CoinID]struct{}{}
+			g.edges[link[0]] = neighbours
+		}
+		neighbours[link[1]] = struct{}{}
+	}
+
+	return true
+}
+
+// addPools adds pools to the graph and drops cached routes if any of them is new
+func (g *poolGraph) addPools(keys []PairKey) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, key := range keys {
+		if g.add(key) {
+			g.routes = map[routeKey][]PairKey{}
+		}
+	}
+}
+
+// routePools returns keys of pools which can be a part of a route from coinIn to coinOut not longer than maxHops
+func (g *poolGraph) routePools(coinIn, coinOut types.CoinID, maxHops int32) []PairKey {
+	key := routeKey{coinIn: coinIn, coinOut: coinOut, maxHops: maxHops}
+
+	g.mu.RLock()
+	pools, ok := g.routes[key]
+	g.mu.RUnlock()
+	if ok {
+		return pools
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if pools, ok := g.routes[key]; ok {
+		return pools
+	}
+
+	fromIn := g.distances(coinIn, maxHops-1)
+	toOut := g.distances(coinOut, maxHops-1)
+
+	taken := map[PairKey]struct{}{}
+	pools = []PairKey{}
+	for coin0, hops := range fromIn {
+		for coin1 := range g.edges[coin0] {
+			if hopsToOut, ok := toOut[coin1]; !ok || hops+1+hopsToOut > maxHops {
+				continue
+			}
+			pair := PairKey{Coin0: coin0, Coin1: coin1}.sort()
+			if _, ok := taken[pair]; ok {
+				continue
+			}
+			taken[pair] = struct{}{}
+			pools = append(pools, pair)
+		}
+	}
+
+	g.routes[key] = pools
+	return pools
+}
+
+// distances returns the number of hops from the coin to every coin reachable within maxHops
+func (g *poolGraph) distances(coin types.CoinID, maxHops int32) map[types.CoinID]int32 {
+	result := map[types.CoinID]int32{coin: 0}
+	current := []types.CoinID{coin}
+	for hops := int32(1); hops <= maxHops && len(current) != 0; hops++ {
+		var next []types.CoinID
+		for _, c := range current {
+			for neighbour := range g.edges[c] {
+				if _, ok := result[neighbour]; ok {
+					continue
+				}
+				result[neighbour] = hops
+				next = append(next, neighbour)
+			}
+		}
+		current = next
+	}
+	return result
+}
+
+// tradeCache keeps the best trades computed at the height until any pool or its orders are changed
+type tradeCache struct {
+	mu      sync.Mutex
+	height  uint64
+	version uint64
+	trades  map[tradeKey]*Trade
+}
+
+type tradeKey struct {
+	trader          trader
+	coinIn, coinOut types.CoinID
+	amount          string
+	exactIn         bool
+	maxHops         int32
+}
+
+func newTradeCache() *tradeCache {
+	return &tradeCache{trades: map[tradeKey]*Trade{}}
+}
+
+// get returns the cached trade and the version of the cache to store the trade computed at the height
+func (c *tradeCache) get(height uint64, key tradeKey) (trade *Trade, ok bool, version uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.height != height {
+		c.height = height
+		c.reset()
+	}
+
+	trade, ok = c.trades[key]
+	return trade, ok, c.version
+}
+
+// set caches the trade if the cache is not reset after the trade is started
+func (c *tradeCache) set(version uint64, key tradeKey, trade *Trade) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.version == version {
+		c.trades[key] = trade
+	}
+}
+
+// drop removes all trades after a change of a pool
+func (c *tradeCache) drop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reset()
+}
+
+func (c *tradeCache) reset() {
+	c.trades = map[tradeKey]*Trade{}
+	c.version++
+}
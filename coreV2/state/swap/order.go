@@ -50,6 +50,7 @@ func (s *Swap) PairBuyWithOrders(coin0, coin1 types.CoinID, maxAmount0In, amount
 		panic(fmt.Sprintf("calculatedAmount1Out %s less minAmount1Out %s", amount1Out, maxAmount0In))
 	}
 
+	addOrderFillEvents(s.bus, pair, details.Orders)
 	s.hundleLittleExpiredOrders(expiredOrders)
 
 	owners := sortOwners(ownersMap)
@@ -482,11 +483,13 @@ func (p *Pair) calculateAddAmountsForPrice(price *big.Float) (amount0 *big.Int,
 	r1 := big.NewFloat(0).SetInt(reserve1)
 	k := big.NewFloat(0).Mul(r0, r1)
 	r0Qrt := big.NewFloat(0).Mul(r0, r0)
-	b := big.NewFloat(0).Mul(big.NewFloat((2000.0-commission)/2), r0)
+	fee, denominator := p.swapFee()
+	f, dn := float64(fee.Int64()), float64(denominator.Int64())
+	b := big.NewFloat(0).Mul(big.NewFloat((2*dn-f)/2), r0)
 	kMulPrice := big.NewFloat(0).Mul(k, big.NewFloat(0).Quo(big.NewFloat(1), price))
 	r0QrtSubKMulPrice := big.NewFloat(0).Sub(r0Qrt, kMulPrice)
-	d := big.NewFloat(0).Sub(big.NewFloat(0).Mul(big.NewFloat((2000-commission)*(2000-commission)/4), r0Qrt), big.NewFloat(0).Mul(big.NewFloat(2000*(1000-commission)/2), r0QrtSubKMulPrice))
-	x1 := big.NewFloat(0).Quo(big.NewFloat(0).Add(big.NewFloat(0).Neg(b), big.NewFloat(0).Sqrt(d)), big.NewFloat(1000-commission))
+	d := big.NewFloat(0).Sub(big.NewFloat(0).Mul(big.NewFloat((2*dn-f)*(2*dn-f)/4), r0Qrt), big.NewFloat(0).Mul(big.NewFloat(dn*(dn-f)), r0QrtSubKMulPrice))
+	x1 := big.NewFloat(0).Quo(big.NewFloat(0).Add(big.NewFloat(0).Neg(b), big.NewFloat(0).Sqrt(d)), big.NewFloat(dn-f))
 	var acc big.Accuracy
 	amount0, acc = x1.Int(nil)
 	if acc != big.Exact {
@@ -655,6 +658,9 @@ type Limit struct {
 	Height   uint64
 
 	PairKey
+	// More is an optional parameters of the order, the first one is the height of expiration
+	More []uint64 `rlp:"tail"`
+
 	oldSortPrice *big.Float
 	id           uint32
 
@@ -681,6 +687,25 @@ func (l *Limit) GetHeight() uint64 {
 	return l.Height
 }
 
+// decode decodes the order from the tree, empty optional parameters are the same as for a new order
+func (l *Limit) decode(value []byte) error {
+	if err := rlp.DecodeBytes(value, l); err != nil {
+		return err
+	}
+	if len(l.More) == 0 {
+		l.More = nil
+	}
+	return nil
+}
+
+// ExpireHeight returns the height of the order expiration, 0 if it is expired by the network period only
+func (l *Limit) ExpireHeight() uint64 {
+	if len(l.More) == 0 {
+		return 0
+	}
+	return l.More[0]
+}
+
 func (l *Limit) ID() uint32 {
 	if l == nil {
 		return 0
@@ -874,6 +899,7 @@ func (l *Limit) reverse() *Limit {
 		WantSell:     l.WantBuy,
 		Owner:        l.Owner,
 		Height:       l.Height,
+		More:         l.More,
 		oldSortPrice: l.oldSortPrice,
 		id:           l.id,
 		mu:           l.mu,
@@ -894,6 +920,7 @@ func (l *Limit) Reverse() *Limit {
 		WantSell:     l.WantBuy,
 		Owner:        l.Owner,
 		Height:       l.Height,
+		More:         l.More,
 		oldSortPrice: l.oldSortPrice,
 		id:           l.id,
 		mu:           l.mu,
@@ -927,6 +954,7 @@ func (l *Limit) clone() *Limit {
 		WantSell:     big.NewInt(0).Set(l.WantSell),
 		Owner:        l.Owner,
 		Height:       l.Height,
+		More:         append([]uint64(nil), l.More...),
 		oldSortPrice: new(big.Float).SetPrec(Precision).Set(l.oldSortPrice),
 		id:           l.id,
 		mu:           &sync.RWMutex{},
@@ -1078,8 +1106,14 @@ func (p *Pair) getDirtyOrdersList() []uint32 {
 }
 
 func (s *Swap) PairAddOrder(coinWantBuy, coinWantSell types.CoinID, wantBuyAmount, wantSellAmount *big.Int, sender types.Address, block uint64) (uint32, uint32) {
+	return s.PairAddOrderWithExpire(coinWantBuy, coinWantSell, wantBuyAmount, wantSellAmount, sender, block, 0)
+}
+
+// PairAddOrderWithExpire adds the order which is expired at the end of expireHeight block,
+// 0 means the network period of orders expiration
+func (s *Swap) PairAddOrderWithExpire(coinWantBuy, coinWantSell types.CoinID, wantBuyAmount, wantSellAmount *big.Int, sender types.Address, block uint64, expireHeight uint64) (uint32, uint32) {
 	pair := s.Pair(coinWantBuy, coinWantSell)
-	order := pair.AddOrder(wantBuyAmount, wantSellAmount, sender, block)
+	order := pair.addOrder(wantBuyAmount, wantSellAmount, sender, block, s.expiration.expireHeight(block, expireHeight))
 
 	s.bus.Checker().AddCoin(coinWantSell, wantSellAmount)
 
@@ -1130,7 +1164,7 @@ func (s *Swap) removeLimitOrder(order *Limit) (types.CoinID, *big.Int) {
 
 func (s *Swap) pairAddOrderWithID(coinWantBuy, coinWantSell types.CoinID, wantBuyAmount, wantSellAmount *big.Int, sender types.Address, id uint32, height uint64) (uint32, uint32) {
 	pair := s.Pair(coinWantBuy, coinWantSell)
-	order := pair.addOrderWithID(wantBuyAmount, wantSellAmount, sender, id, height)
+	order := pair.addOrderWithID(wantBuyAmount, wantSellAmount, sender, id, height, 0)
 
 	s.bus.Checker().AddCoin(coinWantSell, wantSellAmount)
 
@@ -1157,6 +1191,10 @@ func (p *Pair) isOrderAlreadyUsed(id uint32) bool {
 }
 
 func (p *Pair) AddOrder(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.Address, block uint64) (order *Limit) {
+	return p.addOrder(wantBuyAmount0, wantSellAmount1, sender, block, 0)
+}
+
+func (p *Pair) addOrder(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.Address, block uint64, expireHeight uint64) (order *Limit) {
 	order = &Limit{
 		PairKey:      p.PairKey,
 		IsBuy:        false,
@@ -1167,6 +1205,7 @@ func (p *Pair) AddOrder(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.A
 		Owner:        sender,
 		mu:           new(sync.RWMutex),
 		Height:       block,
+		More:         orderMore(expireHeight),
 	}
 	sortedOrder := order.sort()
 
@@ -1180,7 +1219,7 @@ func (p *Pair) AddOrder(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.A
 	return order
 }
 
-func (p *Pair) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.Address, id uint32, height uint64) (order *Limit) {
+func (p *Pair) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.Address, id uint32, height uint64, expireHeight uint64) (order *Limit) {
 	order = &Limit{
 		PairKey:      p.PairKey,
 		IsBuy:        false,
@@ -1190,6 +1229,7 @@ func (p *Pair) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender t
 		oldSortPrice: new(big.Float).SetPrec(Precision),
 		Owner:        sender,
 		Height:       height,
+		More:         orderMore(expireHeight),
 		mu:           new(sync.RWMutex),
 	}
 	sortedOrder := order.sort()
@@ -1329,7 +1369,7 @@ func (s *Swap) loadOrder(id uint32) *Limit {
 		oldSortPrice: new(big.Float).SetPrec(Precision),
 		mu:           new(sync.RWMutex),
 	}
-	err := rlp.DecodeBytes(value, order)
+	err := order.decode(value)
 	if err != nil {
 		panic(err)
 	}
@@ -1751,6 +1791,7 @@ func (p *Pair) AddLastSwapStepWithOrders(amount0In, amount1Out *big.Int, buy boo
 			Reserve1:  reserve1,
 			ID:        p.ID,
 			markDirty: func() {},
+			fee:       p.fee,
 		},
 		sellOrders: &limits{
 			ids: p.sellOrders.ids[:len(p.sellOrders.ids):len(p.sellOrders.ids)],
@@ -10,8 +10,8 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
 	"github.com/MinterTeam/minter-go-node/coreV2/types"
-	"github.com/MinterTeam/minter-go-node/rlp"
 	"github.com/cosmos/iavl"
 )
 
@@ -24,6 +24,7 @@ func (s *SwapV2) PairSellWithOrders(coin0, coin1 types.CoinID, amount0In, minAmo
 		panic(fmt.Sprintf("calculatedAmount1Out %s less minAmount1Out %s", amount1Out, minAmount1Out))
 	}
 
+	addOrderFillEvents(s.bus, pair, details.Orders)
 	s.handleLittleExpiredOrders(expiredOrders)
 
 	owners := sortOwners(ownersMap)
@@ -48,6 +49,7 @@ func (s *SwapV2) PairBuyWithOrders(coin0, coin1 types.CoinID, maxAmount0In, amou
 		panic(fmt.Sprintf("calculatedAmount1Out %s less minAmount1Out %s", amount1Out, maxAmount0In))
 	}
 
+	addOrderFillEvents(s.bus, pair, details.Orders)
 	s.handleLittleExpiredOrders(expiredOrders)
 
 	owners := sortOwners(ownersMap)
@@ -79,6 +81,46 @@ func (s *SwapV2) handleLittleExpiredOrders(expiredOrders []*Limit) {
 	}
 }
 
+type orderGetter interface {
+	getOrder(id uint32) *Limit
+}
+
+// addOrderFillEvents emits events of the limit orders consumed by the swap with their remaining volumes
+func addOrderFillEvents(b *bus.Bus, pair orderGetter, orders []*Limit) {
+	if b.Events() == nil {
+		return
+	}
+	for _, fill := range orders {
+		coinToSell, coinToBuy, valueSold, valueBought := fill.Coin1, fill.Coin0, fill.WantSell, fill.WantBuy
+		if fill.IsBuy {
+			coinToSell, coinToBuy, valueSold, valueBought = fill.Coin0, fill.Coin1, fill.WantBuy, fill.WantSell
+		}
+
+		remainingSell, remainingBuy := big.NewInt(0), big.NewInt(0)
+		if order := pair.getOrder(fill.id); order != nil {
+			remainingSell, remainingBuy = order.WantSell, order.WantBuy
+			if order.IsBuy {
+				remainingSell, remainingBuy = order.WantBuy, order.WantSell
+			}
+		}
+
+		event := &events.OrderFilledEvent{
+			ID:            uint64(fill.id),
+			Address:       fill.Owner,
+			CoinToSell:    uint64(coinToSell),
+			ValueSold:     valueSold.String(),
+			CoinToBuy:     uint64(coinToBuy),
+			ValueBought:   valueBought.String(),
+			RemainingSell: remainingSell.String(),
+			RemainingBuy:  remainingBuy.String(),
+		}
+		if txHash := b.TxHash(); txHash != (types.Hash{}) {
+			event.TxHash = fmt.Sprintf("Mt%x", txHash[:])
+		}
+		b.Events().AddEvent(event)
+	}
+}
+
 func (p *PairV2) SellWithOrders(amount0In *big.Int) (amount1Out *big.Int, owners map[types.Address]*big.Int, c *ChangeDetailsWithOrders, expiredOrders []*Limit) {
 	if amount0In == nil || amount0In.Sign() != 1 {
 		panic(ErrorInsufficientInputAmount)
@@ -340,11 +382,13 @@ func (p *PairV2) calculateAddAmountsForPrice(price *big.Float) (amount0 *big.Int
 	r1 := big.NewFloat(0).SetInt(reserve1)
 	k := big.NewFloat(0).Mul(r0, r1)
 	r0Qrt := big.NewFloat(0).Mul(r0, r0)
-	b := big.NewFloat(0).Mul(big.NewFloat((2000.0-commission)/2), r0)
+	fee, denominator := p.swapFee()
+	f, dn := float64(fee.Int64()), float64(denominator.Int64())
+	b := big.NewFloat(0).Mul(big.NewFloat((2*dn-f)/2), r0)
 	kMulPrice := big.NewFloat(0).Mul(k, big.NewFloat(0).Quo(big.NewFloat(1), price))
 	r0QrtSubKMulPrice := big.NewFloat(0).Sub(r0Qrt, kMulPrice)
-	d := big.NewFloat(0).Sub(big.NewFloat(0).Mul(big.NewFloat((2000-commission)*(2000-commission)/4), r0Qrt), big.NewFloat(0).Mul(big.NewFloat(2000*(1000-commission)/2), r0QrtSubKMulPrice))
-	x1 := big.NewFloat(0).Quo(big.NewFloat(0).Add(big.NewFloat(0).Neg(b), big.NewFloat(0).Sqrt(d)), big.NewFloat(1000-commission))
+	d := big.NewFloat(0).Sub(big.NewFloat(0).Mul(big.NewFloat((2*dn-f)*(2*dn-f)/4), r0Qrt), big.NewFloat(0).Mul(big.NewFloat(dn*(dn-f)), r0QrtSubKMulPrice))
+	x1 := big.NewFloat(0).Quo(big.NewFloat(0).Add(big.NewFloat(0).Neg(b), big.NewFloat(0).Sqrt(d)), big.NewFloat(dn-f))
 	var acc big.Accuracy
 	amount0, acc = x1.Int(nil)
 	if acc != big.Exact {
@@ -726,8 +770,14 @@ func (p *PairV2) getDirtyOrdersList() []uint32 {
 }
 
 func (s *SwapV2) PairAddOrder(coinWantBuy, coinWantSell types.CoinID, wantBuyAmount, wantSellAmount *big.Int, sender types.Address, block uint64) (uint32, uint32) {
+	return s.PairAddOrderWithExpire(coinWantBuy, coinWantSell, wantBuyAmount, wantSellAmount, sender, block, 0)
+}
+
+// PairAddOrderWithExpire adds the order which is expired at the end of expireHeight block,
+// 0 means the network period of orders expiration
+func (s *SwapV2) PairAddOrderWithExpire(coinWantBuy, coinWantSell types.CoinID, wantBuyAmount, wantSellAmount *big.Int, sender types.Address, block uint64, expireHeight uint64) (uint32, uint32) {
 	pair := s.Pair(coinWantBuy, coinWantSell)
-	order := pair.AddOrder(wantBuyAmount, wantSellAmount, sender, block)
+	order := pair.addOrder(wantBuyAmount, wantSellAmount, sender, block, s.expiration.expireHeight(block, expireHeight))
 
 	s.bus.Checker().AddCoin(coinWantSell, wantSellAmount)
 
@@ -778,7 +828,7 @@ func (s *SwapV2) removeLimitOrder(order *Limit) (types.CoinID, *big.Int) {
 
 func (s *SwapV2) pairAddOrderWithID(coinWantBuy, coinWantSell types.CoinID, wantBuyAmount, wantSellAmount *big.Int, sender types.Address, id uint32, height uint64) (uint32, uint32) {
 	pair := s.Pair(coinWantBuy, coinWantSell)
-	order := pair.addOrderWithID(wantBuyAmount, wantSellAmount, sender, id, height)
+	order := pair.addOrderWithID(wantBuyAmount, wantSellAmount, sender, id, height, 0)
 
 	s.bus.Checker().AddCoin(coinWantSell, wantSellAmount)
 
@@ -805,6 +855,10 @@ func (p *PairV2) isOrderAlreadyUsed(id uint32) bool {
 }
 
 func (p *PairV2) AddOrder(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.Address, block uint64) (order *Limit) {
+	return p.addOrder(wantBuyAmount0, wantSellAmount1, sender, block, 0)
+}
+
+func (p *PairV2) addOrder(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.Address, block uint64, expireHeight uint64) (order *Limit) {
 	order = &Limit{
 		PairKey:      p.PairKey,
 		IsBuy:        false,
@@ -815,6 +869,7 @@ func (p *PairV2) AddOrder(wantBuyAmount0, wantSellAmount1 *big.Int, sender types
 		Owner:        sender,
 		mu:           new(sync.RWMutex),
 		Height:       block,
+		More:         orderMore(expireHeight),
 	}
 	sortedOrder := order.sort()
 
@@ -828,7 +883,7 @@ func (p *PairV2) AddOrder(wantBuyAmount0, wantSellAmount1 *big.Int, sender types
 	return order
 }
 
-func (p *PairV2) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.Address, id uint32, height uint64) (order *Limit) {
+func (p *PairV2) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender types.Address, id uint32, height uint64, expireHeight uint64) (order *Limit) {
 	order = &Limit{
 		PairKey:      p.PairKey,
 		IsBuy:        false,
@@ -838,6 +893,7 @@ func (p *PairV2) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender
 		oldSortPrice: new(big.Float).SetPrec(Precision),
 		Owner:        sender,
 		Height:       height,
+		More:         orderMore(expireHeight),
 		mu:           new(sync.RWMutex),
 	}
 	sortedOrder := order.sort()
@@ -848,6 +904,13 @@ func (p *PairV2) addOrderWithID(wantBuyAmount0, wantSellAmount1 *big.Int, sender
 	return order
 }
 
+func orderMore(expireHeight uint64) []uint64 {
+	if expireHeight == 0 {
+		return nil
+	}
+	return []uint64{expireHeight}
+}
+
 func (p *PairV2) loadAllOrders(immutableTree *iavl.ImmutableTree) (orders []*Limit) {
 	const countFirstBytes = 10
 
@@ -977,7 +1040,7 @@ func (s *SwapV2) loadOrder(id uint32) *Limit {
 		oldSortPrice: new(big.Float).SetPrec(Precision),
 		mu:           new(sync.RWMutex),
 	}
-	err := rlp.DecodeBytes(value, order)
+	err := order.decode(value)
 	if err != nil {
 		panic(err)
 	}
@@ -1355,6 +1418,7 @@ func (p *PairV2) AddLastSwapStepWithOrders(amount0In, amount1Out *big.Int, buy b
 			Reserve1:  reserve1,
 			ID:        p.ID,
 			markDirty: func() {},
+			fee:       p.fee,
 		},
 		sellOrders: &limits{
 			ids: p.sellOrders.ids[:len(p.sellOrders.ids):len(p.sellOrders.ids)],
@@ -3,6 +3,7 @@ package swap
 import (
 	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"math"
 	"math/big"
 	"testing"
 
@@ -165,3 +166,246 @@ func TestSwap_PairSellSimple(t *testing.T) {
 	}
 	t.Logf("%#v", events.LoadEvents(0))
 }
+
+func TestSwap_ExpireOrdersAtHeight(t *testing.T) {
+	memDB := db.NewMemDB()
+	immutableTree, err := tree.NewMutableTree(0, memDB, 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newBus := bus.NewBus()
+	checker.NewChecker(newBus)
+	accountsState := accounts.NewAccounts(newBus, immutableTree.GetLastImmutable())
+	accounts.NewBus(accountsState)
+	newBus.SetEvents(&eventsdb.MockEvents{})
+
+	swap := NewV2(newBus, immutableTree.GetLastImmutable())
+	_, _, _, _ = swap.PairCreate(0, 1, helpers.BipToPip(big.NewInt(1)), helpers.BipToPip(big.NewInt(1)))
+	id, _ := swap.PairAddOrderWithExpire(0, 1, helpers.BipToPip(big.NewInt(1001)), helpers.BipToPip(big.NewInt(999)), types.Address{1}, 1, 5)
+	withoutExpire, _ := swap.PairAddOrder(0, 1, helpers.BipToPip(big.NewInt(1002)), helpers.BipToPip(big.NewInt(999)), types.Address{1}, 1)
+
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	swap.ExpireOrdersAtHeight(4)
+	if order := swap.GetOrder(id); order == nil || order.ExpireHeight() != 5 {
+		t.Fatal("order is expired before its height")
+	}
+
+	swap.ExpireOrdersAtHeight(5)
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if swap.GetOrder(id) != nil {
+		t.Error("order is not expired")
+	}
+	if swap.GetOrder(withoutExpire) == nil {
+		t.Error("order without expiration height is expired")
+	}
+	if balance := accountsState.GetBalance(types.Address{1}, 1); balance.Cmp(helpers.BipToPip(big.NewInt(999))) != 0 {
+		t.Errorf("volume of expired order is not returned, balance %s", balance)
+	}
+
+	immutableTree.GetLastImmutable().IterateRange(pathOrderExpire(0, 0), pathOrderExpire(math.MaxUint64, 0), true, func(key []byte, value []byte) bool {
+		t.Errorf("expiration index is not cleared: %x", key)
+		return false
+	})
+}
+
+func TestSwap_ActivateStopOrders(t *testing.T) {
+	memDB := db.NewMemDB()
+	immutableTree, err := tree.NewMutableTree(0, memDB, 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newBus := bus.NewBus()
+	checker.NewChecker(newBus)
+	accountsState := accounts.NewAccounts(newBus, immutableTree.GetLastImmutable())
+	accounts.NewBus(accountsState)
+	events := &eventsdb.MockEvents{}
+	newBus.SetEvents(events)
+
+	swap := NewV2(newBus, immutableTree.GetLastImmutable())
+	_, _, _, _ = swap.PairCreate(0, 1, helpers.BipToPip(big.NewInt(1000)), helpers.BipToPip(big.NewInt(1000)))
+	market, _ := swap.PairAddStopOrder(0, 1, helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(9)), big.NewInt(0), types.Address{1}, 1)
+	limit, _ := swap.PairAddStopOrder(0, 1, helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(8)), helpers.BipToPip(big.NewInt(9)), types.Address{2}, 1)
+
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	swap.ActivateStopOrders(2)
+	if swap.GetStopOrder(market) == nil || swap.GetStopOrder(limit) == nil {
+		t.Fatal("stop order is activated without price change")
+	}
+
+	_, _, _, _, _ = swap.PairSellWithOrders(0, 1, helpers.BipToPip(big.NewInt(100)), big.NewInt(0))
+	swap.ActivateStopOrders(3)
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if swap.GetStopOrder(market) == nil {
+		t.Fatal("stop order is activated by the price of the same block")
+	}
+
+	swap.ActivateStopOrders(4)
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if swap.GetStopOrder(market) != nil {
+		t.Error("market stop order is not activated")
+	}
+	if swap.GetStopOrder(limit) == nil {
+		t.Fatal("stop order is activated above its trigger price")
+	}
+	if balance := accountsState.GetBalance(types.Address{1}, 1); balance.Sign() != 1 {
+		t.Errorf("market stop order is not sold, balance %s", balance)
+	}
+
+	_, _, _, _, _ = swap.PairSellWithOrders(0, 1, helpers.BipToPip(big.NewInt(100)), big.NewInt(0))
+	swap.ActivateStopOrders(5)
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	swap.ActivateStopOrders(6)
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if swap.GetStopOrder(limit) != nil {
+		t.Fatal("limit stop order is not activated")
+	}
+	activated := events.LoadEvents(0)
+	if len(activated) != 2 {
+		t.Fatalf("want 2 events, got %d", len(activated))
+	}
+	event := activated[1].(*eventsdb.StopOrderActivatedEvent)
+	if event.LimitOrderID == 0 || event.ValueToSell != "0" {
+		t.Fatalf("stop order is not converted into the limit order: %#v", event)
+	}
+	if order := swap.GetOrder(uint32(event.LimitOrderID)); order == nil || order.Owner != (types.Address{2}) {
+		t.Fatal("limit order is not placed")
+	}
+}
+
+func TestSwap_ExpireStopOrders(t *testing.T) {
+	memDB := db.NewMemDB()
+	immutableTree, err := tree.NewMutableTree(0, memDB, 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newBus := bus.NewBus()
+	checker.NewChecker(newBus)
+	accountsState := accounts.NewAccounts(newBus, immutableTree.GetLastImmutable())
+	accounts.NewBus(accountsState)
+	events := &eventsdb.MockEvents{}
+	newBus.SetEvents(events)
+
+	swap := NewV2(newBus, immutableTree.GetLastImmutable())
+	_, _, _, _ = swap.PairCreate(0, 1, helpers.BipToPip(big.NewInt(1000)), helpers.BipToPip(big.NewInt(1000)))
+	first, _ := swap.PairAddStopOrder(0, 1, helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(9)), big.NewInt(0), types.Address{1}, 1)
+	second, _ := swap.PairAddStopOrder(1, 0, helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(9)), big.NewInt(0), types.Address{1}, 2)
+	if count := swap.GetStopOrdersCount(types.Address{1}, 1, 0); count != 2 {
+		t.Fatalf("want 2 stop orders of the owner, got %d", count)
+	}
+
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	swap.ExpireStopOrders(1)
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if swap.GetStopOrder(first) != nil {
+		t.Error("stop order is not expired")
+	}
+	if swap.GetStopOrder(second) == nil {
+		t.Error("stop order is expired before its height")
+	}
+	if count := swap.GetStopOrdersCount(types.Address{1}, 0, 1); count != 1 {
+		t.Errorf("want 1 stop order of the owner, got %d", count)
+	}
+	if balance := accountsState.GetBalance(types.Address{1}, 0); balance.Cmp(helpers.BipToPip(big.NewInt(10))) != 0 {
+		t.Errorf("expired stop order is not refunded, balance %s", balance)
+	}
+	if expired := events.LoadEvents(0); len(expired) != 1 {
+		t.Errorf("want 1 event, got %d", len(expired))
+	}
+}
+
+func TestSwap_MigrateOrdersExpiration(t *testing.T) {
+	memDB := db.NewMemDB()
+	immutableTree, err := tree.NewMutableTree(0, memDB, 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newBus := bus.NewBus()
+	checker.NewChecker(newBus)
+	accountsState := accounts.NewAccounts(newBus, immutableTree.GetLastImmutable())
+	accounts.NewBus(accountsState)
+	newBus.SetEvents(&eventsdb.MockEvents{})
+
+	swap := NewV2(newBus, immutableTree.GetLastImmutable())
+	_, _, _, _ = swap.PairCreate(0, 1, helpers.BipToPip(big.NewInt(1)), helpers.BipToPip(big.NewInt(1)))
+	legacy, _ := swap.PairAddOrder(0, 1, helpers.BipToPip(big.NewInt(1001)), helpers.BipToPip(big.NewInt(999)), types.Address{1}, 1)
+
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	swap.EnableOrdersExpiration(10)
+	swap.MigrateOrdersExpiration()
+	id, _ := swap.PairAddOrder(0, 1, helpers.BipToPip(big.NewInt(1002)), helpers.BipToPip(big.NewInt(999)), types.Address{1}, 2)
+
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if order := swap.GetOrder(id); order == nil || order.ExpireHeight() != 12 {
+		t.Fatalf("order is not expired at the end of the period: %v", order)
+	}
+
+	swap.ExpireOrdersAtHeight(11)
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if swap.GetOrder(legacy) != nil {
+		t.Error("order placed before the index is not expired")
+	}
+	if swap.GetOrder(id) == nil {
+		t.Fatal("order is expired before its height")
+	}
+
+	swap.ExpireOrdersAtHeight(12)
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if swap.GetOrder(id) != nil {
+		t.Error("order is not expired")
+	}
+	immutableTree.GetLastImmutable().IterateRange(pathOrderExpire(0, 0), pathOrderExpire(math.MaxUint64, 0), true, func(key []byte, value []byte) bool {
+		t.Errorf("expiration index is not cleared: %x", key)
+		return false
+	})
+}
@@ -0,0 +1,100 @@
+package swap
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cosmos/iavl"
+)
+
+// orderExpiration is the index of limit orders by heights of their expiration.
+// After the index is enabled by the network update orders without custom expiration height are indexed
+// at the end of the network period, so all orders are expired by the index instead of scanning by creation height.
+type orderExpiration struct {
+	// period is the network period of orders expiration, zero until the index is enabled.
+	// Orders keep the expiration height of the period they are placed with.
+	period uint64
+	// migration is non-zero if the next commit should index the committed orders placed before the index was enabled
+	migration uint32
+
+	mu sync.Mutex
+	// processed are the keys of the index handled by expiration, they are removed on commit
+	processed [][]byte
+
+	immutableTree func() *iavl.ImmutableTree
+}
+
+func newOrderExpiration(immutableTree func() *iavl.ImmutableTree) *orderExpiration {
+	return &orderExpiration{immutableTree: immutableTree}
+}
+
+func (oe *orderExpiration) enable(period uint64) {
+	atomic.StoreUint64(&oe.period, period)
+}
+
+func (oe *orderExpiration) migrate() {
+	atomic.StoreUint32(&oe.migration, 1)
+}
+
+// expireHeight returns the height of expiration of the order placed at the block, 0 if it is expired by creation height
+func (oe *orderExpiration) expireHeight(block, expireHeight uint64) uint64 {
+	if expireHeight != 0 {
+		return expireHeight
+	}
+	if period := atomic.LoadUint64(&oe.period); period != 0 {
+		return block + period
+	}
+	return 0
+}
+
+// expired returns the orders which expiration height is not greater than height,
+// keys of removed orders are handled too, so the index doesn't keep keys of orders filled or cancelled before
+func (oe *orderExpiration) expired(height uint64, loadOrder func(id uint32) *Limit) []*Limit {
+	oe.mu.Lock()
+	defer oe.mu.Unlock()
+
+	var orders []*Limit
+	oe.immutableTree().IterateRange(pathOrderExpire(0, 0), pathOrderExpire(height+1, 0), true, func(key []byte, value []byte) bool {
+		oe.processed = append(oe.processed, append([]byte{}, key...))
+		if order := loadOrder(binary.BigEndian.Uint32(key[len(key)-4:])); order != nil {
+			orders = append(orders, order)
+		}
+		return false
+	})
+
+	return orders
+}
+
+func (oe *orderExpiration) commit(db *iavl.MutableTree) {
+	oe.mu.Lock()
+	processed := oe.processed
+	oe.processed = nil
+	oe.mu.Unlock()
+
+	for _, key := range processed {
+		db.Remove(key)
+	}
+
+	if !atomic.CompareAndSwapUint32(&oe.migration, 1, 0) {
+		return
+	}
+
+	period := atomic.LoadUint64(&oe.period)
+	oe.immutableTree().IterateRange(pathOrder(0), pathOrder(math.MaxUint32), true, func(key []byte, value []byte) bool {
+		if value == nil {
+			return false
+		}
+
+		order := &Limit{}
+		if err := order.decode(value); err != nil {
+			panic(err)
+		}
+		if order.ExpireHeight() == 0 {
+			db.Set(pathOrderExpire(order.Height+period, binary.BigEndian.Uint32(key[1:])), []byte{})
+		}
+
+		return false
+	})
+}
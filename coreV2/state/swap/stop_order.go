@@ -0,0 +1,660 @@
+package swap
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const stopOrderPrefix = 't'
+const pairStopOrdersPrefix = 'g'
+const ownerStopOrdersPrefix = 'u'
+const stopOrderExpirePrefix = 'y'
+const pendingStopOrderPairsPrefix = 'q'
+const totalStopOrdersIDPrefix = 'z'
+
+// MaxStopOrdersPerOwner is the maximum number of stop orders of an address in a pool
+const MaxStopOrdersPerOwner = 10
+
+// StopOrderSlippage is the maximum deviation of the price of the activated market stop order from its trigger price
+// in basis points, the part which can't be sold within it is refunded
+const StopOrderSlippage = 1000
+
+// maxStopOrdersActivatedPerBlock bounds the work of the end of the block,
+// the rest of the triggered orders are activated in the next blocks
+const maxStopOrdersActivatedPerBlock = 100
+
+// StopOrder is a dormant order which is activated when the price of CoinToSell in the pool
+// at the end of the previous block is TriggerValue/ValueToSell or lower.
+// Activated order is sold by the market no cheaper than StopOrderSlippage below the trigger price if MinimumValueToBuy is zero,
+// otherwise it becomes a limit order with the price MinimumValueToBuy/ValueToSell.
+// The order which is not activated during the expiration period of limit orders is refunded.
+type StopOrder struct {
+	Owner             types.Address
+	CoinToSell        types.CoinID
+	ValueToSell       *big.Int
+	CoinToBuy         types.CoinID
+	TriggerValue      *big.Int
+	MinimumValueToBuy *big.Int
+	Height            uint64
+
+	id      uint32
+	removed bool
+}
+
+func (o *StopOrder) ID() uint32 {
+	return o.id
+}
+
+func (o *StopOrder) PairKey() PairKey {
+	return PairKey{Coin0: o.CoinToSell, Coin1: o.CoinToBuy}
+}
+
+// IsMarket returns true if the order is sold by the market after activation
+func (o *StopOrder) IsMarket() bool {
+	return o.MinimumValueToBuy.Sign() == 0
+}
+
+// TriggerPrice returns the price of CoinToSell in CoinToBuy which activates the order
+func (o *StopOrder) TriggerPrice() *big.Rat {
+	return CalcPriceSellRat(o.ValueToSell, o.TriggerValue)
+}
+
+// IsTriggered returns true if the order should be activated with the given price of CoinToSell in CoinToBuy
+func (o *StopOrder) IsTriggered(price *big.Rat) bool {
+	return price.Cmp(o.TriggerPrice()) != 1
+}
+
+func pathStopOrder(id uint32) []byte {
+	return append([]byte{mainPrefix, stopOrderPrefix}, id2Bytes(id)...)
+}
+
+func pathPairStopOrders(key PairKey) []byte {
+	return append([]byte{mainPrefix, pairStopOrdersPrefix}, key.bytes()...)
+}
+
+func pathPairStopOrder(key PairKey, id uint32) []byte {
+	return append(pathPairStopOrders(key), id2Bytes(id)...)
+}
+
+func pathOwnerStopOrders(owner types.Address, key PairKey) []byte {
+	return append(append([]byte{mainPrefix, ownerStopOrdersPrefix}, owner.Bytes()...), key.bytes()...)
+}
+
+func pathOwnerStopOrder(owner types.Address, key PairKey, id uint32) []byte {
+	return append(pathOwnerStopOrders(owner, key), id2Bytes(id)...)
+}
+
+func pathStopOrderExpire(height uint64, id uint32) []byte {
+	byteHeight := make([]byte, 8)
+	binary.BigEndian.PutUint64(byteHeight, height)
+	return append(append([]byte{mainPrefix, stopOrderExpirePrefix}, byteHeight...), id2Bytes(id)...)
+}
+
+type stopOrders struct {
+	mu     sync.Mutex
+	list   map[uint32]*StopOrder
+	dirty  map[uint32]struct{}
+	nextID uint32
+
+	// pending are the pairs which stop orders are checked at the end of the next block by prices of the committed state
+	pending      []PairKey
+	dirtyPending bool
+
+	dirtyNextID   bool
+	immutableTree func() *iavl.ImmutableTree
+}
+
+func newStopOrders(immutableTree func() *iavl.ImmutableTree) *stopOrders {
+	return &stopOrders{
+		list:          map[uint32]*StopOrder{},
+		dirty:         map[uint32]struct{}{},
+		immutableTree: immutableTree,
+	}
+}
+
+func (so *stopOrders) get(id uint32) *StopOrder {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	return so.getLocked(id)
+}
+
+func (so *stopOrders) getLocked(id uint32) *StopOrder {
+	if order, ok := so.list[id]; ok {
+		if order.removed {
+			return nil
+		}
+		return order
+	}
+
+	_, value := so.immutableTree().Get(pathStopOrder(id))
+	if value == nil {
+		return nil
+	}
+
+	order := &StopOrder{id: id}
+	if err := rlp.DecodeBytes(value, order); err != nil {
+		panic(err)
+	}
+	so.list[id] = order
+
+	return order
+}
+
+func (so *stopOrders) add(order *StopOrder) {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	if order.id == 0 {
+		order.id = so.loadNextID()
+		so.nextID = order.id + 1
+	} else if order.id >= so.loadNextID() {
+		so.nextID = order.id + 1
+	}
+	so.dirtyNextID = true
+
+	so.list[order.id] = order
+	so.dirty[order.id] = struct{}{}
+}
+
+func (so *stopOrders) remove(order *StopOrder) {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	order.removed = true
+	so.list[order.id] = order
+	so.dirty[order.id] = struct{}{}
+}
+
+func (so *stopOrders) loadNextID() uint32 {
+	if so.nextID != 0 {
+		return so.nextID
+	}
+	_, value := so.immutableTree().Get([]byte{mainPrefix, totalStopOrdersIDPrefix})
+	if len(value) == 0 {
+		return 1
+	}
+	var id uint32
+	if err := rlp.DecodeBytes(value, &id); err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// pairOrders returns active stop orders of the pair in both directions sorted by ID
+func (so *stopOrders) pairOrders(key PairKey) []*StopOrder {
+	return so.orders(pathPairStopOrders(key), func(order *StopOrder) bool {
+		return order.PairKey().sort() == key.sort()
+	})
+}
+
+// ownerOrders returns active stop orders of the owner in the pair in both directions sorted by ID
+func (so *stopOrders) ownerOrders(owner types.Address, key PairKey) []*StopOrder {
+	return so.orders(pathOwnerStopOrders(owner, key), func(order *StopOrder) bool {
+		return order.Owner == owner && order.PairKey().sort() == key.sort()
+	})
+}
+
+// orders returns active stop orders of the index prefix and not committed ones which match the filter
+func (so *stopOrders) orders(prefix []byte, filter func(order *StopOrder) bool) []*StopOrder {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	ids := map[uint32]struct{}{}
+	start := append(append([]byte{}, prefix...), id2Bytes(0)...)
+	end := append(append([]byte{}, prefix...), id2Bytes(math.MaxUint32)...)
+	so.immutableTree().IterateRange(start, end, true, func(k []byte, value []byte) bool {
+		ids[binary.BigEndian.Uint32(k[len(k)-4:])] = struct{}{}
+		return false
+	})
+	for id := range so.dirty {
+		ids[id] = struct{}{}
+	}
+
+	var orders []*StopOrder
+	for id := range ids {
+		order := so.getLocked(id)
+		if order == nil || !filter(order) {
+			continue
+		}
+		orders = append(orders, order)
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].id < orders[j].id
+	})
+
+	return orders
+}
+
+// expiredOrders returns active stop orders placed not later than the height sorted by ID
+func (so *stopOrders) expiredOrders(beforeHeight uint64) []*StopOrder {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	var orders []*StopOrder
+	so.immutableTree().IterateRange(pathStopOrderExpire(0, 0), pathStopOrderExpire(beforeHeight+1, 0), true, func(k []byte, value []byte) bool {
+		if order := so.getLocked(binary.BigEndian.Uint32(k[len(k)-4:])); order != nil {
+			orders = append(orders, order)
+		}
+		return false
+	})
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].id < orders[j].id
+	})
+
+	return orders
+}
+
+func (so *stopOrders) loadPending() []PairKey {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	if so.dirtyPending {
+		return so.pending
+	}
+	_, value := so.immutableTree().Get([]byte{mainPrefix, pendingStopOrderPairsPrefix})
+	if len(value) == 0 {
+		return nil
+	}
+	var keys []PairKey
+	if err := rlp.DecodeBytes(value, &keys); err != nil {
+		panic(err)
+	}
+	return keys
+}
+
+func (so *stopOrders) setPending(keys []PairKey) {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	so.pending = keys
+	so.dirtyPending = true
+}
+
+// committedPrice returns the price of CoinToSell in CoinToBuy at the end of the previous block or nil if the pool has not been committed yet
+func (so *stopOrders) committedPrice(order *StopOrder) *big.Rat {
+	key := order.PairKey()
+	_, data := so.immutableTree().Get(append([]byte{mainPrefix}, key.sort().pathData()...))
+	if len(data) == 0 {
+		return nil
+	}
+	pair := &pairData{}
+	if err := rlp.DecodeBytes(data, pair); err != nil {
+		panic(err)
+	}
+	if pair.Reserve0.Sign() != 1 || pair.Reserve1.Sign() != 1 {
+		return nil
+	}
+	if key.isSorted() {
+		return CalcPriceSellRat(pair.Reserve0, pair.Reserve1)
+	}
+	return CalcPriceSellRat(pair.Reserve1, pair.Reserve0)
+}
+
+func (so *stopOrders) commit(db *iavl.MutableTree) error {
+	so.mu.Lock()
+	defer so.mu.Unlock()
+
+	if so.dirtyNextID {
+		so.dirtyNextID = false
+		b, err := rlp.EncodeToBytes(so.nextID)
+		if err != nil {
+			return err
+		}
+		db.Set([]byte{mainPrefix, totalStopOrdersIDPrefix}, b)
+	}
+
+	if so.dirtyPending {
+		so.dirtyPending = false
+		if len(so.pending) == 0 {
+			db.Remove([]byte{mainPrefix, pendingStopOrderPairsPrefix})
+		} else {
+			b, err := rlp.EncodeToBytes(so.pending)
+			if err != nil {
+				return err
+			}
+			db.Set([]byte{mainPrefix, pendingStopOrderPairsPrefix}, b)
+		}
+		so.pending = nil
+	}
+
+	ids := make([]uint32, 0, len(so.dirty))
+	for id := range so.dirty {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		order := so.list[id]
+		if order.removed {
+			db.Remove(pathStopOrder(id))
+			db.Remove(pathPairStopOrder(order.PairKey(), id))
+			db.Remove(pathOwnerStopOrder(order.Owner, order.PairKey(), id))
+			db.Remove(pathStopOrderExpire(order.Height, id))
+			delete(so.list, id)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(order)
+		if err != nil {
+			return err
+		}
+		db.Set(pathStopOrder(id), data)
+		db.Set(pathPairStopOrder(order.PairKey(), id), []byte{})
+		db.Set(pathOwnerStopOrder(order.Owner, order.PairKey(), id), []byte{})
+		db.Set(pathStopOrderExpire(order.Height, id), []byte{})
+	}
+	so.dirty = map[uint32]struct{}{}
+
+	return nil
+}
+
+func (so *stopOrders) export(key PairKey) []types.StopOrder {
+	var result []types.StopOrder
+	for _, order := range so.pairOrders(key) {
+		result = append(result, types.StopOrder{
+			ID:                uint64(order.id),
+			Owner:             order.Owner,
+			CoinToSell:        uint64(order.CoinToSell),
+			ValueToSell:       order.ValueToSell.String(),
+			CoinToBuy:         uint64(order.CoinToBuy),
+			TriggerValue:      order.TriggerValue.String(),
+			MinimumValueToBuy: order.MinimumValueToBuy.String(),
+			Height:            order.Height,
+		})
+	}
+	return result
+}
+
+func (so *stopOrders) importOrders(pool types.Pool) []*StopOrder {
+	var result []*StopOrder
+	for _, order := range pool.StopOrders {
+		stopOrder := &StopOrder{
+			Owner:             order.Owner,
+			CoinToSell:        types.CoinID(order.CoinToSell),
+			ValueToSell:       helpers.StringToBigInt(order.ValueToSell),
+			CoinToBuy:         types.CoinID(order.CoinToBuy),
+			TriggerValue:      helpers.StringToBigInt(order.TriggerValue),
+			MinimumValueToBuy: helpers.StringToBigInt(order.MinimumValueToBuy),
+			Height:            order.Height,
+			id:                uint32(order.ID),
+		}
+		so.add(stopOrder)
+		result = append(result, stopOrder)
+	}
+	return result
+}
+
+type stopOrderExecutor interface {
+	GetSwapper(coinA, coinB types.CoinID) EditableChecker
+	PairSellWithOrders(coin0, coin1 types.CoinID, amount0In, minAmount1Out *big.Int) (*big.Int, *big.Int, uint32, *ChangeDetailsWithOrders, []*OrderDetail)
+	PairAddOrder(coinWantBuy, coinWantSell types.CoinID, wantBuyAmount, wantSellAmount *big.Int, sender types.Address, block uint64) (uint32, uint32)
+}
+
+// activate converts the stop orders of the pairs changed in the previous block which are triggered by the committed prices,
+// so a swap can't trigger the orders and take them in the same block. Pairs of the block are checked in the next one.
+func (so *stopOrders) activate(s stopOrderExecutor, bus *bus.Bus, keys []PairKey, height uint64) {
+	var next []PairKey
+	activated := 0
+	pending := so.loadPending()
+	for i, key := range pending {
+		if activated == maxStopOrdersActivatedPerBlock {
+			next = append(next, pending[i:]...)
+			break
+		}
+		for _, order := range so.pairOrders(key) {
+			price := so.committedPrice(order)
+			if price == nil || !order.IsTriggered(price) {
+				continue
+			}
+			if activated == maxStopOrdersActivatedPerBlock {
+				next = append(next, key)
+				break
+			}
+			so.activateOrder(s, bus, order, height)
+			activated++
+		}
+	}
+
+	for _, key := range keys {
+		isPending := false
+		for _, pending := range next {
+			if pending.sort() == key.sort() {
+				isPending = true
+				break
+			}
+		}
+		if !isPending && len(so.pairOrders(key)) != 0 {
+			next = append(next, key.sort())
+		}
+	}
+
+	so.setPending(next)
+}
+
+func (so *stopOrders) activateOrder(s stopOrderExecutor, bus *bus.Bus, order *StopOrder, height uint64) {
+	so.remove(order)
+	bus.Checker().AddCoin(order.CoinToSell, new(big.Int).Neg(order.ValueToSell))
+
+	event := &events.StopOrderActivatedEvent{
+		ID:          uint64(order.id),
+		Address:     order.Owner,
+		CoinToSell:  uint64(order.CoinToSell),
+		ValueToSell: "0",
+		CoinToBuy:   uint64(order.CoinToBuy),
+		ValueToBuy:  "0",
+		Refund:      "0",
+	}
+
+	valueToBuy := order.MinimumValueToBuy
+	if order.IsMarket() {
+		valueToBuy = new(big.Int).Mul(order.TriggerValue, big.NewInt(10000-StopOrderSlippage))
+		valueToBuy.Quo(valueToBuy, big.NewInt(10000))
+	}
+	fillValue, _ := ImmediateOrderFill(s.GetSwapper(order.CoinToSell, order.CoinToBuy), order.ValueToSell, valueToBuy)
+
+	if fillValue.Sign() == 1 {
+		// the filled part keeps the price of the order rounding in favor of the owner
+		minimumValueToBuy := new(big.Int).Mul(valueToBuy, fillValue)
+		minimumValueToBuy.Add(minimumValueToBuy, new(big.Int).Sub(order.ValueToSell, big.NewInt(1)))
+		minimumValueToBuy.Quo(minimumValueToBuy, order.ValueToSell)
+
+		amountIn, amountOut, _, _, owners := s.PairSellWithOrders(order.CoinToSell, order.CoinToBuy, fillValue, minimumValueToBuy)
+		for _, value := range owners {
+			bus.Accounts().AddBalance(value.Owner, order.CoinToSell, value.ValueBigInt)
+		}
+		bus.Accounts().AddBalance(order.Owner, order.CoinToBuy, amountOut)
+		event.ValueToSell = amountIn.String()
+		event.ValueToBuy = amountOut.String()
+	}
+	rest := new(big.Int).Sub(order.ValueToSell, fillValue)
+	if rest.Sign() == 1 && !order.IsMarket() {
+		// keep the price of the order rounding the remainder in favor of the owner
+		wantBuy := new(big.Int).Mul(order.MinimumValueToBuy, rest)
+		wantBuy.Add(wantBuy, new(big.Int).Sub(order.ValueToSell, big.NewInt(1)))
+		wantBuy.Quo(wantBuy, order.ValueToSell)
+
+		minimumVolume := big.NewInt(MinimumOrderVolume())
+		if rest.Cmp(minimumVolume) != -1 && wantBuy.Cmp(minimumVolume) != -1 {
+			limitOrderID, _ := s.PairAddOrder(order.CoinToBuy, order.CoinToSell, wantBuy, rest, order.Owner, height)
+			event.LimitOrderID = uint64(limitOrderID)
+			rest = big.NewInt(0)
+		}
+	}
+	if rest.Sign() == 1 {
+		bus.Accounts().AddBalance(order.Owner, order.CoinToSell, rest)
+		event.Refund = rest.String()
+	}
+
+	bus.Events().AddEvent(event)
+}
+
+// immediateOrderFillSteps bounds the number of swap calculations of ImmediateOrderFill,
+// the found volume is less than the maximum one by no more than 1/2^immediateOrderFillSteps of the order
+const immediateOrderFillSteps = 24
+
+// ImmediateOrderFill returns the maximum volume of the order which can be sold at the limit price right now
+// with the amount of coins to buy for it
+func ImmediateOrderFill(swapper EditableChecker, valueToSell, valueToBuy *big.Int) (fillValue, fillReturn *big.Int) {
+	isFilled := func(value *big.Int) (*big.Int, bool) {
+		if value.Sign() != 1 {
+			return nil, false
+		}
+		amountOut, _ := swapper.CalculateBuyForSellWithOrders(value)
+		if amountOut == nil || amountOut.Sign() != 1 {
+			return nil, false
+		}
+		// amountOut / value >= valueToBuy / valueToSell
+		return amountOut, new(big.Int).Mul(amountOut, valueToSell).Cmp(new(big.Int).Mul(value, valueToBuy)) != -1
+	}
+
+	if amountOut, ok := isFilled(valueToSell); ok {
+		return new(big.Int).Set(valueToSell), amountOut
+	}
+
+	fillValue, fillReturn = big.NewInt(0), big.NewInt(0)
+	low, high := big.NewInt(0), new(big.Int).Set(valueToSell)
+	for step := 0; step < immediateOrderFillSteps && new(big.Int).Sub(high, low).Cmp(big.NewInt(1)) == 1; step++ {
+		middle := new(big.Int).Rsh(new(big.Int).Add(low, high), 1)
+		if amountOut, ok := isFilled(middle); ok {
+			low = middle
+			fillValue, fillReturn = middle, amountOut
+		} else {
+			high = middle
+		}
+	}
+
+	return fillValue, fillReturn
+}
+
+// PairAddStopOrder places the stop order, the volume to sell is held by the swap state until activation or removal
+func (s *SwapV2) PairAddStopOrder(coinToSell, coinToBuy types.CoinID, valueToSell, triggerValue, minimumValueToBuy *big.Int, owner types.Address, block uint64) (uint32, uint32) {
+	order := &StopOrder{
+		Owner:             owner,
+		CoinToSell:        coinToSell,
+		ValueToSell:       new(big.Int).Set(valueToSell),
+		CoinToBuy:         coinToBuy,
+		TriggerValue:      new(big.Int).Set(triggerValue),
+		MinimumValueToBuy: new(big.Int).Set(minimumValueToBuy),
+		Height:            block,
+	}
+	s.stopOrders.add(order)
+
+	s.bus.Checker().AddCoin(coinToSell, valueToSell)
+
+	return order.id, s.Pair(coinToSell, coinToBuy).GetID()
+}
+
+// PairRemoveStopOrder removes the stop order and returns the held volume
+func (s *SwapV2) PairRemoveStopOrder(id uint32) (types.CoinID, *big.Int) {
+	order := s.stopOrders.get(id)
+	if order == nil {
+		return 0, big.NewInt(0)
+	}
+	s.stopOrders.remove(order)
+
+	s.bus.Checker().AddCoin(order.CoinToSell, new(big.Int).Neg(order.ValueToSell))
+
+	return order.CoinToSell, new(big.Int).Set(order.ValueToSell)
+}
+
+func (s *SwapV2) GetStopOrder(id uint32) *StopOrder {
+	return s.stopOrders.get(id)
+}
+
+// ActivateStopOrders converts the stop orders triggered by the price changes of the pools in the previous block
+func (s *SwapV2) ActivateStopOrders(height uint64) {
+	s.stopOrders.activate(s, s.bus, s.getOrderedDirtyPairs(), height)
+}
+
+// ExpireStopOrders refunds the stop orders placed not later than beforeHeight
+func (s *SwapV2) ExpireStopOrders(beforeHeight uint64) {
+	for _, order := range s.stopOrders.expiredOrders(beforeHeight) {
+		coin, volume := s.PairRemoveStopOrder(order.id)
+		s.bus.Accounts().AddBalance(order.Owner, coin, volume)
+		s.bus.Events().AddEvent(&events.StopOrderExpiredEvent{
+			ID:      uint64(order.id),
+			Address: order.Owner,
+			Coin:    uint64(coin),
+			Amount:  volume.String(),
+		})
+	}
+}
+
+// GetStopOrdersCount returns the number of stop orders of the owner in the pool
+func (s *SwapV2) GetStopOrdersCount(owner types.Address, coin0, coin1 types.CoinID) int {
+	return len(s.stopOrders.ownerOrders(owner, PairKey{Coin0: coin0, Coin1: coin1}))
+}
+
+// PairAddStopOrder places the stop order, the volume to sell is held by the swap state until activation or removal
+func (s *Swap) PairAddStopOrder(coinToSell, coinToBuy types.CoinID, valueToSell, triggerValue, minimumValueToBuy *big.Int, owner types.Address, block uint64) (uint32, uint32) {
+	order := &StopOrder{
+		Owner:             owner,
+		CoinToSell:        coinToSell,
+		ValueToSell:       new(big.Int).Set(valueToSell),
+		CoinToBuy:         coinToBuy,
+		TriggerValue:      new(big.Int).Set(triggerValue),
+		MinimumValueToBuy: new(big.Int).Set(minimumValueToBuy),
+		Height:            block,
+	}
+	s.stopOrders.add(order)
+
+	s.bus.Checker().AddCoin(coinToSell, valueToSell)
+
+	return order.id, s.Pair(coinToSell, coinToBuy).GetID()
+}
+
+// PairRemoveStopOrder removes the stop order and returns the held volume
+func (s *Swap) PairRemoveStopOrder(id uint32) (types.CoinID, *big.Int) {
+	order := s.stopOrders.get(id)
+	if order == nil {
+		return 0, big.NewInt(0)
+	}
+	s.stopOrders.remove(order)
+
+	s.bus.Checker().AddCoin(order.CoinToSell, new(big.Int).Neg(order.ValueToSell))
+
+	return order.CoinToSell, new(big.Int).Set(order.ValueToSell)
+}
+
+func (s *Swap) GetStopOrder(id uint32) *StopOrder {
+	return s.stopOrders.get(id)
+}
+
+// ActivateStopOrders converts the stop orders triggered by the price changes of the pools in the previous block
+func (s *Swap) ActivateStopOrders(height uint64) {
+	s.stopOrders.activate(s, s.bus, s.getOrderedDirtyPairs(), height)
+}
+
+// ExpireStopOrders refunds the stop orders placed not later than beforeHeight
+func (s *Swap) ExpireStopOrders(beforeHeight uint64) {
+	for _, order := range s.stopOrders.expiredOrders(beforeHeight) {
+		coin, volume := s.PairRemoveStopOrder(order.id)
+		s.bus.Accounts().AddBalance(order.Owner, coin, volume)
+		s.bus.Events().AddEvent(&events.StopOrderExpiredEvent{
+			ID:      uint64(order.id),
+			Address: order.Owner,
+			Coin:    uint64(coin),
+			Amount:  volume.String(),
+		})
+	}
+}
+
+// GetStopOrdersCount returns the number of stop orders of the owner in the pool
+func (s *Swap) GetStopOrdersCount(owner types.Address, coin0, coin1 types.CoinID) int {
+	return len(s.stopOrders.ownerOrders(owner, PairKey{Coin0: coin0, Coin1: coin1}))
+}
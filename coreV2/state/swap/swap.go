@@ -41,6 +41,8 @@ type EditableChecker interface {
 	GetOrders(ids []uint32) []*Limit
 	Exists() bool
 	GetID() uint32
+	PriceCumulative() (price0, price1 *big.Int, height uint64)
+	SwapFee() uint32
 	// Deprecated
 	AddLastSwapStep(amount0In, amount1Out *big.Int) EditableChecker
 	AddLastSwapStepWithOrders(amount0In, amount1Out *big.Int, isBuy bool) EditableChecker
@@ -73,6 +75,8 @@ type RSwap interface {
 
 	SwapPools(context.Context) []EditableChecker
 	GetOrder(id uint32) *Limit
+	GetStopOrder(id uint32) *StopOrder
+	GetStopOrdersCount(owner types.Address, coin0, coin1 types.CoinID) int
 	Export(state *types.AppState)
 	SwapPool(coin0, coin1 types.CoinID) (reserve0, reserve1 *big.Int, id uint32)
 	GetSwapper(coin0, coin1 types.CoinID) EditableChecker
@@ -104,37 +108,66 @@ type Swap struct {
 	muLoadPools sync.Mutex
 	loadedPools bool
 
+	stopOrders *stopOrders
+	expiration *orderExpiration
+
+	// priceOracle is non-zero after the accumulators of prices are enabled by the network update
+	priceOracle uint32
+
+	graph  *poolGraph
+	trades *tradeCache
 	trader trader
 }
 
 func (s *Swap) GetBestTradeExactIn(ctx context.Context, outId, inId uint64, inAmount *big.Int, maxHops int32) *Trade {
-	pairs := s.swapPools(ctx)
+	key := tradeKey{trader: s.trader, coinIn: types.CoinID(inId), coinOut: types.CoinID(outId), amount: inAmount.String(), exactIn: true, maxHops: maxHops}
+	trade, ok, version := s.trades.get(s.currentHeight(), key)
+	if ok {
+		return trade
+	}
+
+	pairs := s.routePools(ctx, types.CoinID(inId), types.CoinID(outId), maxHops)
 
 	s.muPairs.RLock()
 	defer s.muPairs.RUnlock()
 
-	return s.trader.GetBestTradeExactIn(ctx,
+	trade = s.trader.GetBestTradeExactIn(ctx,
 		pairs,
 		types.CoinID(outId),
 		NewTokenAmount(types.CoinID(inId), inAmount),
 		maxHops,
 	)
+	if ctx.Err() == nil {
+		s.trades.set(version, key, trade)
+	}
+	return trade
 }
 func (s *Swap) GetBestTradeExactOut(ctx context.Context, inId, outId uint64, outAmount *big.Int, maxHops int32) *Trade {
-	pairs := s.swapPools(ctx)
+	key := tradeKey{trader: s.trader, coinIn: types.CoinID(inId), coinOut: types.CoinID(outId), amount: outAmount.String(), maxHops: maxHops}
+	trade, ok, version := s.trades.get(s.currentHeight(), key)
+	if ok {
+		return trade
+	}
+
+	pairs := s.routePools(ctx, types.CoinID(inId), types.CoinID(outId), maxHops)
 
 	s.muPairs.RLock()
 	defer s.muPairs.RUnlock()
 
-	return s.trader.GetBestTradeExactOut(ctx,
+	trade = s.trader.GetBestTradeExactOut(ctx,
 		pairs,
 		types.CoinID(inId),
 		NewTokenAmount(types.CoinID(outId), outAmount),
 		maxHops,
 	)
+	if ctx.Err() == nil {
+		s.trades.set(version, key, trade)
+	}
+	return trade
 }
 
-func (s *Swap) swapPools(ctx context.Context) []EditableChecker {
+// routePools returns the pools which can be a part of a route between the coins not longer than maxHops
+func (s *Swap) routePools(ctx context.Context, coinIn, coinOut types.CoinID, maxHops int32) []EditableChecker {
 	s.loadPools()
 
 	select {
@@ -143,25 +176,20 @@ func (s *Swap) swapPools(ctx context.Context) []EditableChecker {
 	default:
 	}
 
-	pools := make([]EditableChecker, 0, len(s.pairs))
+	keys := s.graph.routePools(coinIn, coinOut, maxHops)
 
-	for _, pair := range s.pairs {
+	s.muPairs.RLock()
+	defer s.muPairs.RUnlock()
+
+	pools := make([]EditableChecker, 0, len(keys))
+	for _, key := range keys {
+		pair := s.pairs[key]
 		if pair == nil {
 			continue
 		}
 		pools = append(pools, pair)
-
-		select {
-		case <-ctx.Done():
-			return pools
-		default:
-		}
 	}
 
-	//sort.SliceStable(pools, func(i, j int) bool {
-	//	return pools[i].GetID() < pools[j].GetID()
-	//})
-
 	return pools
 }
 func (s *Swap) SwapPools(ctx context.Context) []EditableChecker {
@@ -206,6 +234,7 @@ func (s *Swap) loadPools() {
 		return
 	}
 
+	var keys []PairKey
 	s.immutableTree().IterateRange([]byte{mainPrefix, pairDataPrefix}, []byte{mainPrefix, pairDataPrefix + 1}, true, func(key []byte, value []byte) bool {
 		if len(key) < 10 {
 			return false
@@ -213,13 +242,32 @@ func (s *Swap) loadPools() {
 		coin0 := types.BytesToCoinID(key[2:6])
 		coin1 := types.BytesToCoinID(key[6:10])
 		_ = s.Pair(coin0, coin1)
+		keys = append(keys, PairKey{Coin0: coin0, Coin1: coin1})
 
 		return false
 	})
+	s.graph.addPools(keys)
 
 	s.loadedPools = true
 }
 
+// ExpireOrdersAtHeight removes orders which expiration height in the index is not greater than height
+func (s *Swap) ExpireOrdersAtHeight(height uint64) {
+	s.expireOrders(s.expiration.expired(height, s.loadOrder))
+}
+
+// EnableOrdersExpiration makes orders placed without custom expiration height expire after the period by the index
+func (s *Swap) EnableOrdersExpiration(period uint64) {
+	s.expiration.enable(period)
+}
+
+// MigrateOrdersExpiration makes the next commit index orders placed before the expiration index was enabled
+func (s *Swap) MigrateOrdersExpiration() {
+	s.expiration.migrate()
+}
+
+// ExpireOrders removes orders placed not later than beforeHeight by scanning orders by creation height,
+// it is used until all orders are expired by the index
 func (s *Swap) ExpireOrders(beforeHeight uint64) {
 	var orders []*Limit
 	s.immutableTree().IterateRange(pathOrder(0), pathOrder(math.MaxUint32), true, func(key []byte, value []byte) bool {
@@ -234,7 +282,7 @@ func (s *Swap) ExpireOrders(beforeHeight uint64) {
 			oldSortPrice: new(big.Float).SetPrec(Precision),
 			mu:           new(sync.RWMutex),
 		}
-		err := rlp.DecodeBytes(value, order)
+		err := order.decode(value)
 		if err != nil {
 			panic(err)
 		}
@@ -248,6 +296,10 @@ func (s *Swap) ExpireOrders(beforeHeight uint64) {
 		return false
 	})
 
+	s.expireOrders(orders)
+}
+
+func (s *Swap) expireOrders(orders []*Limit) {
 	for _, order := range orders {
 		//fmt.Println(order)
 		coin, volume := s.removeLimitOrder(order)
@@ -293,13 +345,32 @@ func (s *Swap) getOrderedDirtyOrderPairs() []PairKey {
 func New(bus *bus.Bus, db *iavl.ImmutableTree) *Swap {
 	immutableTree := atomic.Value{}
 	immutableTree.Store(db)
-	return &Swap{trader: &traderV2{}, pairs: map[PairKey]*Pair{}, bus: bus, db: immutableTree, dirties: map[PairKey]struct{}{}, dirtiesOrders: map[PairKey]struct{}{}}
+	s := &Swap{trader: &traderV2{}, pairs: map[PairKey]*Pair{}, bus: bus, db: immutableTree, dirties: map[PairKey]struct{}{}, dirtiesOrders: map[PairKey]struct{}{}}
+	s.stopOrders = newStopOrders(s.immutableTree)
+	s.expiration = newOrderExpiration(s.immutableTree)
+	s.graph = newPoolGraph()
+	s.trades = newTradeCache()
+	return s
 }
 
 func (s *Swap) immutableTree() *iavl.ImmutableTree {
 	return s.db.Load().(*iavl.ImmutableTree)
 }
 
+// currentHeight returns the height of the block being delivered on top of the last committed state
+func (s *Swap) currentHeight() uint64 {
+	return uint64(s.immutableTree().Version()) + 1
+}
+
+// EnablePriceOracle starts accumulation of prices of pools for time-weighted average prices
+func (s *Swap) EnablePriceOracle() {
+	atomic.StoreUint32(&s.priceOracle, 1)
+}
+
+func (s *Swap) isPriceOracleEnabled() bool {
+	return atomic.LoadUint32(&s.priceOracle) == 1
+}
+
 func (s *Swap) Export(state *types.AppState) {
 	s.immutableTree().IterateRange([]byte{mainPrefix, pairDataPrefix}, []byte{mainPrefix, pairDataPrefix + 1}, true, func(key []byte, value []byte) bool {
 		if len(key) < 10 {
@@ -327,6 +398,8 @@ func (s *Swap) Export(state *types.AppState) {
 				ID:      uint64(limit.id),
 				Owner:   limit.Owner,
 				Height:  limit.Height,
+
+				ExpireHeight: limit.ExpireHeight(),
 			})
 		}
 
@@ -338,6 +411,9 @@ func (s *Swap) Export(state *types.AppState) {
 			Reserve1: reserve1.String(),
 			ID:       uint64(pair.GetID()),
 			Orders:   orders,
+			SwapFee:  pair.SwapFee(),
+
+			StopOrders: s.stopOrders.export(key),
 		}
 
 		state.Pools = append(state.Pools, swap)
@@ -362,6 +438,9 @@ func (s *Swap) Import(state *types.AppState) {
 		pair.Reserve1.Set(reserve1)
 		s.bus.Checker().AddCoin(coin0, reserve0)
 		s.bus.Checker().AddCoin(coin1, reserve1)
+		if pool.SwapFee != 0 {
+			pair.setSwapFee(pool.SwapFee)
+		}
 		pair.markDirty()
 		s.incID()
 		for _, order := range pool.Orders {
@@ -373,9 +452,12 @@ func (s *Swap) Import(state *types.AppState) {
 				v0, v1 = v1, v0
 			}
 
-			pair0.addOrderWithID(v0, v1, order.Owner, uint32(order.ID), order.Height)
+			pair0.addOrderWithID(v0, v1, order.Owner, uint32(order.ID), order.Height, order.ExpireHeight)
 			s.bus.Checker().AddCoin(pair0.Coin1(), v1)
 		}
+		for _, order := range s.stopOrders.importOrders(pool) {
+			s.bus.Checker().AddCoin(order.CoinToSell, order.ValueToSell)
+		}
 	}
 	if state.NextOrderID > 1 {
 		s.nextOrderID = uint32(state.NextOrderID)
@@ -390,6 +472,7 @@ const pairLimitOrderPrefix = 'l'
 const pairOrdersPrefix = 'o'
 const totalPairIDPrefix = 'i'
 const totalOrdersIDPrefix = 'n'
+const orderExpirePrefix = 'x'
 
 type pairData struct {
 	mu        *sync.RWMutex
@@ -397,6 +480,9 @@ type pairData struct {
 	Reserve1  *big.Int
 	ID        *uint32
 	markDirty func()
+	oracle    *priceOracle
+	fee       uint32
+	dirtyFee  bool
 }
 
 func (pd *pairData) Reserves() (reserve0 *big.Int, reserve1 *big.Int) {
@@ -426,6 +512,8 @@ func (pd *pairData) reverse() *pairData {
 		Reserve1:  pd.Reserve0,
 		ID:        pd.ID,
 		markDirty: pd.markDirty,
+		oracle:    pd.oracle,
+		fee:       pd.fee,
 	}
 }
 
@@ -460,6 +548,7 @@ func (p *Pair) AddLastSwapStep(amount0In, amount1Out *big.Int) EditableChecker {
 			Reserve1:  reserve1.Sub(reserve1, amount1Out),
 			ID:        p.ID,
 			markDirty: func() {},
+			fee:       p.fee,
 		},
 		sellOrders:              p.sellOrders,
 		buyOrders:               p.buyOrders,
@@ -525,6 +614,12 @@ func pathOrder(id uint32) []byte {
 	return append([]byte{pairLimitOrderPrefix}, byteID...)
 }
 
+func pathOrderExpire(height uint64, id uint32) []byte {
+	byteHeight := make([]byte, 8)
+	binary.BigEndian.PutUint64(byteHeight, height)
+	return append(append([]byte{mainPrefix, orderExpirePrefix}, byteHeight...), id2Bytes(id)...)
+}
+
 func id2Bytes(id uint32) []byte {
 	byteID := make([]byte, 4)
 	binary.BigEndian.PutUint32(byteID, id)
@@ -621,10 +716,17 @@ func (s *Swap) Commit(db *iavl.MutableTree, version int64) error {
 	}
 	s.muNextOrdersID.Unlock()
 
+	if err := s.stopOrders.commit(db); err != nil {
+		return err
+	}
+	s.expiration.commit(db)
+
 	s.muPairs.RLock()
 	defer s.muPairs.RUnlock()
 
-	for _, key := range s.getOrderedDirtyPairs() {
+	dirties := s.getOrderedDirtyPairs()
+	s.graph.addPools(dirties)
+	for _, key := range dirties {
 		pair, _ := s.pair(key)
 		pairDataBytes, err := rlp.EncodeToBytes(pair.pairData)
 		if err != nil {
@@ -632,6 +734,12 @@ func (s *Swap) Commit(db *iavl.MutableTree, version int64) error {
 			return err
 		}
 		db.Set(append(basePath, key.pathData()...), pairDataBytes)
+		if err := pair.oracle.commit(db, key); err != nil {
+			return err
+		}
+		if err := pair.commitSwapFee(db, key); err != nil {
+			return err
+		}
 	}
 	s.dirties = map[PairKey]struct{}{}
 
@@ -658,6 +766,9 @@ func (s *Swap) Commit(db *iavl.MutableTree, version int64) error {
 				if limit.isEmpty() {
 					db.Remove(pathOrderID)
 					db.Remove(oldPathOrderList)
+					if expireHeight := limit.ExpireHeight(); expireHeight != 0 {
+						db.Remove(pathOrderExpire(expireHeight, limit.id))
+					}
 					continue
 				}
 
@@ -671,6 +782,9 @@ func (s *Swap) Commit(db *iavl.MutableTree, version int64) error {
 			}
 
 			db.Set(newPath, []byte{})
+			if expireHeight := limit.ExpireHeight(); expireHeight != 0 {
+				db.Set(pathOrderExpire(expireHeight, limit.id), []byte{})
+			}
 
 			pairOrderBytes, err := rlp.EncodeToBytes(limit)
 			if err != nil {
@@ -777,6 +891,8 @@ func (s *Swap) Pair(coin0, coin1 types.CoinID) *Pair {
 	if err != nil {
 		panic(err)
 	}
+	pair.oracle.load(s.immutableTree(), key.sort())
+	pair.loadSwapFee(s.immutableTree(), key.sort())
 
 	if !key.isSorted() {
 		return pair.reverse()
@@ -830,6 +946,8 @@ func (s *Swap) PairCreate(coin0, coin1 types.CoinID, amount0, amount1 *big.Int)
 	pair := s.ReturnPair(coin0, coin1)
 	id := s.incID()
 	*pair.ID = id
+	s.graph.addPools([]PairKey{pair.PairKey})
+	s.trades.drop()
 	oldReserve0, oldReserve1 := pair.Reserves()
 	liquidity := pair.Create(amount0, amount1)
 	newReserve0, newReserve1 := pair.Reserves()
@@ -935,6 +1053,7 @@ func (s *Swap) markDirty(key PairKey) func() {
 		s.muPairs.Lock()
 		defer s.muPairs.Unlock()
 		s.dirties[key] = struct{}{}
+		s.trades.drop()
 	}
 }
 func (s *Swap) markDirtyOrders(key PairKey) func() {
@@ -942,6 +1061,7 @@ func (s *Swap) markDirtyOrders(key PairKey) func() {
 		s.muPairs.Lock()
 		defer s.muPairs.Unlock()
 		s.dirtiesOrders[key] = struct{}{}
+		s.trades.drop()
 	}
 }
 
@@ -958,6 +1078,7 @@ func (s *Swap) addPair(key PairKey) *Pair {
 			Reserve1:  big.NewInt(0),
 			ID:        new(uint32),
 			markDirty: s.markDirty(key),
+			oracle:    newPriceOracle(s.currentHeight, s.isPriceOracleEnabled),
 		},
 		sellOrders:              &limits{},
 		buyOrders:               &limits{},
@@ -1161,9 +1282,10 @@ func (p *Pair) CalculateBuyForSellAllowNeg(amount0In *big.Int) (amount1Out *big.
 	}
 
 	reserve0, reserve1 := p.Reserves()
-	kAdjusted := new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), big.NewInt(1000000))
-	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0In, reserve0), big.NewInt(1000)), new(big.Int).Mul(amount0In, big.NewInt(commission)))
-	amount1Out = new(big.Int).Sub(reserve1, new(big.Int).Quo(kAdjusted, new(big.Int).Mul(balance0Adjusted, big.NewInt(1000))))
+	fee, denominator := p.swapFee()
+	kAdjusted := new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), new(big.Int).Mul(denominator, denominator))
+	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0In, reserve0), denominator), new(big.Int).Mul(amount0In, fee))
+	amount1Out = new(big.Int).Sub(reserve1, new(big.Int).Quo(kAdjusted, new(big.Int).Mul(balance0Adjusted, denominator)))
 	amount1Out = new(big.Int).Sub(amount1Out, big.NewInt(1))
 	if amount1Out.Sign() != 1 {
 		return nil
@@ -1175,9 +1297,10 @@ func (p *Pair) CalculateBuyForSellAllowNeg(amount0In *big.Int) (amount1Out *big.
 // reserve1-(reserve0*reserve1)/((amount0+reserve0)-amount0*0.002)
 func (p *Pair) CalculateBuyForSell(amount0In *big.Int) (amount1Out *big.Int) {
 	reserve0, reserve1 := p.Reserves()
-	kAdjusted := new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), big.NewInt(1000000))
-	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0In, reserve0), big.NewInt(1000)), new(big.Int).Mul(amount0In, big.NewInt(commission)))
-	amount1Out = new(big.Int).Sub(reserve1, new(big.Int).Quo(kAdjusted, new(big.Int).Mul(balance0Adjusted, big.NewInt(1000))))
+	fee, denominator := p.swapFee()
+	kAdjusted := new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), new(big.Int).Mul(denominator, denominator))
+	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0In, reserve0), denominator), new(big.Int).Mul(amount0In, fee))
+	amount1Out = new(big.Int).Sub(reserve1, new(big.Int).Quo(kAdjusted, new(big.Int).Mul(balance0Adjusted, denominator)))
 	amount1Out = new(big.Int).Sub(amount1Out, big.NewInt(1))
 	if amount1Out.Sign() != 1 {
 		return nil
@@ -1194,19 +1317,21 @@ func (p *Pair) CalculateSellForBuyAllowNeg(amount1Out *big.Int) (amount0In *big.
 	}
 
 	reserve0, reserve1 := p.Reserves()
+	fee, denominator := p.swapFee()
 	k := new(big.Int).Mul(reserve0, reserve1)
 	if amount1Out.Cmp(reserve1) != -1 {
 		return nil
 	}
-	kAdjusted := new(big.Int).Mul(k, big.NewInt(1000000))
-	balance1Adjusted := new(big.Int).Mul(new(big.Int).Add(new(big.Int).Neg(amount1Out), reserve1), big.NewInt(1000))
-	amount0In = new(big.Int).Quo(new(big.Int).Sub(new(big.Int).Quo(kAdjusted, balance1Adjusted), new(big.Int).Mul(reserve0, big.NewInt(1000))), big.NewInt(1000-commission))
+	kAdjusted := new(big.Int).Mul(k, new(big.Int).Mul(denominator, denominator))
+	balance1Adjusted := new(big.Int).Mul(new(big.Int).Add(new(big.Int).Neg(amount1Out), reserve1), denominator)
+	amount0In = new(big.Int).Quo(new(big.Int).Sub(new(big.Int).Quo(kAdjusted, balance1Adjusted), new(big.Int).Mul(reserve0, denominator)), new(big.Int).Sub(denominator, fee))
 	return new(big.Int).Add(amount0In, big.NewInt(1))
 }
 
 // (reserve0*reserve1/(reserve1-amount1)-reserve0)/0.998
 func (p *Pair) CalculateSellForBuy(amount1Out *big.Int) (amount0In *big.Int) {
 	reserve0, reserve1 := p.Reserves()
+	fee, denominator := p.swapFee()
 	if amount1Out.Cmp(reserve1) == 1 {
 		return nil
 	}
@@ -1214,9 +1339,9 @@ func (p *Pair) CalculateSellForBuy(amount1Out *big.Int) (amount0In *big.Int) {
 	if amount1Out.Cmp(reserve1) != -1 {
 		return nil
 	}
-	kAdjusted := new(big.Int).Mul(k, big.NewInt(1000000))
-	balance1Adjusted := new(big.Int).Mul(new(big.Int).Add(new(big.Int).Neg(amount1Out), reserve1), big.NewInt(1000))
-	amount0In = new(big.Int).Quo(new(big.Int).Sub(new(big.Int).Quo(kAdjusted, balance1Adjusted), new(big.Int).Mul(reserve0, big.NewInt(1000))), big.NewInt(1000-commission))
+	kAdjusted := new(big.Int).Mul(k, new(big.Int).Mul(denominator, denominator))
+	balance1Adjusted := new(big.Int).Mul(new(big.Int).Add(new(big.Int).Neg(amount1Out), reserve1), denominator)
+	amount0In = new(big.Int).Quo(new(big.Int).Sub(new(big.Int).Quo(kAdjusted, balance1Adjusted), new(big.Int).Mul(reserve0, denominator)), new(big.Int).Sub(denominator, fee))
 	return new(big.Int).Add(amount0In, big.NewInt(1))
 }
 
@@ -1227,6 +1352,7 @@ func (p *Pair) Swap(amount0In, amount1In, amount0Out, amount1Out *big.Int) (amou
 	}
 
 	reserve0, reserve1 := p.Reserves()
+	fee, denominator := p.swapFee()
 
 	if amount0Out.Cmp(reserve0) == 1 || amount1Out.Cmp(reserve1) == 1 {
 		panic(ErrorInsufficientLiquidity)
@@ -1239,10 +1365,10 @@ func (p *Pair) Swap(amount0In, amount1In, amount0Out, amount1Out *big.Int) (amou
 		panic(ErrorInsufficientInputAmount)
 	}
 
-	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0, reserve0), big.NewInt(1000)), new(big.Int).Mul(amount0In, big.NewInt(commission)))
-	balance1Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount1, reserve1), big.NewInt(1000)), new(big.Int).Mul(amount1In, big.NewInt(commission)))
+	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0, reserve0), denominator), new(big.Int).Mul(amount0In, fee))
+	balance1Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount1, reserve1), denominator), new(big.Int).Mul(amount1In, fee))
 
-	if new(big.Int).Mul(balance0Adjusted, balance1Adjusted).Cmp(new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), big.NewInt(1000000))) == -1 {
+	if new(big.Int).Mul(balance0Adjusted, balance1Adjusted).Cmp(new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), new(big.Int).Mul(denominator, denominator))) == -1 {
 		panic(ErrorK)
 	}
 
@@ -1253,6 +1379,7 @@ func (p *Pair) Swap(amount0In, amount1In, amount0Out, amount1Out *big.Int) (amou
 
 func (p *Pair) checkSwap(amount0In, amount1In, amount0Out, amount1Out *big.Int) (err error) {
 	reserve0, reserve1 := p.Reserves()
+	fee, denominator := p.swapFee()
 	if amount0Out.Cmp(reserve0) == 1 || amount1Out.Cmp(reserve1) == 1 {
 		return ErrorInsufficientLiquidity
 	}
@@ -1268,10 +1395,10 @@ func (p *Pair) checkSwap(amount0In, amount1In, amount0Out, amount1Out *big.Int)
 		return ErrorInsufficientInputAmount
 	}
 
-	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0, reserve0), big.NewInt(1000)), new(big.Int).Mul(amount0In, big.NewInt(commission)))
-	balance1Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount1, reserve1), big.NewInt(1000)), new(big.Int).Mul(amount1In, big.NewInt(commission)))
+	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0, reserve0), denominator), new(big.Int).Mul(amount0In, fee))
+	balance1Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount1, reserve1), denominator), new(big.Int).Mul(amount1In, fee))
 
-	if new(big.Int).Mul(balance0Adjusted, balance1Adjusted).Cmp(new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), big.NewInt(1000000))) == -1 {
+	if new(big.Int).Mul(balance0Adjusted, balance1Adjusted).Cmp(new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), new(big.Int).Mul(denominator, denominator))) == -1 {
 		return ErrorK
 	}
 	return nil
@@ -1283,6 +1410,8 @@ func (p *Pair) update(amount0, amount1 *big.Int) {
 	p.pairData.mu.Lock()
 	defer p.pairData.mu.Unlock()
 
+	p.accumulatePrice(p.isSorted())
+
 	p.Reserve0.Add(p.Reserve0, amount0)
 	p.Reserve1.Add(p.Reserve1, amount1)
 }
@@ -47,24 +47,52 @@ type SwapV2 struct {
 	muLoadPools sync.Mutex
 	loadedPools bool
 
+	stopOrders *stopOrders
+	expiration *orderExpiration
+
+	// priceOracle is non-zero after the accumulators of prices are enabled by the network update
+	priceOracle uint32
+
+	graph  *poolGraph
+	trades *tradeCache
 	trader trader
 }
 
 func (s *SwapV2) GetBestTradeExactIn(ctx context.Context, outId, inId uint64, inAmount *big.Int, maxHops int32) *Trade {
-	pairs := s.swapPools(ctx)
+	key := tradeKey{trader: s.trader, coinIn: types.CoinID(inId), coinOut: types.CoinID(outId), amount: inAmount.String(), exactIn: true, maxHops: maxHops}
+	trade, ok, version := s.trades.get(s.currentHeight(), key)
+	if ok {
+		return trade
+	}
+
+	pairs := s.routePools(ctx, types.CoinID(inId), types.CoinID(outId), maxHops)
 
 	s.muPairs.RLock()
 	defer s.muPairs.RUnlock()
 
-	return s.trader.GetBestTradeExactIn(ctx, pairs, types.CoinID(outId), NewTokenAmount(types.CoinID(inId), inAmount), maxHops)
+	trade = s.trader.GetBestTradeExactIn(ctx, pairs, types.CoinID(outId), NewTokenAmount(types.CoinID(inId), inAmount), maxHops)
+	if ctx.Err() == nil {
+		s.trades.set(version, key, trade)
+	}
+	return trade
 }
 func (s *SwapV2) GetBestTradeExactOut(ctx context.Context, inId, outId uint64, outAmount *big.Int, maxHops int32) *Trade {
-	pairs := s.swapPools(ctx)
+	key := tradeKey{trader: s.trader, coinIn: types.CoinID(inId), coinOut: types.CoinID(outId), amount: outAmount.String(), maxHops: maxHops}
+	trade, ok, version := s.trades.get(s.currentHeight(), key)
+	if ok {
+		return trade
+	}
+
+	pairs := s.routePools(ctx, types.CoinID(inId), types.CoinID(outId), maxHops)
 
 	s.muPairs.RLock()
 	defer s.muPairs.RUnlock()
 
-	return s.trader.GetBestTradeExactOut(ctx, pairs, types.CoinID(inId), NewTokenAmount(types.CoinID(outId), outAmount), maxHops)
+	trade = s.trader.GetBestTradeExactOut(ctx, pairs, types.CoinID(inId), NewTokenAmount(types.CoinID(outId), outAmount), maxHops)
+	if ctx.Err() == nil {
+		s.trades.set(version, key, trade)
+	}
+	return trade
 }
 
 func (p *PairV2) GetPairKey() PairKey {
@@ -77,7 +105,8 @@ func (p *PairV2) Coin1() types.CoinID {
 	return p.PairKey.Coin1
 }
 
-func (s *SwapV2) swapPools(ctx context.Context) []EditableChecker {
+// routePools returns the pools which can be a part of a route between the coins not longer than maxHops
+func (s *SwapV2) routePools(ctx context.Context, coinIn, coinOut types.CoinID, maxHops int32) []EditableChecker {
 	s.loadPools()
 
 	select {
@@ -86,25 +115,20 @@ func (s *SwapV2) swapPools(ctx context.Context) []EditableChecker {
 	default:
 	}
 
-	pools := make([]EditableChecker, 0, len(s.pairs))
+	keys := s.graph.routePools(coinIn, coinOut, maxHops)
 
-	for _, pair := range s.pairs {
+	s.muPairs.RLock()
+	defer s.muPairs.RUnlock()
+
+	pools := make([]EditableChecker, 0, len(keys))
+	for _, key := range keys {
+		pair := s.pairs[key]
 		if pair == nil {
 			continue
 		}
 		pools = append(pools, pair)
-
-		select {
-		case <-ctx.Done():
-			return pools
-		default:
-		}
 	}
 
-	//sort.SliceStable(pools, func(i, j int) bool {
-	//	return pools[i].GetID() < pools[j].GetID()
-	//})
-
 	return pools
 }
 func (s *SwapV2) SwapPools(ctx context.Context) []EditableChecker {
@@ -149,6 +173,7 @@ func (s *SwapV2) loadPools() {
 		return
 	}
 
+	var keys []PairKey
 	s.immutableTree().IterateRange([]byte{mainPrefix, pairDataPrefix}, []byte{mainPrefix, pairDataPrefix + 1}, true, func(key []byte, value []byte) bool {
 		if len(key) < 10 {
 			return false
@@ -156,13 +181,32 @@ func (s *SwapV2) loadPools() {
 		coin0 := types.BytesToCoinID(key[2:6])
 		coin1 := types.BytesToCoinID(key[6:10])
 		_ = s.Pair(coin0, coin1)
+		keys = append(keys, PairKey{Coin0: coin0, Coin1: coin1})
 
 		return false
 	})
+	s.graph.addPools(keys)
 
 	s.loadedPools = true
 }
 
+// ExpireOrdersAtHeight removes orders which expiration height in the index is not greater than height
+func (s *SwapV2) ExpireOrdersAtHeight(height uint64) {
+	s.expireOrders(s.expiration.expired(height, s.loadOrder))
+}
+
+// EnableOrdersExpiration makes orders placed without custom expiration height expire after the period by the index
+func (s *SwapV2) EnableOrdersExpiration(period uint64) {
+	s.expiration.enable(period)
+}
+
+// MigrateOrdersExpiration makes the next commit index orders placed before the expiration index was enabled
+func (s *SwapV2) MigrateOrdersExpiration() {
+	s.expiration.migrate()
+}
+
+// ExpireOrders removes orders placed not later than beforeHeight by scanning orders by creation height,
+// it is used until all orders are expired by the index
 func (s *SwapV2) ExpireOrders(beforeHeight uint64) {
 	var orders []*Limit
 	s.immutableTree().IterateRange(pathOrder(0), pathOrder(math.MaxUint32), true, func(key []byte, value []byte) bool {
@@ -177,7 +221,7 @@ func (s *SwapV2) ExpireOrders(beforeHeight uint64) {
 			oldSortPrice: new(big.Float).SetPrec(Precision),
 			mu:           new(sync.RWMutex),
 		}
-		err := rlp.DecodeBytes(value, order)
+		err := order.decode(value)
 		if err != nil {
 			panic(err)
 		}
@@ -191,6 +235,10 @@ func (s *SwapV2) ExpireOrders(beforeHeight uint64) {
 		return false
 	})
 
+	s.expireOrders(orders)
+}
+
+func (s *SwapV2) expireOrders(orders []*Limit) {
 	for _, order := range orders {
 		//fmt.Println(order)
 		coin, volume := s.removeLimitOrder(order)
@@ -236,13 +284,32 @@ func (s *SwapV2) getOrderedDirtyOrderPairs() []PairKey {
 func NewV2(bus *bus.Bus, db *iavl.ImmutableTree) *SwapV2 {
 	immutableTree := atomic.Value{}
 	immutableTree.Store(db)
-	return &SwapV2{trader: &traderV2{}, pairs: map[PairKey]*PairV2{}, bus: bus, db: immutableTree, dirties: map[PairKey]struct{}{}, dirtiesOrders: map[PairKey]struct{}{}}
+	s := &SwapV2{trader: &traderV2{}, pairs: map[PairKey]*PairV2{}, bus: bus, db: immutableTree, dirties: map[PairKey]struct{}{}, dirtiesOrders: map[PairKey]struct{}{}}
+	s.stopOrders = newStopOrders(s.immutableTree)
+	s.expiration = newOrderExpiration(s.immutableTree)
+	s.graph = newPoolGraph()
+	s.trades = newTradeCache()
+	return s
 }
 
 func (s *SwapV2) immutableTree() *iavl.ImmutableTree {
 	return s.db.Load().(*iavl.ImmutableTree)
 }
 
+// currentHeight returns the height of the block being delivered on top of the last committed state
+func (s *SwapV2) currentHeight() uint64 {
+	return uint64(s.immutableTree().Version()) + 1
+}
+
+// EnablePriceOracle starts accumulation of prices of pools for time-weighted average prices
+func (s *SwapV2) EnablePriceOracle() {
+	atomic.StoreUint32(&s.priceOracle, 1)
+}
+
+func (s *SwapV2) isPriceOracleEnabled() bool {
+	return atomic.LoadUint32(&s.priceOracle) == 1
+}
+
 func (s *SwapV2) Export(state *types.AppState) {
 	s.immutableTree().IterateRange([]byte{mainPrefix, pairDataPrefix}, []byte{mainPrefix, pairDataPrefix + 1}, true, func(key []byte, value []byte) bool {
 		if len(key) < 10 {
@@ -270,6 +337,8 @@ func (s *SwapV2) Export(state *types.AppState) {
 				ID:      uint64(limit.id),
 				Owner:   limit.Owner,
 				Height:  limit.Height,
+
+				ExpireHeight: limit.ExpireHeight(),
 			})
 		}
 
@@ -281,6 +350,9 @@ func (s *SwapV2) Export(state *types.AppState) {
 			Reserve1: reserve1.String(),
 			ID:       uint64(pair.GetID()),
 			Orders:   orders,
+			SwapFee:  pair.SwapFee(),
+
+			StopOrders: s.stopOrders.export(key),
 		}
 
 		state.Pools = append(state.Pools, swap)
@@ -305,6 +377,9 @@ func (s *SwapV2) Import(state *types.AppState) {
 		pair.Reserve1.Set(reserve1)
 		s.bus.Checker().AddCoin(coin0, reserve0)
 		s.bus.Checker().AddCoin(coin1, reserve1)
+		if pool.SwapFee != 0 {
+			pair.setSwapFee(pool.SwapFee)
+		}
 		pair.markDirty()
 		s.incID()
 		for _, order := range pool.Orders {
@@ -316,9 +391,12 @@ func (s *SwapV2) Import(state *types.AppState) {
 				v0, v1 = v1, v0
 			}
 
-			pair0.addOrderWithID(v0, v1, order.Owner, uint32(order.ID), order.Height)
+			pair0.addOrderWithID(v0, v1, order.Owner, uint32(order.ID), order.Height, order.ExpireHeight)
 			s.bus.Checker().AddCoin(pair0.Coin1(), v1)
 		}
+		for _, order := range s.stopOrders.importOrders(pool) {
+			s.bus.Checker().AddCoin(order.CoinToSell, order.ValueToSell)
+		}
 	}
 	if state.NextOrderID > 1 {
 		s.nextOrderID = uint32(state.NextOrderID)
@@ -346,6 +424,7 @@ func (p *PairV2) AddLastSwapStep(amount0In, amount1Out *big.Int) EditableChecker
 			Reserve1:  reserve1.Sub(reserve1, amount1Out),
 			ID:        p.ID,
 			markDirty: func() {},
+			fee:       p.fee,
 		},
 		sellOrders:              p.sellOrders,
 		buyOrders:               p.buyOrders,
@@ -422,10 +501,17 @@ func (s *SwapV2) Commit(db *iavl.MutableTree, version int64) error {
 	}
 	s.muNextOrdersID.Unlock()
 
+	if err := s.stopOrders.commit(db); err != nil {
+		return err
+	}
+	s.expiration.commit(db)
+
 	s.muPairs.RLock()
 	defer s.muPairs.RUnlock()
 
-	for _, key := range s.getOrderedDirtyPairs() {
+	dirties := s.getOrderedDirtyPairs()
+	s.graph.addPools(dirties)
+	for _, key := range dirties {
 		pair, _ := s.pair(key)
 		pairDataBytes, err := rlp.EncodeToBytes(pair.pairData)
 		if err != nil {
@@ -433,6 +519,12 @@ func (s *SwapV2) Commit(db *iavl.MutableTree, version int64) error {
 			return err
 		}
 		db.Set(append(basePath, key.pathData()...), pairDataBytes)
+		if err := pair.oracle.commit(db, key); err != nil {
+			return err
+		}
+		if err := pair.commitSwapFee(db, key); err != nil {
+			return err
+		}
 	}
 	s.dirties = map[PairKey]struct{}{}
 
@@ -459,6 +551,9 @@ func (s *SwapV2) Commit(db *iavl.MutableTree, version int64) error {
 				if limit.isEmpty() {
 					db.Remove(pathOrderID)
 					db.Remove(oldPathOrderList)
+					if expireHeight := limit.ExpireHeight(); expireHeight != 0 {
+						db.Remove(pathOrderExpire(expireHeight, limit.id))
+					}
 					continue
 				}
 
@@ -472,6 +567,9 @@ func (s *SwapV2) Commit(db *iavl.MutableTree, version int64) error {
 			}
 
 			db.Set(newPath, []byte{})
+			if expireHeight := limit.ExpireHeight(); expireHeight != 0 {
+				db.Set(pathOrderExpire(expireHeight, limit.id), []byte{})
+			}
 
 			pairOrderBytes, err := rlp.EncodeToBytes(limit)
 			if err != nil {
@@ -576,6 +674,8 @@ func (s *SwapV2) Pair(coin0, coin1 types.CoinID) *PairV2 {
 	if err != nil {
 		panic(err)
 	}
+	pair.oracle.load(s.immutableTree(), key.sort())
+	pair.loadSwapFee(s.immutableTree(), key.sort())
 
 	if !key.isSorted() {
 		return pair.reverse()
@@ -629,6 +729,8 @@ func (s *SwapV2) PairCreate(coin0, coin1 types.CoinID, amount0, amount1 *big.Int
 	pair := s.ReturnPair(coin0, coin1)
 	id := s.incID()
 	*pair.ID = id
+	s.graph.addPools([]PairKey{pair.PairKey})
+	s.trades.drop()
 	oldReserve0, oldReserve1 := pair.Reserves()
 	liquidity := pair.Create(amount0, amount1)
 	newReserve0, newReserve1 := pair.Reserves()
@@ -711,6 +813,7 @@ func (s *SwapV2) markDirty(key PairKey) func() {
 		s.muPairs.Lock()
 		defer s.muPairs.Unlock()
 		s.dirties[key] = struct{}{}
+		s.trades.drop()
 	}
 }
 func (s *SwapV2) markDirtyOrders(key PairKey) func() {
@@ -718,6 +821,7 @@ func (s *SwapV2) markDirtyOrders(key PairKey) func() {
 		s.muPairs.Lock()
 		defer s.muPairs.Unlock()
 		s.dirtiesOrders[key] = struct{}{}
+		s.trades.drop()
 	}
 }
 
@@ -734,6 +838,7 @@ func (s *SwapV2) addPair(key PairKey) *PairV2 {
 			Reserve1:  big.NewInt(0),
 			ID:        new(uint32),
 			markDirty: s.markDirty(key),
+			oracle:    newPriceOracle(s.currentHeight, s.isPriceOracleEnabled),
 		},
 		sellOrders:              &limits{},
 		buyOrders:               &limits{},
@@ -915,9 +1020,10 @@ func (p *PairV2) CalculateBuyForSellAllowNeg(amount0In *big.Int) (amount1Out *bi
 	}
 
 	reserve0, reserve1 := p.Reserves()
-	kAdjusted := new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), big.NewInt(1000000))
-	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0In, reserve0), big.NewInt(1000)), new(big.Int).Mul(amount0In, big.NewInt(commission)))
-	amount1Out = new(big.Int).Sub(reserve1, new(big.Int).Quo(kAdjusted, new(big.Int).Mul(balance0Adjusted, big.NewInt(1000))))
+	fee, denominator := p.swapFee()
+	kAdjusted := new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), new(big.Int).Mul(denominator, denominator))
+	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0In, reserve0), denominator), new(big.Int).Mul(amount0In, fee))
+	amount1Out = new(big.Int).Sub(reserve1, new(big.Int).Quo(kAdjusted, new(big.Int).Mul(balance0Adjusted, denominator)))
 	amount1Out = new(big.Int).Sub(amount1Out, big.NewInt(1))
 	if amount1Out.Sign() != 1 {
 		return nil
@@ -929,9 +1035,10 @@ func (p *PairV2) CalculateBuyForSellAllowNeg(amount0In *big.Int) (amount1Out *bi
 // reserve1-(reserve0*reserve1)/((amount0+reserve0)-amount0*0.002)
 func (p *PairV2) CalculateBuyForSell(amount0In *big.Int) (amount1Out *big.Int) {
 	reserve0, reserve1 := p.Reserves()
-	kAdjusted := new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), big.NewInt(1000000))
-	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0In, reserve0), big.NewInt(1000)), new(big.Int).Mul(amount0In, big.NewInt(commission)))
-	amount1Out = new(big.Int).Sub(reserve1, new(big.Int).Quo(kAdjusted, new(big.Int).Mul(balance0Adjusted, big.NewInt(1000))))
+	fee, denominator := p.swapFee()
+	kAdjusted := new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), new(big.Int).Mul(denominator, denominator))
+	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0In, reserve0), denominator), new(big.Int).Mul(amount0In, fee))
+	amount1Out = new(big.Int).Sub(reserve1, new(big.Int).Quo(kAdjusted, new(big.Int).Mul(balance0Adjusted, denominator)))
 	amount1Out = new(big.Int).Sub(amount1Out, big.NewInt(1))
 	if amount1Out.Sign() != 1 {
 		return nil
@@ -948,19 +1055,21 @@ func (p *PairV2) CalculateSellForBuyAllowNeg(amount1Out *big.Int) (amount0In *bi
 	}
 
 	reserve0, reserve1 := p.Reserves()
+	fee, denominator := p.swapFee()
 	k := new(big.Int).Mul(reserve0, reserve1)
 	if amount1Out.Cmp(reserve1) != -1 {
 		return nil
 	}
-	kAdjusted := new(big.Int).Mul(k, big.NewInt(1000000))
-	balance1Adjusted := new(big.Int).Mul(new(big.Int).Add(new(big.Int).Neg(amount1Out), reserve1), big.NewInt(1000))
-	amount0In = new(big.Int).Quo(new(big.Int).Sub(new(big.Int).Quo(kAdjusted, balance1Adjusted), new(big.Int).Mul(reserve0, big.NewInt(1000))), big.NewInt(1000-commission))
+	kAdjusted := new(big.Int).Mul(k, new(big.Int).Mul(denominator, denominator))
+	balance1Adjusted := new(big.Int).Mul(new(big.Int).Add(new(big.Int).Neg(amount1Out), reserve1), denominator)
+	amount0In = new(big.Int).Quo(new(big.Int).Sub(new(big.Int).Quo(kAdjusted, balance1Adjusted), new(big.Int).Mul(reserve0, denominator)), new(big.Int).Sub(denominator, fee))
 	return new(big.Int).Add(amount0In, big.NewInt(1))
 }
 
 // (reserve0*reserve1/(reserve1-amount1)-reserve0)/0.998
 func (p *PairV2) CalculateSellForBuy(amount1Out *big.Int) (amount0In *big.Int) {
 	reserve0, reserve1 := p.Reserves()
+	fee, denominator := p.swapFee()
 	if amount1Out.Cmp(reserve1) == 1 {
 		return nil
 	}
@@ -968,9 +1077,9 @@ func (p *PairV2) CalculateSellForBuy(amount1Out *big.Int) (amount0In *big.Int) {
 	if amount1Out.Cmp(reserve1) != -1 {
 		return nil
 	}
-	kAdjusted := new(big.Int).Mul(k, big.NewInt(1000000))
-	balance1Adjusted := new(big.Int).Mul(new(big.Int).Add(new(big.Int).Neg(amount1Out), reserve1), big.NewInt(1000))
-	amount0In = new(big.Int).Quo(new(big.Int).Sub(new(big.Int).Quo(kAdjusted, balance1Adjusted), new(big.Int).Mul(reserve0, big.NewInt(1000))), big.NewInt(1000-commission))
+	kAdjusted := new(big.Int).Mul(k, new(big.Int).Mul(denominator, denominator))
+	balance1Adjusted := new(big.Int).Mul(new(big.Int).Add(new(big.Int).Neg(amount1Out), reserve1), denominator)
+	amount0In = new(big.Int).Quo(new(big.Int).Sub(new(big.Int).Quo(kAdjusted, balance1Adjusted), new(big.Int).Mul(reserve0, denominator)), new(big.Int).Sub(denominator, fee))
 	return new(big.Int).Add(amount0In, big.NewInt(1))
 }
 
@@ -981,6 +1090,7 @@ func (p *PairV2) Swap(amount0In, amount1In, amount0Out, amount1Out *big.Int) (am
 	}
 
 	reserve0, reserve1 := p.Reserves()
+	fee, denominator := p.swapFee()
 
 	if amount0Out.Cmp(reserve0) == 1 || amount1Out.Cmp(reserve1) == 1 {
 		panic(ErrorInsufficientLiquidity)
@@ -993,10 +1103,10 @@ func (p *PairV2) Swap(amount0In, amount1In, amount0Out, amount1Out *big.Int) (am
 		panic(ErrorInsufficientInputAmount)
 	}
 
-	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0, reserve0), big.NewInt(1000)), new(big.Int).Mul(amount0In, big.NewInt(commission)))
-	balance1Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount1, reserve1), big.NewInt(1000)), new(big.Int).Mul(amount1In, big.NewInt(commission)))
+	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0, reserve0), denominator), new(big.Int).Mul(amount0In, fee))
+	balance1Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount1, reserve1), denominator), new(big.Int).Mul(amount1In, fee))
 
-	if new(big.Int).Mul(balance0Adjusted, balance1Adjusted).Cmp(new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), big.NewInt(1000000))) == -1 {
+	if new(big.Int).Mul(balance0Adjusted, balance1Adjusted).Cmp(new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), new(big.Int).Mul(denominator, denominator))) == -1 {
 		panic(ErrorK)
 	}
 
@@ -1007,6 +1117,7 @@ func (p *PairV2) Swap(amount0In, amount1In, amount0Out, amount1Out *big.Int) (am
 
 func (p *PairV2) checkSwap(amount0In, amount1In, amount0Out, amount1Out *big.Int) (err error) {
 	reserve0, reserve1 := p.Reserves()
+	fee, denominator := p.swapFee()
 	if amount0Out.Cmp(reserve0) == 1 || amount1Out.Cmp(reserve1) == 1 {
 		return ErrorInsufficientLiquidity
 	}
@@ -1022,10 +1133,10 @@ func (p *PairV2) checkSwap(amount0In, amount1In, amount0Out, amount1Out *big.Int
 		return ErrorInsufficientInputAmount
 	}
 
-	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0, reserve0), big.NewInt(1000)), new(big.Int).Mul(amount0In, big.NewInt(commission)))
-	balance1Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount1, reserve1), big.NewInt(1000)), new(big.Int).Mul(amount1In, big.NewInt(commission)))
+	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount0, reserve0), denominator), new(big.Int).Mul(amount0In, fee))
+	balance1Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount1, reserve1), denominator), new(big.Int).Mul(amount1In, fee))
 
-	if new(big.Int).Mul(balance0Adjusted, balance1Adjusted).Cmp(new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), big.NewInt(1000000))) == -1 {
+	if new(big.Int).Mul(balance0Adjusted, balance1Adjusted).Cmp(new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), new(big.Int).Mul(denominator, denominator))) == -1 {
 		return ErrorK
 	}
 	return nil
@@ -1037,6 +1148,8 @@ func (p *PairV2) update(amount0, amount1 *big.Int) {
 	p.pairData.mu.Lock()
 	defer p.pairData.mu.Unlock()
 
+	p.accumulatePrice(p.isSorted())
+
 	p.Reserve0.Add(p.Reserve0, amount0)
 	p.Reserve1.Add(p.Reserve1, amount1)
 }
@@ -1 +1,130 @@
 package swap
+
+import (
+	"math/big"
+	"testing"
+
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/accounts"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/bus"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/checker"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestPairV2_PriceCumulative(t *testing.T) {
+	memDB := db.NewMemDB()
+	immutableTree, err := tree.NewMutableTree(0, memDB, 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newBus := bus.NewBus()
+	checker.NewChecker(newBus)
+	accounts.NewBus(accounts.NewAccounts(newBus, immutableTree.GetLastImmutable()))
+	newBus.SetEvents(&eventsdb.MockEvents{})
+
+	swap := NewV2(newBus, immutableTree.GetLastImmutable())
+	swap.EnablePriceOracle()
+	_, _, _, _ = swap.PairCreate(0, 1, helpers.BipToPip(big.NewInt(1000)), helpers.BipToPip(big.NewInt(1000)))
+	for i := 0; i < 3; i++ {
+		if _, _, err = immutableTree.Commit(swap); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	one := new(big.Int).Lsh(big.NewInt(1), priceResolution)
+	fromPrice0, fromPrice1, fromHeight := swap.Pair(0, 1).PriceCumulative()
+	if fromHeight != 4 || fromPrice0.Cmp(new(big.Int).Mul(one, big.NewInt(3))) != 0 || fromPrice0.Cmp(fromPrice1) != 0 {
+		t.Fatalf("wrong accumulators %s %s at %d", fromPrice0, fromPrice1, fromHeight)
+	}
+
+	_, _, _, _, _ = swap.PairSellWithOrders(0, 1, helpers.BipToPip(big.NewInt(1000)), big.NewInt(0))
+	if price0, _, _ := swap.Pair(0, 1).PriceCumulative(); price0.Cmp(fromPrice0) != 0 {
+		t.Fatal("swap changed the price of its own block")
+	}
+	_, _, _, _, _ = swap.PairSellWithOrders(1, 0, helpers.BipToPip(big.NewInt(100)), big.NewInt(0))
+	if _, _, err = immutableTree.Commit(swap); err != nil {
+		t.Fatal(err)
+	}
+
+	swap = NewV2(newBus, immutableTree.GetLastImmutable())
+	reserve0, reserve1 := swap.Pair(0, 1).Reserves()
+	price0, price1, height := swap.Pair(0, 1).PriceCumulative()
+	if height != 5 {
+		t.Fatalf("want height 5, got %d", height)
+	}
+	if twap := CalcTWAP(fromPrice0, price0, fromHeight, height); twap.Cmp(new(big.Rat).SetFrac(fixedPrice(reserve0, reserve1), one)) != 0 {
+		t.Errorf("wrong twap of coin0 %s", twap.FloatString(18))
+	}
+	if twap := CalcTWAP(fromPrice1, price1, fromHeight, height); twap.Cmp(new(big.Rat).SetFrac(fixedPrice(reserve1, reserve0), one)) != 0 {
+		t.Errorf("wrong twap of coin1 %s", twap.FloatString(18))
+	}
+
+	reversed0, reversed1, _ := swap.Pair(1, 0).PriceCumulative()
+	if reversed0.Cmp(price1) != 0 || reversed1.Cmp(price0) != 0 {
+		t.Error("accumulators of the reversed pair are not swapped")
+	}
+}
+
+func TestPairV2_SwapFee(t *testing.T) {
+	memDB := db.NewMemDB()
+	immutableTree, err := tree.NewMutableTree(0, memDB, 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newBus := bus.NewBus()
+	checker.NewChecker(newBus)
+	accounts.NewBus(accounts.NewAccounts(newBus, immutableTree.GetLastImmutable()))
+	newBus.SetEvents(&eventsdb.MockEvents{})
+
+	swap := NewV2(newBus, immutableTree.GetLastImmutable())
+	for _, coin := range []types.CoinID{1, 2, 3} {
+		_, _, _, _ = swap.PairCreate(0, coin, helpers.BipToPip(big.NewInt(1000)), helpers.BipToPip(big.NewInt(1000)))
+	}
+	swap.PairSetSwapFee(2, 0, 20)
+	swap.PairSetSwapFee(0, 3, 5)
+	if _, _, err = immutableTree.Commit(swap); err != nil {
+		t.Fatal(err)
+	}
+
+	swap = NewV2(newBus, immutableTree.GetLastImmutable())
+	if fee := swap.Pair(0, 1).SwapFee(); fee != 0 {
+		t.Fatalf("want default fee, got %d", fee)
+	}
+	if fee := swap.Pair(2, 0).SwapFee(); fee != 20 {
+		t.Fatalf("want fee 20, got %d", fee)
+	}
+	if fee := swap.Pair(0, 3).SwapFee(); fee != 5 {
+		t.Fatalf("want fee 5, got %d", fee)
+	}
+
+	amount := helpers.BipToPip(big.NewInt(10))
+	defaultOut := swap.Pair(0, 1).CalculateBuyForSell(amount)
+	if out := swap.Pair(0, 2).CalculateBuyForSell(amount); out.Cmp(defaultOut) != 0 {
+		t.Errorf("pool with 0.2%% fee tier and default pool differ: %s and %s", out, defaultOut)
+	}
+
+	reserve0, reserve1 := swap.Pair(0, 3).Reserves()
+	balance0Adjusted := new(big.Int).Sub(new(big.Int).Mul(new(big.Int).Add(amount, reserve0), big.NewInt(10000)), new(big.Int).Mul(amount, big.NewInt(5)))
+	want := new(big.Int).Sub(reserve1, new(big.Int).Quo(new(big.Int).Mul(new(big.Int).Mul(reserve0, reserve1), big.NewInt(100000000)), new(big.Int).Mul(balance0Adjusted, big.NewInt(10000))))
+	want.Sub(want, big.NewInt(1))
+	out := swap.Pair(0, 3).CalculateBuyForSell(amount)
+	if out.Cmp(want) != 0 {
+		t.Fatalf("want %s, got %s", want, out)
+	}
+	if out.Cmp(defaultOut) != 1 {
+		t.Errorf("pool with low fee tier returned %s, default pool %s", out, defaultOut)
+	}
+	if in := swap.Pair(0, 3).CalculateSellForBuy(out); in.Cmp(amount) == 1 {
+		t.Errorf("want to sell at most %s, got %s", amount, in)
+	}
+
+	if err := swap.Pair(0, 3).CheckSwap(amount, out); err != nil {
+		t.Errorf("swap by the fee tier is rejected: %s", err)
+	}
+	if err := swap.Pair(0, 3).CheckSwap(amount, new(big.Int).Add(want, big.NewInt(2))); err != ErrorK {
+		t.Errorf("want %s, got %v", ErrorK, err)
+	}
+}
@@ -73,6 +73,84 @@ func TestSwap_GetBestTrade(t *testing.T) {
 	}
 
 }
+func TestSwap_RoutePools(t *testing.T) {
+	newBus := bus.NewBus()
+	checker.NewChecker(newBus)
+	memDB := db.NewMemDB()
+	immutableTree, err := tree.NewMutableTree(0, memDB, 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	swap := NewV2(newBus, immutableTree.GetLastImmutable())
+	// chain 0-1-2-3-4 and the branch 2-5 which does not lead to 4
+	for _, pair := range [][2]types.CoinID{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {2, 5}} {
+		swap.PairCreate(pair[0], pair[1], big.NewInt(1e18), big.NewInt(1e18))
+	}
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pools := swap.routePools(context.Background(), 0, 4, 4); len(pools) != 4 {
+		t.Errorf("want 4 pools of the route, got %d", len(pools))
+	}
+	if pools := swap.routePools(context.Background(), 0, 4, 3); len(pools) != 0 {
+		t.Errorf("want no pools of routes shorter than chain, got %d", len(pools))
+	}
+	if trade := swap.GetBestTradeExactIn(context.Background(), 4, 0, big.NewInt(1e15), 4); trade == nil || len(trade.Route.Pairs) != 4 {
+		t.Fatal("route not found")
+	}
+
+	swap.PairCreate(0, 4, big.NewInt(1e18), big.NewInt(1e18))
+	if pools := swap.routePools(context.Background(), 0, 4, 1); len(pools) != 1 {
+		t.Errorf("want new pool in the block of its creation, got %d", len(pools))
+	}
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trade := swap.GetBestTradeExactIn(context.Background(), 4, 0, big.NewInt(1e15), 4); trade == nil || len(trade.Route.Pairs) != 1 {
+		t.Error("direct route not found")
+	}
+}
+
+func TestSwap_TradeCache(t *testing.T) {
+	newBus := bus.NewBus()
+	checker.NewChecker(newBus)
+	immutableTree, err := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	swap := NewV2(newBus, immutableTree.GetLastImmutable())
+	swap.PairCreate(0, 1, big.NewInt(1e18), big.NewInt(1e18))
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trade := swap.GetBestTradeExactIn(context.Background(), 1, 0, big.NewInt(1e15), 4)
+	if cached := swap.GetBestTradeExactIn(context.Background(), 1, 0, big.NewInt(1e15), 4); cached != trade {
+		t.Error("trade is not cached")
+	}
+	if other := swap.GetBestTradeExactIn(context.Background(), 1, 0, big.NewInt(2e15), 4); other == trade {
+		t.Error("trade is cached for another amount")
+	}
+
+	swap.PairSell(0, 1, big.NewInt(1e16), big.NewInt(1))
+	changed := swap.GetBestTradeExactIn(context.Background(), 1, 0, big.NewInt(1e15), 4)
+	if changed == trade || changed.OutputAmount.Amount.Cmp(trade.OutputAmount.Amount) != -1 {
+		t.Error("trade is not recomputed after the change of the pool")
+	}
+
+	_, _, err = immutableTree.Commit(swap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := swap.GetBestTradeExactIn(context.Background(), 1, 0, big.NewInt(1e15), 4); next == changed {
+		t.Error("trade is cached for the next height")
+	}
+}
+
 func BenchmarkSwap_GetBestTrade(b *testing.B) {
 	memDB := db.NewMemDB()
 	immutableTree, err := tree.NewMutableTree(0, memDB, 1024, 0)
@@ -0,0 +1,148 @@
+package swap
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const priceOraclePrefix = 'w'
+
+// priceResolution is the number of fractional bits of accumulated prices
+const priceResolution = 112
+
+// priceOracle accumulates prices of the pool for time-weighted average price calculation.
+// On the first change of the pool in a block the price at the end of the previous change multiplied by the number of
+// blocks since that change is added to the accumulators, so a swap can not move the accumulated price of its own block.
+type priceOracle struct {
+	Price0Cumulative *big.Int
+	Price1Cumulative *big.Int
+	Height           uint64
+
+	dirty         bool
+	currentHeight func() uint64
+	isEnabled     func() bool
+}
+
+func pathPriceOracle(key PairKey) []byte {
+	return append([]byte{mainPrefix, priceOraclePrefix}, key.bytes()...)
+}
+
+func newPriceOracle(currentHeight func() uint64, isEnabled func() bool) *priceOracle {
+	return &priceOracle{
+		Price0Cumulative: big.NewInt(0),
+		Price1Cumulative: big.NewInt(0),
+		currentHeight:    currentHeight,
+		isEnabled:        isEnabled,
+	}
+}
+
+func (o *priceOracle) load(immutableTree *iavl.ImmutableTree, key PairKey) {
+	_, data := immutableTree.Get(pathPriceOracle(key))
+	if len(data) == 0 {
+		return
+	}
+	if err := rlp.DecodeBytes(data, o); err != nil {
+		panic(err)
+	}
+}
+
+func (o *priceOracle) commit(db *iavl.MutableTree, key PairKey) error {
+	if !o.dirty {
+		return nil
+	}
+	o.dirty = false
+
+	data, err := rlp.EncodeToBytes(o)
+	if err != nil {
+		return err
+	}
+	db.Set(pathPriceOracle(key), data)
+	return nil
+}
+
+// fixedPrice returns reserve1/reserve0 with priceResolution fractional bits
+func fixedPrice(reserve0, reserve1 *big.Int) *big.Int {
+	return new(big.Int).Quo(new(big.Int).Lsh(reserve1, priceResolution), reserve0)
+}
+
+// cumulative returns accumulators extrapolated to the current height by reserves of the sorted pair
+func (o *priceOracle) cumulative(reserve0, reserve1 *big.Int) (price0, price1 *big.Int, height uint64) {
+	price0, price1 = new(big.Int).Set(o.Price0Cumulative), new(big.Int).Set(o.Price1Cumulative)
+	if o.Height == 0 {
+		return price0, price1, 0
+	}
+
+	height = o.currentHeight()
+	if height > o.Height && reserve0.Sign() == 1 && reserve1.Sign() == 1 {
+		elapsed := new(big.Int).SetUint64(height - o.Height)
+		price0.Add(price0, new(big.Int).Mul(fixedPrice(reserve0, reserve1), elapsed))
+		price1.Add(price1, new(big.Int).Mul(fixedPrice(reserve1, reserve0), elapsed))
+	}
+	return price0, price1, height
+}
+
+// accumulate is called before the first change of reserves of the sorted pair in the block
+func (o *priceOracle) accumulate(reserve0, reserve1 *big.Int) {
+	if !o.isEnabled() {
+		return
+	}
+
+	height := o.currentHeight()
+	if o.Height >= height {
+		return
+	}
+
+	o.Price0Cumulative, o.Price1Cumulative, _ = o.cumulative(reserve0, reserve1)
+	o.Height = height
+	o.dirty = true
+}
+
+func (pd *pairData) accumulatePrice(sorted bool) {
+	if pd.oracle == nil {
+		return
+	}
+	if sorted {
+		pd.oracle.accumulate(pd.Reserve0, pd.Reserve1)
+	} else {
+		pd.oracle.accumulate(pd.Reserve1, pd.Reserve0)
+	}
+}
+
+func (pd *pairData) priceCumulative(sorted bool) (price0, price1 *big.Int, height uint64) {
+	if pd.oracle == nil {
+		return big.NewInt(0), big.NewInt(0), 0
+	}
+
+	pd.mu.RLock()
+	defer pd.mu.RUnlock()
+
+	if sorted {
+		return pd.oracle.cumulative(pd.Reserve0, pd.Reserve1)
+	}
+	price1, price0, height = pd.oracle.cumulative(pd.Reserve1, pd.Reserve0)
+	return price0, price1, height
+}
+
+// PriceCumulative returns accumulated prices of Coin0 in Coin1 and of Coin1 in Coin0 as of the current block height
+// with 112 fractional bits. The height is zero if the pool has not been changed since the accumulators were introduced.
+func (p *PairV2) PriceCumulative() (price0, price1 *big.Int, height uint64) {
+	return p.priceCumulative(p.isSorted())
+}
+
+// PriceCumulative returns accumulated prices of Coin0 in Coin1 and of Coin1 in Coin0 as of the current block height
+// with 112 fractional bits. The height is zero if the pool has not been changed since the accumulators were introduced.
+func (p *Pair) PriceCumulative() (price0, price1 *big.Int, height uint64) {
+	return p.priceCumulative(p.isSorted())
+}
+
+// CalcTWAP returns the time-weighted average price between two observations of price accumulator
+func CalcTWAP(fromCumulative, toCumulative *big.Int, fromHeight, toHeight uint64) *big.Rat {
+	if toHeight <= fromHeight {
+		return nil
+	}
+	diff := new(big.Int).Sub(toCumulative, fromCumulative)
+	elapsed := new(big.Int).SetUint64(toHeight - fromHeight)
+	return new(big.Rat).SetFrac(diff, new(big.Int).Lsh(elapsed, priceResolution))
+}
@@ -0,0 +1,51 @@
+package treasury
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Proposal is a proposal to pay Value of Coin from the DAO balance to Recipient.
+// Votes are addresses of stakeholders, the proposal is tallied by their stakes at Height.
+type Proposal struct {
+	Proposer        types.Address
+	Recipient       types.Address
+	Coin            types.CoinID
+	Value           *big.Int
+	DescriptionHash types.Hash
+	Height          uint64
+	Votes           []types.Address
+
+	id        uint32
+	markDirty func(id uint32)
+
+	lock sync.RWMutex
+}
+
+func (p *Proposal) ID() uint32 {
+	return p.id
+}
+
+// HasVote returns true if the address has voted for the proposal
+func (p *Proposal) HasVote(address types.Address) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	for _, vote := range p.Votes {
+		if vote == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Proposal) addVote(address types.Address) {
+	p.lock.Lock()
+	p.Votes = append(p.Votes, address)
+	p.lock.Unlock()
+
+	p.markDirty(p.id)
+}
@@ -0,0 +1,298 @@
+package treasury
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"github.com/cosmos/iavl"
+)
+
+const mainPrefix = byte('r')
+
+// MaxProposalsPerHeight is a limit of proposals with voting finished at the same height
+const MaxProposalsPerHeight = 16
+
+const (
+	proposalPrefix = byte('p')
+	heightPrefix   = byte('h')
+	nextIDPrefix   = byte('i')
+)
+
+type RTreasury interface {
+	Export(state *types.AppState)
+	GetProposal(id uint32) *Proposal
+	GetProposals(height uint64) []*Proposal
+}
+
+// Treasury is a store of pending spending proposals of the DAO balance
+type Treasury struct {
+	proposals    map[uint32]*Proposal
+	dirty        map[uint32]struct{}
+	deleted      map[uint32]struct{}
+	nextID       uint32
+	dirtyNextID  bool
+	loadedNextID bool
+
+	db atomic.Value
+
+	lock sync.RWMutex
+}
+
+func NewTreasury(db *iavl.ImmutableTree) *Treasury {
+	immutableTree := atomic.Value{}
+	if db != nil {
+		immutableTree.Store(db)
+	}
+	return &Treasury{
+		db:        immutableTree,
+		proposals: map[uint32]*Proposal{},
+		dirty:     map[uint32]struct{}{},
+		deleted:   map[uint32]struct{}{},
+	}
+}
+
+func (t *Treasury) immutableTree() *iavl.ImmutableTree {
+	db := t.db.Load()
+	if db == nil {
+		return nil
+	}
+	return db.(*iavl.ImmutableTree)
+}
+
+func (t *Treasury) SetImmutableTree(immutableTree *iavl.ImmutableTree) {
+	t.db.Store(immutableTree)
+}
+
+func (t *Treasury) Commit(db *iavl.MutableTree, version int64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.dirtyNextID {
+		t.dirtyNextID = false
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, t.nextID)
+		db.Set([]byte{mainPrefix, nextIDPrefix}, b)
+	}
+
+	ids := make([]uint32, 0, len(t.dirty))
+	for id := range t.dirty {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	for _, id := range ids {
+		proposal := t.proposals[id]
+		if _, ok := t.deleted[id]; ok {
+			delete(t.proposals, id)
+			db.Remove(pathProposal(id))
+			db.Remove(pathHeight(proposal.Height, id))
+			continue
+		}
+
+		proposal.lock.RLock()
+		data, err := rlp.EncodeToBytes(proposal)
+		proposal.lock.RUnlock()
+		if err != nil {
+			return fmt.Errorf("can't encode treasury proposal %d: %v", id, err)
+		}
+		db.Set(pathProposal(id), data)
+		db.Set(pathHeight(proposal.Height, id), []byte{0x1})
+	}
+	t.dirty = map[uint32]struct{}{}
+	t.deleted = map[uint32]struct{}{}
+
+	return nil
+}
+
+// GetProposal returns the pending proposal or nil if it does not exist
+func (t *Treasury) GetProposal(id uint32) *Proposal {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.getProposal(id)
+}
+
+func (t *Treasury) getProposal(id uint32) *Proposal {
+	if _, ok := t.deleted[id]; ok {
+		return nil
+	}
+	if proposal, ok := t.proposals[id]; ok {
+		return proposal
+	}
+
+	_, data := t.immutableTree().Get(pathProposal(id))
+	if len(data) == 0 {
+		return nil
+	}
+
+	proposal := &Proposal{}
+	if err := rlp.DecodeBytes(data, proposal); err != nil {
+		panic(fmt.Sprintf("failed to decode treasury proposal %d: %s", id, err))
+	}
+	proposal.id = id
+	proposal.markDirty = t.markDirty
+	t.proposals[id] = proposal
+
+	return proposal
+}
+
+// GetProposals returns proposals with voting finished at the height sorted by ids
+func (t *Treasury) GetProposals(height uint64) []*Proposal {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	from := pathHeight(height, 0)
+	t.immutableTree().IterateRange(from, pathHeight(height+1, 0), true, func(key []byte, value []byte) bool {
+		t.getProposal(binary.BigEndian.Uint32(key[len(from)-4:]))
+		return false
+	})
+
+	var proposals []*Proposal
+	for id, proposal := range t.proposals {
+		if _, ok := t.deleted[id]; !ok && proposal.Height == height {
+			proposals = append(proposals, proposal)
+		}
+	}
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].id < proposals[j].id
+	})
+
+	return proposals
+}
+
+// CreateProposal adds the spending proposal with voting finished at the height and returns its id
+func (t *Treasury) CreateProposal(proposer, recipient types.Address, coin types.CoinID, value *big.Int, descriptionHash types.Hash, height uint64) uint32 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	id := t.getNextID()
+	t.nextID = id + 1
+	t.dirtyNextID = true
+
+	t.proposals[id] = &Proposal{
+		Proposer:        proposer,
+		Recipient:       recipient,
+		Coin:            coin,
+		Value:           new(big.Int).Set(value),
+		DescriptionHash: descriptionHash,
+		Height:          height,
+		id:              id,
+		markDirty:       t.markDirty,
+	}
+	t.markDirty(id)
+
+	return id
+}
+
+func (t *Treasury) AddVote(id uint32, address types.Address) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.getProposal(id).addVote(address)
+}
+
+// Delete removes the proposal after the end of voting
+func (t *Treasury) Delete(id uint32) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.getProposal(id) == nil {
+		return
+	}
+	t.deleted[id] = struct{}{}
+	t.markDirty(id)
+}
+
+func (t *Treasury) getNextID() uint32 {
+	if t.loadedNextID {
+		return t.nextID
+	}
+	t.loadedNextID = true
+
+	_, data := t.immutableTree().Get([]byte{mainPrefix, nextIDPrefix})
+	if len(data) == 0 {
+		t.nextID = 1
+	} else {
+		t.nextID = binary.BigEndian.Uint32(data)
+	}
+
+	return t.nextID
+}
+
+func (t *Treasury) markDirty(id uint32) {
+	t.dirty[id] = struct{}{}
+}
+
+func (t *Treasury) Export(state *types.AppState) {
+	var ids []uint32
+	t.immutableTree().IterateRange([]byte{mainPrefix, proposalPrefix}, []byte{mainPrefix, proposalPrefix + 1}, true, func(key []byte, value []byte) bool {
+		ids = append(ids, binary.BigEndian.Uint32(key[2:]))
+		return false
+	})
+
+	for _, id := range ids {
+		proposal := t.GetProposal(id)
+		if proposal == nil {
+			continue
+		}
+		state.TreasuryProposals = append(state.TreasuryProposals, types.TreasuryProposal{
+			ID:              uint64(id),
+			Proposer:        proposal.Proposer,
+			Recipient:       proposal.Recipient,
+			Coin:            uint64(proposal.Coin),
+			Value:           proposal.Value.String(),
+			DescriptionHash: proposal.DescriptionHash,
+			Height:          proposal.Height,
+			Votes:           proposal.Votes,
+		})
+	}
+}
+
+func (t *Treasury) Import(state *types.AppState) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.getNextID()
+	for _, exported := range state.TreasuryProposals {
+		id := uint32(exported.ID)
+		proposal := &Proposal{
+			Proposer:        exported.Proposer,
+			Recipient:       exported.Recipient,
+			Coin:            types.CoinID(exported.Coin),
+			Value:           helpers.StringToBigInt(exported.Value),
+			DescriptionHash: exported.DescriptionHash,
+			Height:          exported.Height,
+			Votes:           exported.Votes,
+			id:              id,
+			markDirty:       t.markDirty,
+		}
+		t.proposals[id] = proposal
+		t.markDirty(id)
+
+		if id >= t.nextID {
+			t.nextID = id + 1
+			t.dirtyNextID = true
+		}
+	}
+}
+
+func pathProposal(id uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, id)
+	return append([]byte{mainPrefix, proposalPrefix}, b...)
+}
+
+func pathHeight(height uint64, id uint32) []byte {
+	b := make([]byte, 12)
+	binary.BigEndian.PutUint64(b, height)
+	binary.BigEndian.PutUint32(b[8:], id)
+	return append([]byte{mainPrefix, heightPrefix}, b...)
+}
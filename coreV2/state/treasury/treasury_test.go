@@ -0,0 +1,89 @@
+package treasury
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/tree"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestTreasury_ProposalsByHeight(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	tr := NewTreasury(mutableTree.GetLastImmutable())
+
+	first := tr.CreateProposal(types.Address{1}, types.Address{2}, 0, big.NewInt(100), types.Hash{1}, 10)
+	second := tr.CreateProposal(types.Address{1}, types.Address{3}, 0, big.NewInt(200), types.Hash{2}, 20)
+	tr.AddVote(first, types.Address{4})
+
+	_, _, err := mutableTree.Commit(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	third := tr.CreateProposal(types.Address{1}, types.Address{5}, 0, big.NewInt(300), types.Hash{3}, 10)
+
+	loaded := NewTreasury(mutableTree.GetLastImmutable())
+	if proposals := loaded.GetProposals(10); len(proposals) != 1 || proposals[0].ID() != first || !proposals[0].HasVote(types.Address{4}) {
+		t.Fatalf("proposals %#v", proposals)
+	}
+	if proposals := tr.GetProposals(10); len(proposals) != 2 || proposals[1].ID() != third {
+		t.Fatalf("proposals %#v", proposals)
+	}
+
+	tr.Delete(first)
+	if tr.GetProposal(first) != nil {
+		t.Error("proposal is not deleted")
+	}
+
+	_, _, err = mutableTree.Commit(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded = NewTreasury(mutableTree.GetLastImmutable())
+	if proposals := loaded.GetProposals(10); len(proposals) != 1 || proposals[0].ID() != third {
+		t.Fatalf("proposals %#v", proposals)
+	}
+	if proposal := loaded.GetProposal(second); proposal == nil || proposal.Value.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("proposal %#v", proposal)
+	}
+}
+
+func TestTreasury_ExportImport(t *testing.T) {
+	t.Parallel()
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	tr := NewTreasury(mutableTree.GetLastImmutable())
+
+	id := tr.CreateProposal(types.Address{1}, types.Address{2}, 1, big.NewInt(100), types.Hash{1}, 10)
+	tr.AddVote(id, types.Address{3})
+
+	_, _, err := mutableTree.Commit(tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := new(types.AppState)
+	tr.Export(state)
+	if len(state.TreasuryProposals) != 1 || len(state.TreasuryProposals[0].Votes) != 1 {
+		t.Fatalf("exported %#v", state.TreasuryProposals)
+	}
+
+	importedTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+	imported := NewTreasury(importedTree.GetLastImmutable())
+	imported.Import(state)
+	_, _, err = importedTree.Commit(imported)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proposal := imported.GetProposal(id)
+	if proposal == nil || proposal.DescriptionHash != (types.Hash{1}) || !proposal.HasVote(types.Address{3}) {
+		t.Fatalf("imported %#v", proposal)
+	}
+	if next := imported.CreateProposal(types.Address{1}, types.Address{2}, 1, big.NewInt(1), types.Hash{}, 20); next != id+1 {
+		t.Errorf("next proposal id %d, want %d", next, id+1)
+	}
+}
@@ -37,6 +37,9 @@ type Validators struct {
 	removed map[types.Pubkey]struct{}
 	loaded  bool
 
+	daoCommission        uint64
+	developersCommission uint64
+
 	db   atomic.Value
 	bus  *bus.Bus
 	lock sync.RWMutex
@@ -60,11 +63,19 @@ func NewValidators(bus *bus.Bus, db *iavl.ImmutableTree) *Validators {
 	} else {
 		loaded = true
 	}
-	validators := &Validators{db: immutableTree, bus: bus, loaded: loaded}
+	validators := &Validators{db: immutableTree, bus: bus, loaded: loaded, daoCommission: uint64(dao.Commission), developersCommission: uint64(developers.Commission)}
 	validators.bus.SetValidators(NewBus(validators))
 	return validators
 }
 
+// SetRewardCommissions sets percents of rewards paid to DAO and developers by PayRewardsV5Fix
+func (v *Validators) SetRewardCommissions(daoCommission, developersCommission uint64) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.daoCommission, v.developersCommission = daoCommission, developersCommission
+}
+
 func (v *Validators) immutableTree() *iavl.ImmutableTree {
 	db := v.db.Load()
 	if db == nil {
@@ -435,6 +446,10 @@ func (v *Validators) PayRewardsV3(height uint64, period int64) (moreRewards *big
 func (v *Validators) PayRewardsV5Fix(height uint64, period int64) (moreRewards *big.Int) {
 	moreRewards = big.NewInt(0)
 
+	v.lock.RLock()
+	daoCommission, developersCommission := v.daoCommission, v.developersCommission
+	v.lock.RUnlock()
+
 	vals := v.GetValidators()
 
 	calcReward, safeReward := v.bus.App().Reward()
@@ -459,13 +474,13 @@ func (v *Validators) PayRewardsV5Fix(height uint64, period int64) (moreRewards *
 		// pay commission to DAO
 
 		DAOReward := big.NewInt(0).Set(totalReward)
-		DAOReward.Mul(DAOReward, big.NewInt(int64(dao.Commission)))
+		DAOReward.Mul(DAOReward, big.NewInt(int64(daoCommission)))
 		DAOReward.Div(DAOReward, big.NewInt(100))
 
 		// pay commission to Developers
 
 		DevelopersReward := big.NewInt(0).Set(totalReward)
-		DevelopersReward.Mul(DevelopersReward, big.NewInt(int64(developers.Commission)))
+		DevelopersReward.Mul(DevelopersReward, big.NewInt(int64(developersCommission)))
 		DevelopersReward.Div(DevelopersReward, big.NewInt(100))
 
 		totalReward.Sub(totalReward, DevelopersReward)
@@ -514,8 +529,8 @@ func (v *Validators) PayRewardsV5Fix(height uint64, period int64) (moreRewards *
 					safeRewards.Div(safeRewards, validator.GetTotalBipStake())
 					safeRewards.Div(safeRewards, totalAccumRewards)
 
-					taxDAOx3 := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(developers.Commission))), big.NewInt(100))
-					taxDEVx3 := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(dao.Commission))), big.NewInt(100))
+					taxDAOx3 := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(developersCommission))), big.NewInt(100))
+					taxDEVx3 := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(daoCommission))), big.NewInt(100))
 
 					safeRewards.Sub(safeRewards, taxDAOx3)
 					safeRewards.Sub(safeRewards, taxDEVx3)
@@ -527,12 +542,12 @@ func (v *Validators) PayRewardsV5Fix(height uint64, period int64) (moreRewards *
 					calcRewards.Div(calcRewards, validator.GetTotalBipStake())
 					calcRewards.Div(calcRewards, totalAccumRewards)
 
-					taxDAO := big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(developers.Commission))), big.NewInt(100))
-					taxDEV := big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(dao.Commission))), big.NewInt(100))
+					taxDAO := big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(developersCommission))), big.NewInt(100))
+					taxDEV := big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(daoCommission))), big.NewInt(100))
 
 					calcRewards.Sub(calcRewards, taxDAO)
 					calcRewards.Sub(calcRewards, taxDEV)
-					calcRewards.Sub(calcRewards, big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(developers.Commission+dao.Commission))), big.NewInt(100)))
+					calcRewards.Sub(calcRewards, big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(developersCommission+daoCommission))), big.NewInt(100)))
 					calcRewards.Sub(calcRewards, big.NewInt(0).Div(big.NewInt(0).Mul(calcRewards, big.NewInt(int64(candidate.Commission))), big.NewInt(100)))
 
 					diffDAO := big.NewInt(0).Sub(taxDAOx3, taxDAO)
@@ -551,8 +566,8 @@ func (v *Validators) PayRewardsV5Fix(height uint64, period int64) (moreRewards *
 					safeRewards.Mul(safeRewards, big.NewInt(3))
 					safeRewards.Div(safeRewards, totalStakes)
 
-					taxDAO := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(developers.Commission))), big.NewInt(100))
-					taxDEV := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(dao.Commission))), big.NewInt(100))
+					taxDAO := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(developersCommission))), big.NewInt(100))
+					taxDEV := big.NewInt(0).Div(big.NewInt(0).Mul(safeRewards, big.NewInt(int64(daoCommission))), big.NewInt(100))
 
 					DAOReward.Add(DAOReward, taxDAO)
 					DevelopersReward.Add(DevelopersReward, taxDEV)
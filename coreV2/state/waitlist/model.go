@@ -12,6 +12,12 @@ type Item struct {
 	Value       *big.Int
 }
 
+// OwnedItem is an item of waitlist with its owner address
+type OwnedItem struct {
+	Owner types.Address
+	*Item
+}
+
 type Model struct {
 	List []*Item
 
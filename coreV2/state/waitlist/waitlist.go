@@ -24,6 +24,7 @@ type RWaitList interface {
 	Get(address types.Address, pubkey types.Pubkey, coin types.CoinID) *Item
 	GetByAddress(address types.Address) *Model
 	GetByAddressAndPubKey(address types.Address, pubkey types.Pubkey) []*Item
+	GetByPubKey(pubkey types.Pubkey) []*OwnedItem
 	Export(state *types.AppState)
 }
 
@@ -104,7 +105,7 @@ func (wl *WaitList) ExportV1(state *types.AppState, droppedIDs []uint32, height
 			for _, w := range model.List {
 				if _, ok := dropped[w.CandidateId]; ok {
 					state.FrozenFunds = append(state.FrozenFunds, types.FrozenFund{
-						Height:       height + types.GetUnbondPeriod(),
+						Height:       height + wl.bus.Params().GetUnbondPeriod(),
 						CandidateID:  0,
 						CandidateKey: nil,
 						Address:      address,
@@ -204,6 +205,33 @@ func (wl *WaitList) GetByAddressAndPubKey(address types.Address, pubkey types.Pu
 	return items
 }
 
+// GetByPubKey returns all waitlist items of the candidate ordered by owner address
+func (wl *WaitList) GetByPubKey(pubkey types.Pubkey) []*OwnedItem {
+	candidateID := wl.bus.Candidates().ID(pubkey)
+	if candidateID == 0 {
+		return nil
+	}
+
+	var items []*OwnedItem
+	wl.immutableTree().IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		address := types.BytesToAddress(key[1:])
+
+		model := wl.GetByAddress(address)
+		if model == nil {
+			return false
+		}
+		for _, item := range model.List {
+			if item.CandidateId == candidateID {
+				items = append(items, &OwnedItem{Owner: address, Item: item})
+			}
+		}
+
+		return false
+	})
+
+	return items
+}
+
 func (wl *WaitList) AddWaitList(address types.Address, pubkey types.Pubkey, coin types.CoinID, value *big.Int) {
 	w := wl.getOrNew(address)
 
@@ -86,3 +86,42 @@ func TestWaitListToPartialDelete(t *testing.T) {
 		t.Fatal("Invalid waitlist data")
 	}
 }
+
+func TestWaitListToGetByPubKey(t *testing.T) {
+	t.Parallel()
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024, 0)
+
+	wl := NewWaitList(b, mutableTree.GetLastImmutable())
+
+	candidatesState := candidates.NewCandidates(b, mutableTree.GetLastImmutable())
+
+	addr1, addr2, pubkey1, pubkey2, coin := types.Address{1}, types.Address{2}, types.Pubkey{1}, types.Pubkey{2}, types.GetBaseCoinID()
+
+	candidatesState.Create(addr1, addr1, addr1, pubkey1, 10, 0, 0)
+	candidatesState.Create(addr2, addr2, addr2, pubkey2, 10, 0, 0)
+
+	wl.AddWaitList(addr1, pubkey1, coin, big.NewInt(1e18))
+	wl.AddWaitList(addr1, pubkey2, coin, big.NewInt(2e18))
+	wl.AddWaitList(addr2, pubkey1, coin, big.NewInt(3e18))
+	_, _, err := mutableTree.Commit(wl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items := wl.GetByPubKey(pubkey1)
+	if len(items) != 2 {
+		t.Fatalf("Incorrect amount of items in waitlist: %d", len(items))
+	}
+	if items[0].Owner != addr1 || items[0].Value.Cmp(big.NewInt(1e18)) != 0 {
+		t.Fatal("Invalid waitlist data")
+	}
+	if items[1].Owner != addr2 || items[1].Value.Cmp(big.NewInt(3e18)) != 0 {
+		t.Fatal("Invalid waitlist data")
+	}
+
+	if len(wl.GetByPubKey(types.Pubkey{3})) != 0 {
+		t.Fatal("Unexpected items of unknown candidate")
+	}
+}
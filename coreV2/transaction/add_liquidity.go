@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
 	"github.com/MinterTeam/minter-go-node/coreV2/state"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
 	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
@@ -202,3 +203,27 @@ func liquidityCoinName(c0, c1 types.CoinID) string {
 	}
 	return fmt.Sprintf("%d-%d", c1, c0)
 }
+
+// addLiquidityChangedEvent records the change of the provider position in the pool, it is used by liquidity analytics
+func addLiquidityChangedEvent(deliverState *state.State, provider types.Address, coin0, coin1 types.CoinID, volume0, volume1, liquidity *big.Int, remove bool, liquidityCoin types.CoinID) {
+	events := deliverState.Bus().Events()
+	if events == nil {
+		return
+	}
+
+	swapper := deliverState.Swapper().GetSwapper(coin0, coin1)
+	reserve0, reserve1 := swapper.Reserves()
+	events.AddEvent(&eventsdb.LiquidityChangedEvent{
+		Address:     provider,
+		PoolID:      uint64(swapper.GetID()),
+		Coin0:       uint64(coin0),
+		Volume0:     volume0.String(),
+		Coin1:       uint64(coin1),
+		Volume1:     volume1.String(),
+		Liquidity:   liquidity.String(),
+		Remove:      remove,
+		Reserve0:    reserve0.String(),
+		Reserve1:    reserve1.String(),
+		TotalSupply: deliverState.Coins.GetCoin(liquidityCoin).Volume().String(),
+	})
+}
@@ -91,6 +91,13 @@ func (data AddLiquidityDataV260) Run(tx *Transaction, context state.Interface, r
 		return *response
 	}
 
+	if response := checkFrozen(checkState, data.Coin0, sender); response != nil {
+		return *response
+	}
+	if response := checkFrozen(checkState, data.Coin1, sender); response != nil {
+		return *response
+	}
+
 	commissionInBaseCoin := price
 	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
 	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
@@ -212,6 +219,7 @@ func (data AddLiquidityDataV260) Run(tx *Transaction, context state.Interface, r
 
 		deliverState.Coins.AddVolume(coinLiquidity.ID(), liquidity)
 		deliverState.Accounts.AddBalance(sender, coinLiquidity.ID(), liquidity)
+		addLiquidityChangedEvent(deliverState, sender, data.Coin0, data.Coin1, amount0, amount1, liquidity, false, coinLiquidity.ID())
 
 		deliverState.Accounts.SetNonce(sender, tx.Nonce)
 
@@ -79,6 +79,10 @@ func (data AddLimitOrderData) CommissionData(price *commission.Price) *big.Int {
 }
 
 func (data AddLimitOrderData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return data.run(tx, context, rewardPool, currentBlock, price, 0, 0)
+}
+
+func (data AddLimitOrderData) run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int, expireHeight uint64, flags uint32) Response {
 	const precision = 34
 	sender, _ := tx.Sender()
 
@@ -93,6 +97,13 @@ func (data AddLimitOrderData) Run(tx *Transaction, context state.Interface, rewa
 		return *response
 	}
 
+	if response := checkFrozen(checkState, data.CoinToSell, sender); response != nil {
+		return *response
+	}
+	if response := checkFrozen(checkState, data.CoinToBuy, sender); response != nil {
+		return *response
+	}
+
 	commissionInBaseCoin := price
 	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
 	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
@@ -132,15 +143,39 @@ func (data AddLimitOrderData) Run(tx *Transaction, context state.Interface, rewa
 			swapper = swapper.AddLastSwapStepWithOrders(big.NewInt(0).Neg(commissionInBaseCoin), big.NewInt(0).Neg(commission), true)
 		}
 	}
-	currentPrice := swapper.Reverse().PriceRat()
-	maxPrice := new(big.Rat).Quo(currentPrice, big.NewRat(5, 1))
-	orderPrice := swap.CalcPriceSellRat(data.ValueToBuy, data.ValueToSell)
-	if currentPrice.Cmp(orderPrice) == -1 ||
-		maxPrice.Cmp(orderPrice) == 1 {
-		return Response{
-			Code: code.WrongOrderPrice,
-			Log:  fmt.Sprintf("order price is %s, but must not exceed %s and more than %s", orderPrice.FloatString(precision), currentPrice.FloatString(precision), maxPrice.FloatString(precision)),
-			Info: EncodeError(code.NewWrongOrderPrice(currentPrice.FloatString(precision), maxPrice.FloatString(precision), orderPrice.FloatString(precision))),
+
+	isImmediate := flags&(OrderFlagImmediateOrCancel|OrderFlagFillOrKill) != 0
+	if isImmediate {
+		fillValue, _ := swap.ImmediateOrderFill(swapper, data.ValueToSell, data.ValueToBuy)
+		if flags&OrderFlagFillOrKill != 0 && fillValue.Cmp(data.ValueToSell) != 0 {
+			coin := checkState.Coins().GetCoin(data.CoinToBuy)
+			willGet, _ := swapper.CalculateBuyForSellWithOrders(data.ValueToSell)
+			if willGet == nil {
+				willGet = big.NewInt(0)
+			}
+			return Response{
+				Code: code.MinimumValueToBuyReached,
+				Log:  fmt.Sprintf("You wanted to buy minimum %s, but currently you buy only %s", data.ValueToBuy.String(), willGet.String()),
+				Info: EncodeError(code.NewMinimumValueToBuyReached(data.ValueToBuy.String(), willGet.String(), coin.GetFullSymbol(), coin.ID().String())),
+			}
+		}
+		// the filled part keeps the price of the order, an immediate or cancel order which can't be filled only pays the commission
+		minimumValueToBuy := new(big.Int).Mul(data.ValueToBuy, fillValue)
+		minimumValueToBuy.Add(minimumValueToBuy, new(big.Int).Sub(data.ValueToSell, big.NewInt(1)))
+		minimumValueToBuy.Quo(minimumValueToBuy, data.ValueToSell)
+		data.ValueToSell = fillValue
+		data.ValueToBuy = minimumValueToBuy
+	} else {
+		currentPrice := swapper.Reverse().PriceRat()
+		maxPrice := new(big.Rat).Quo(currentPrice, big.NewRat(5, 1))
+		orderPrice := swap.CalcPriceSellRat(data.ValueToBuy, data.ValueToSell)
+		if currentPrice.Cmp(orderPrice) == -1 ||
+			maxPrice.Cmp(orderPrice) == 1 {
+			return Response{
+				Code: code.WrongOrderPrice,
+				Log:  fmt.Sprintf("order price is %s, but must not exceed %s and more than %s", orderPrice.FloatString(precision), currentPrice.FloatString(precision), maxPrice.FloatString(precision)),
+				Info: EncodeError(code.NewWrongOrderPrice(currentPrice.FloatString(precision), maxPrice.FloatString(precision), orderPrice.FloatString(precision))),
+			}
 		}
 	}
 
@@ -172,8 +207,55 @@ func (data AddLimitOrderData) Run(tx *Transaction, context state.Interface, rewa
 		}
 		rewardPool.Add(rewardPool, commissionInBaseCoin)
 		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		if isImmediate {
+			amountIn, amountOut := big.NewInt(0), big.NewInt(0)
+			var poolIDs tagPoolsChange
+			if data.ValueToSell.Sign() == 1 {
+				var (
+					poolID  uint32
+					details *swap.ChangeDetailsWithOrders
+					owners  []*swap.OrderDetail
+				)
+				amountIn, amountOut, poolID, details, owners = deliverState.Swapper().PairSellWithOrders(data.CoinToSell, data.CoinToBuy, data.ValueToSell, data.ValueToBuy)
+				for _, value := range owners {
+					deliverState.Accounts.AddBalance(value.Owner, data.CoinToSell, value.ValueBigInt)
+				}
+				deliverState.Accounts.SubBalance(sender, data.CoinToSell, amountIn)
+				deliverState.Accounts.AddBalance(sender, data.CoinToBuy, amountOut)
+
+				poolIDs = tagPoolsChange{{
+					PoolID:   poolID,
+					CoinIn:   data.CoinToSell,
+					ValueIn:  amountIn.String(),
+					CoinOut:  data.CoinToBuy,
+					ValueOut: amountOut.String(),
+					Orders:   details,
+				}}
+			}
+
+			deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+			tags = []abcTypes.EventAttribute{
+				{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+				{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+				{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+				{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+				{Key: []byte("tx.coin_to_buy"), Value: []byte(data.CoinToBuy.String()), Index: true},
+				{Key: []byte("tx.coin_to_sell"), Value: []byte(data.CoinToSell.String()), Index: true},
+				{Key: []byte("tx.sell_amount"), Value: []byte(amountIn.String())},
+				{Key: []byte("tx.return"), Value: []byte(amountOut.String())},
+				{Key: []byte("tx.pools"), Value: []byte(poolIDs.string())},
+			}
+
+			return Response{
+				Code: code.OK,
+				Tags: tags,
+			}
+		}
+
 		deliverState.Accounts.SubBalance(sender, data.CoinToSell, data.ValueToSell)
-		orderID, poolID := deliverState.Swapper().PairAddOrder(data.CoinToBuy, data.CoinToSell, data.ValueToBuy, data.ValueToSell, sender, currentBlock)
+		orderID, poolID := deliverState.Swapper().PairAddOrderWithExpire(data.CoinToBuy, data.CoinToSell, data.ValueToBuy, data.ValueToSell, sender, currentBlock, expireHeight)
 
 		deliverState.Accounts.SetNonce(sender, tx.Nonce)
 
@@ -185,6 +267,9 @@ func (data AddLimitOrderData) Run(tx *Transaction, context state.Interface, rewa
 			{Key: []byte("tx.pool_id"), Value: []byte(strconv.Itoa(int(poolID)))},
 			{Key: []byte("tx.order_id"), Value: []byte(strconv.Itoa(int(orderID)))},
 		}
+		if expireHeight != 0 {
+			tags = append(tags, abcTypes.EventAttribute{Key: []byte("tx.expire_height"), Value: []byte(strconv.FormatUint(expireHeight, 10))})
+		}
 	}
 
 	return Response{
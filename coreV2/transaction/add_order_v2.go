@@ -0,0 +1,102 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// Execution flags of the limit order
+const (
+	// OrderFlagImmediateOrCancel fills the order as much as possible at the limit price and returns the rest
+	OrderFlagImmediateOrCancel uint32 = 1 << iota
+	// OrderFlagFillOrKill fills the whole order at the limit price or fails
+	OrderFlagFillOrKill
+)
+
+// AddLimitOrderDataV2 is a limit order with optional custom expiration and execution flags.
+// ExpireHeight is the last block the order can be filled, it can't be later than the network period of orders expiration,
+// 0 means the order is expired at the end of the period.
+// Order with OrderFlagImmediateOrCancel or OrderFlagFillOrKill is executed in the transaction and never rests in the book.
+type AddLimitOrderDataV2 struct {
+	CoinToSell   types.CoinID
+	ValueToSell  *big.Int
+	CoinToBuy    types.CoinID
+	ValueToBuy   *big.Int
+	ExpireHeight uint64
+	Flags        uint32
+}
+
+func (data AddLimitOrderDataV2) order() AddLimitOrderData {
+	return AddLimitOrderData{
+		CoinToSell:  data.CoinToSell,
+		ValueToSell: data.ValueToSell,
+		CoinToBuy:   data.CoinToBuy,
+		ValueToBuy:  data.ValueToBuy,
+	}
+}
+
+func (data AddLimitOrderDataV2) Gas() int64 {
+	return gasAddLimitOrder
+}
+func (data AddLimitOrderDataV2) TxType() TxType {
+	return TypeAddLimitOrderV2
+}
+
+func (data AddLimitOrderDataV2) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.Flags&^(OrderFlagImmediateOrCancel|OrderFlagFillOrKill) != 0 ||
+		data.Flags == OrderFlagImmediateOrCancel|OrderFlagFillOrKill {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  fmt.Sprintf("invalid order flags %d", data.Flags),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+	if data.Flags != 0 && data.ExpireHeight != 0 {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "expire height can't be set for immediately executed order",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	return data.order().basicCheck(tx, context)
+}
+
+func (data AddLimitOrderDataV2) String() string {
+	return fmt.Sprintf("ADD ORDER expire:%d flags:%d", data.ExpireHeight, data.Flags)
+}
+
+func (data AddLimitOrderDataV2) CommissionData(price *commission.Price) *big.Int {
+	return price.AddLimitOrder
+}
+
+func (data AddLimitOrderDataV2) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	if data.ExpireHeight != 0 && data.ExpireHeight < currentBlock {
+		return Response{
+			Code: code.WrongDueHeight,
+			Log:  fmt.Sprintf("Current height %d is higher than the specified one %d", currentBlock, data.ExpireHeight),
+			Info: EncodeError(code.NewCustomCode(code.WrongDueHeight)),
+		}
+	}
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	if period := checkState.Params().GetExpiredOrdersPeriod(); data.ExpireHeight > currentBlock+period {
+		return Response{
+			Code: code.WrongDueHeight,
+			Log:  fmt.Sprintf("Expire height can't be later than %d blocks after the current one", period),
+			Info: EncodeError(code.NewCustomCode(code.WrongDueHeight)),
+		}
+	}
+
+	return data.order().run(tx, context, rewardPool, currentBlock, price, data.ExpireHeight, data.Flags)
+}
@@ -0,0 +1,115 @@
+package transaction
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestImmediateOrderFill(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	coin1 := types.CoinID(1)
+	cState.Swapper().PairCreate(types.GetBaseCoinID(), coin1, helpers.BipToPip(big.NewInt(1000)), helpers.BipToPip(big.NewInt(1000)))
+	swapper := cState.Swapper().GetSwapper(types.GetBaseCoinID(), coin1)
+
+	fillValue, fillReturn := swap.ImmediateOrderFill(swapper, helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(9)))
+	if fillValue.Cmp(helpers.BipToPip(big.NewInt(10))) != 0 {
+		t.Fatalf("order is not filled completely: %s", fillValue)
+	}
+	if fillReturn.Cmp(helpers.BipToPip(big.NewInt(9))) != 1 {
+		t.Fatalf("wrong return %s", fillReturn)
+	}
+
+	fillValue, fillReturn = swap.ImmediateOrderFill(swapper, helpers.BipToPip(big.NewInt(500)), helpers.BipToPip(big.NewInt(490)))
+	if fillValue.Sign() != 1 || fillValue.Cmp(helpers.BipToPip(big.NewInt(500))) != -1 {
+		t.Fatalf("order should be filled partially: %s", fillValue)
+	}
+	if new(big.Int).Mul(fillReturn, big.NewInt(500)).Cmp(new(big.Int).Mul(fillValue, big.NewInt(490))) == -1 {
+		t.Fatalf("order is filled worse than limit price: %s for %s", fillReturn, fillValue)
+	}
+	// the search is bounded, so the volume is found with the precision of 1/2^24 of the order
+	more := new(big.Int).Add(fillValue, new(big.Int).Rsh(helpers.BipToPip(big.NewInt(500)), 24))
+	more.Add(more, big.NewInt(1))
+	if amountOut, _ := swapper.CalculateBuyForSellWithOrders(more); new(big.Int).Mul(amountOut, big.NewInt(500)).Cmp(new(big.Int).Mul(more, big.NewInt(490))) != -1 {
+		t.Fatalf("order is not filled to the limit price: %s", fillValue)
+	}
+
+	fillValue, _ = swap.ImmediateOrderFill(swapper, helpers.BipToPip(big.NewInt(10)), helpers.BipToPip(big.NewInt(11)))
+	if fillValue.Sign() != 0 {
+		t.Fatalf("order is filled above the pool price: %s", fillValue)
+	}
+}
+
+func TestAddLimitOrderV2_ImmediateOrCancelNotFilled(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	coin1 := createTestCoin(cState)
+	cState.Swapper().PairCreate(types.GetBaseCoinID(), coin1, helpers.BipToPip(big.NewInt(1000)), helpers.BipToPip(big.NewInt(1000)))
+
+	commissionPrice := commissionPrice
+	commissionPrice.AddLimitOrder = helpers.BipToPip(big.NewInt(1))
+	cState.Commission.SetNewCommissions(commissionPrice.Encode())
+
+	privateKey, addr := getAccount()
+	balance := helpers.BipToPip(big.NewInt(1000))
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), balance)
+
+	order := AddLimitOrderDataV2{
+		CoinToSell:  types.GetBaseCoinID(),
+		ValueToSell: helpers.BipToPip(big.NewInt(10)),
+		CoinToBuy:   coin1,
+		ValueToBuy:  helpers.BipToPip(big.NewInt(11)),
+		Flags:       OrderFlagFillOrKill,
+	}
+	response := runTx(t, cState, privateKey, 1, TypeAddLimitOrderV2, order)
+	if response.Code != code.MinimumValueToBuyReached {
+		t.Fatalf("Response code is not %d. Error %s", code.MinimumValueToBuyReached, response.Log)
+	}
+
+	order.Flags = OrderFlagImmediateOrCancel
+	response = runTx(t, cState, privateKey, 1, TypeAddLimitOrderV2, order)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	balance.Sub(balance, commissionPrice.AddLimitOrder)
+	if got := cState.Accounts.GetBalance(addr, types.GetBaseCoinID()); got.Cmp(balance) != 0 {
+		t.Fatalf("balance is %s, want %s", got, balance)
+	}
+	if got := cState.Accounts.GetBalance(addr, coin1); got.Sign() != 0 {
+		t.Fatalf("bought %s of the not filled order", got)
+	}
+}
+
+func TestAddLimitOrderV2_ExpireHeightAfterPeriod(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	coin1 := createTestCoin(cState)
+	cState.Swapper().PairCreate(types.GetBaseCoinID(), coin1, helpers.BipToPip(big.NewInt(1000)), helpers.BipToPip(big.NewInt(1000)))
+
+	commissionPrice := commissionPrice
+	commissionPrice.AddLimitOrder = helpers.BipToPip(big.NewInt(1))
+	cState.Commission.SetNewCommissions(commissionPrice.Encode())
+
+	privateKey, addr := getAccount()
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	response := runTx(t, cState, privateKey, 1, TypeAddLimitOrderV2, AddLimitOrderDataV2{
+		CoinToSell:   types.GetBaseCoinID(),
+		ValueToSell:  helpers.BipToPip(big.NewInt(10)),
+		CoinToBuy:    coin1,
+		ValueToBuy:   helpers.BipToPip(big.NewInt(11)),
+		ExpireHeight: cState.Params.GetExpiredOrdersPeriod() + 2,
+	})
+	if response.Code != code.WrongDueHeight {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongDueHeight, response.Log)
+	}
+}
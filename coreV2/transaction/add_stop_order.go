@@ -0,0 +1,214 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// AddStopOrderData places a stop order which is dormant until the pool price of ValueToSell at the end of a block drops to TriggerValue.
+// After activation the order is sold by the market within swap.StopOrderSlippage if MinimumValueToBuy is zero,
+// otherwise it is converted into the limit order selling ValueToSell for MinimumValueToBuy.
+type AddStopOrderData struct {
+	CoinToSell        types.CoinID
+	ValueToSell       *big.Int
+	CoinToBuy         types.CoinID
+	TriggerValue      *big.Int
+	MinimumValueToBuy *big.Int
+}
+
+func (data AddStopOrderData) Gas() int64 {
+	return gasAddStopOrder
+}
+func (data AddStopOrderData) TxType() TxType {
+	return TypeAddStopOrder
+}
+
+func (data AddStopOrderData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.ValueToSell == nil || data.TriggerValue == nil || data.MinimumValueToBuy == nil {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if data.CoinToSell == data.CoinToBuy {
+		return &Response{
+			Code: code.CrossConvert,
+			Log:  "\"From\" coin equals to \"to\" coin",
+			Info: EncodeError(code.NewCrossConvert(
+				data.CoinToBuy.String(),
+				data.CoinToSell.String(), "", "")),
+		}
+	}
+
+	minimumVolume := big.NewInt(swap.MinimumOrderVolume())
+	if data.ValueToSell.Cmp(minimumVolume) == -1 || data.TriggerValue.Sign() != 1 ||
+		(data.MinimumValueToBuy.Sign() != 0 && data.MinimumValueToBuy.Cmp(minimumVolume) == -1) {
+		return &Response{
+			Code: code.WrongOrderVolume,
+			Log:  "minimum volume is 10000000000",
+			Info: EncodeError(code.NewWrongOrderVolume(data.MinimumValueToBuy.String(), data.ValueToSell.String())),
+		}
+	}
+
+	swapper := context.Swap().GetSwapper(data.CoinToSell, data.CoinToBuy)
+	if !swapper.Exists() {
+		return &Response{
+			Code: code.PairNotExists,
+			Log:  "swap pool not found",
+			Info: EncodeError(code.NewPairNotExists(
+				data.CoinToSell.String(),
+				data.CoinToBuy.String())),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if context.Swap().GetStopOrdersCount(sender, data.CoinToSell, data.CoinToBuy) >= swap.MaxStopOrdersPerOwner {
+		return &Response{
+			Code: code.TooManyStopOrders,
+			Log:  fmt.Sprintf("address %s already has %d stop orders in the pool", sender.String(), swap.MaxStopOrdersPerOwner),
+			Info: EncodeError(code.NewTooManyStopOrders(sender.String(), swapper.GetID(), swap.MaxStopOrdersPerOwner)),
+		}
+	}
+
+	return nil
+}
+
+func (data AddStopOrderData) String() string {
+	return fmt.Sprintf("ADD STOP ORDER sell:%s %s buy:%s trigger:%s", data.ValueToSell.String(), data.CoinToSell.String(), data.CoinToBuy.String(), data.TriggerValue.String())
+}
+
+func (data AddStopOrderData) CommissionData(price *commission.Price) *big.Int {
+	return price.AddStopOrderPrice()
+}
+
+func (data AddStopOrderData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	const precision = 34
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	if response := checkFrozen(checkState, data.CoinToSell, sender); response != nil {
+		return *response
+	}
+	if response := checkFrozen(checkState, data.CoinToBuy, sender); response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	amountSell := new(big.Int).Set(data.ValueToSell)
+	if tx.GasCoin != data.CoinToSell {
+		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+			}
+		}
+	} else {
+		amountSell.Add(amountSell, commission)
+	}
+	if checkState.Accounts().GetBalance(sender, data.CoinToSell).Cmp(amountSell) < 0 {
+		coin := checkState.Coins().GetCoin(data.CoinToSell)
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), amountSell.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), amountSell.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	swapper := checkState.Swap().GetSwapper(data.CoinToSell, data.CoinToBuy)
+	if isGasCommissionFromPoolSwap && swapper.GetID() == commissionPoolSwapper.GetID() {
+		commissionInBaseCoin, _ = commissionPoolSwapper.CalculateBuyForSellWithOrders(commission)
+		if tx.GasCoin == data.CoinToSell && data.CoinToBuy.IsBaseCoin() {
+			swapper = swapper.AddLastSwapStepWithOrders(commission, commissionInBaseCoin, true)
+		}
+		if tx.GasCoin == data.CoinToBuy && data.CoinToSell.IsBaseCoin() {
+			swapper = swapper.AddLastSwapStepWithOrders(big.NewInt(0).Neg(commissionInBaseCoin), big.NewInt(0).Neg(commission), true)
+		}
+	}
+
+	currentPrice := swapper.PriceRat()
+	triggerPrice := swap.CalcPriceSellRat(data.ValueToSell, data.TriggerValue)
+	if currentPrice.Cmp(triggerPrice) != 1 {
+		return Response{
+			Code: code.WrongOrderPrice,
+			Log:  fmt.Sprintf("trigger price is %s, but must be less than current price %s", triggerPrice.FloatString(precision), currentPrice.FloatString(precision)),
+			Info: EncodeError(code.NewWrongOrderPrice("0", currentPrice.FloatString(precision), triggerPrice.FloatString(precision))),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		deliverState.Accounts.SubBalance(sender, data.CoinToSell, data.ValueToSell)
+		orderID, poolID := deliverState.Swapper().PairAddStopOrder(data.CoinToSell, data.CoinToBuy, data.ValueToSell, data.TriggerValue, data.MinimumValueToBuy, sender, currentBlock)
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.pool_id"), Value: []byte(strconv.Itoa(int(poolID)))},
+			{Key: []byte("tx.stop_order_id"), Value: []byte(strconv.Itoa(int(orderID))), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
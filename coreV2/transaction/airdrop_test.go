@@ -0,0 +1,97 @@
+package transaction
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/airdrop"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestAirdropTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	ownerPrivateKey, ownerAddr := getAccount()
+	cState.Accounts.AddBalance(ownerAddr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	alicePrivateKey, aliceAddr := getAccount()
+	bobPrivateKey, bobAddr := getAccount()
+
+	aliceValue, bobValue := helpers.BipToPip(big.NewInt(100)), helpers.BipToPip(big.NewInt(200))
+	aliceLeaf, bobLeaf := airdrop.LeafHash(aliceAddr, aliceValue), airdrop.LeafHash(bobAddr, bobValue)
+	root := airdrop.NodeHash(aliceLeaf, bobLeaf)
+
+	response := runTx(t, cState, ownerPrivateKey, 1, TypeCreateAirdrop, CreateAirdropData{
+		Coin:         coin,
+		Value:        helpers.BipToPip(big.NewInt(300)),
+		MerkleRoot:   root,
+		ExpireHeight: 1,
+	})
+	if response.Code != code.WrongDueHeight {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongDueHeight, response.Log)
+	}
+
+	response = runTx(t, cState, ownerPrivateKey, 1, TypeCreateAirdrop, CreateAirdropData{
+		Coin:         coin,
+		Value:        helpers.BipToPip(big.NewInt(300)),
+		MerkleRoot:   root,
+		ExpireHeight: 100,
+	})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	response = runTx(t, cState, alicePrivateKey, 1, TypeClaimAirdrop, ClaimAirdropData{
+		AirdropID: 1,
+		Value:     bobValue,
+		Proof:     []types.Hash{bobLeaf},
+	})
+	if response.Code != code.WrongAirdropProof {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongAirdropProof, response.Log)
+	}
+
+	response = runTx(t, cState, alicePrivateKey, 1, TypeClaimAirdrop, ClaimAirdropData{
+		AirdropID: 1,
+		Value:     aliceValue,
+		Proof:     []types.Hash{bobLeaf},
+	})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	// the commission is paid from the claimed coins
+	balance := new(big.Int).Sub(aliceValue, commissionPrice.Send)
+	if aliceBalance := cState.Accounts.GetBalance(aliceAddr, coin); aliceBalance.Cmp(balance) != 0 {
+		t.Fatalf("Alice balance is %s, want %s", aliceBalance, balance)
+	}
+
+	response = runTx(t, cState, alicePrivateKey, 2, TypeClaimAirdrop, ClaimAirdropData{
+		AirdropID: 1,
+		Value:     aliceValue,
+		Proof:     []types.Hash{bobLeaf},
+	})
+	if response.Code != code.AirdropAlreadyClaimed {
+		t.Fatalf("Response code is not %d. Error %s", code.AirdropAlreadyClaimed, response.Log)
+	}
+
+	response = runTx(t, cState, bobPrivateKey, 1, TypeClaimAirdrop, ClaimAirdropData{
+		AirdropID: 2,
+		Value:     bobValue,
+		Proof:     []types.Hash{aliceLeaf},
+	})
+	if response.Code != code.AirdropNotExists {
+		t.Fatalf("Response code is not %d. Error %s", code.AirdropNotExists, response.Log)
+	}
+
+	if remainder := cState.Airdrops.GetAirdrop(1).Remainder(); remainder.Cmp(bobValue) != 0 {
+		t.Fatalf("Airdrop remainder is %s, want %s", remainder, bobValue)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,170 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/airdrop"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// ClaimAirdropData pays Value of the airdrop to the sender, Proof is a list of sibling nodes
+// from the leaf of the sender and the value to the Merkle root of the airdrop.
+// The commission can be paid from the claimed coins if they are the gas coin.
+type ClaimAirdropData struct {
+	AirdropID uint32
+	Value     *big.Int
+	Proof     []types.Hash
+}
+
+func (data ClaimAirdropData) Gas() int64 {
+	return gasClaimAirdrop
+}
+func (data ClaimAirdropData) TxType() TxType {
+	return TypeClaimAirdrop
+}
+
+func (data ClaimAirdropData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.Value == nil || data.Value.Sign() != 1 || data.Value.BitLen() > 256 {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	drop := context.Airdrops().GetAirdrop(data.AirdropID)
+	if drop == nil {
+		return &Response{
+			Code: code.AirdropNotExists,
+			Log:  "airdrop not found",
+			Info: EncodeError(code.NewAirdropNotExists(data.AirdropID)),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if context.Airdrops().IsClaimed(data.AirdropID, sender) {
+		return &Response{
+			Code: code.AirdropAlreadyClaimed,
+			Log:  "Airdrop is already claimed by the sender",
+			Info: EncodeError(code.NewAirdropAlreadyClaimed(data.AirdropID, sender.String())),
+		}
+	}
+
+	if !airdrop.VerifyProof(drop.MerkleRoot, airdrop.LeafHash(sender, data.Value), data.Proof) {
+		return &Response{
+			Code: code.WrongAirdropProof,
+			Log:  "Sender and value are not included in the airdrop",
+			Info: EncodeError(code.NewWrongAirdropProof(data.AirdropID, sender.String(), data.Value.String())),
+		}
+	}
+
+	if remainder := drop.Remainder(); remainder.Cmp(data.Value) < 0 {
+		coin := context.Coins().GetCoin(drop.Coin)
+		return &Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for airdrop %d. Wanted %s %s", data.AirdropID, data.Value.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(drop.Owner.String(), data.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	return checkFrozen(context, drop.Coin, sender)
+}
+
+func (data ClaimAirdropData) String() string {
+	return fmt.Sprintf("CLAIM AIRDROP: %d %s", data.AirdropID, data.Value)
+}
+
+func (data ClaimAirdropData) CommissionData(price *commission.Price) *big.Int {
+	return price.ClaimAirdropPrice()
+}
+
+func (data ClaimAirdropData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	balance := checkState.Accounts().GetBalance(sender, tx.GasCoin)
+	if tx.GasCoin == checkState.Airdrops().GetAirdrop(data.AirdropID).Coin {
+		balance.Add(balance, data.Value)
+	}
+	if balance.Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		coin := deliverState.Airdrops.Claim(data.AirdropID, sender, data.Value)
+		deliverState.Accounts.AddBalance(sender, coin, data.Value)
+
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.airdrop_id"), Value: []byte(strconv.Itoa(int(data.AirdropID))), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(coin.String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,152 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	eventsdb "github.com/MinterTeam/minter-go-node/coreV2/events"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// ClaimMiningRewardData pays the reward accrued to the sender in the liquidity mining program.
+// After the end of the program its owner also gets back the reward of blocks without stakes.
+type ClaimMiningRewardData struct {
+	ProgramID uint32
+}
+
+func (data ClaimMiningRewardData) Gas() int64 {
+	return gasClaimMiningReward
+}
+func (data ClaimMiningRewardData) TxType() TxType {
+	return TypeClaimMiningReward
+}
+
+func (data ClaimMiningRewardData) basicCheck(tx *Transaction, context *state.CheckState, currentBlock uint64) *Response {
+	if context.Mining().GetProgram(data.ProgramID) == nil {
+		return &Response{
+			Code: code.MiningProgramNotExists,
+			Log:  "mining program not found",
+			Info: EncodeError(code.NewMiningProgramNotExists(data.ProgramID)),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if context.Mining().Earned(data.ProgramID, sender, currentBlock).Sign() != 1 {
+		return &Response{
+			Code: code.NoMiningReward,
+			Log:  "no reward to claim",
+			Info: EncodeError(code.NewNoMiningReward(data.ProgramID, sender.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data ClaimMiningRewardData) String() string {
+	return fmt.Sprintf("CLAIM MINING REWARD: %d", data.ProgramID)
+}
+
+func (data ClaimMiningRewardData) CommissionData(price *commission.Price) *big.Int {
+	return price.ClaimMiningRewardPrice()
+}
+
+func (data ClaimMiningRewardData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		coin, reward := deliverState.Mining.Claim(data.ProgramID, sender, currentBlock)
+		deliverState.Accounts.AddBalance(sender, coin, reward)
+		addMiningRewardEvent(deliverState, sender, data.ProgramID, coin, reward)
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.mining_program_id"), Value: []byte(strconv.Itoa(int(data.ProgramID))), Index: true},
+			{Key: []byte("tx.return"), Value: []byte(reward.String())},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
+
+func addMiningRewardEvent(deliverState *state.State, address types.Address, programID uint32, coin types.CoinID, amount *big.Int) {
+	events := deliverState.Bus().Events()
+	if events == nil {
+		return
+	}
+
+	events.AddEvent(&eventsdb.MiningRewardEvent{
+		Address:   address,
+		ProgramID: uint64(programID),
+		Coin:      uint64(coin),
+		Amount:    amount.String(),
+	})
+}
@@ -0,0 +1,160 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// CreateAirdropData reserves Value of Coin for recipients of the Merkle tree with MerkleRoot.
+// Leaves of the tree are airdrop.LeafHash of recipients and their values, unclaimed coins are returned to the sender at ExpireHeight.
+type CreateAirdropData struct {
+	Coin         types.CoinID
+	Value        *big.Int
+	MerkleRoot   types.Hash
+	ExpireHeight uint64
+}
+
+func (data CreateAirdropData) Gas() int64 {
+	return gasCreateAirdrop
+}
+func (data CreateAirdropData) TxType() TxType {
+	return TypeCreateAirdrop
+}
+
+func (data CreateAirdropData) basicCheck(tx *Transaction, context *state.CheckState, currentBlock uint64) *Response {
+	if data.Value == nil || data.Value.Sign() != 1 || data.MerkleRoot == (types.Hash{}) {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if !context.Coins().Exists(data.Coin) {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  "Coin not exists",
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	if data.ExpireHeight <= currentBlock {
+		return &Response{
+			Code: code.WrongDueHeight,
+			Log:  "Current height is higher than the expiration height of the airdrop",
+			Info: EncodeError(code.NewCustomCode(code.WrongDueHeight)),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	return checkFrozen(context, data.Coin, sender)
+}
+
+func (data CreateAirdropData) String() string {
+	return fmt.Sprintf("CREATE AIRDROP: %s %s %d", data.MerkleRoot.String(), data.Value, data.Coin)
+}
+
+func (data CreateAirdropData) CommissionData(price *commission.Price) *big.Int {
+	return price.CreateAirdropPrice()
+}
+
+func (data CreateAirdropData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	amount := new(big.Int).Set(data.Value)
+	if tx.GasCoin != data.Coin {
+		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+			}
+		}
+	} else {
+		amount.Add(amount, commission)
+	}
+	if checkState.Accounts().GetBalance(sender, data.Coin).Cmp(amount) < 0 {
+		coin := checkState.Coins().GetCoin(data.Coin)
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), amount.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), amount.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		deliverState.Accounts.SubBalance(sender, data.Coin, data.Value)
+		id := deliverState.Airdrops.CreateAirdrop(sender, data.Coin, data.Value, data.MerkleRoot, data.ExpireHeight)
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.airdrop_id"), Value: []byte(strconv.Itoa(int(id))), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -0,0 +1,187 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/mining"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// CreateMiningProgramData funds the liquidity mining program of the pool token.
+// Value of RewardCoin is distributed evenly by Duration blocks among LiquidityCoin tokens staked in the program,
+// Duration can't exceed mining.MaxProgramDuration.
+type CreateMiningProgramData struct {
+	LiquidityCoin types.CoinID
+	RewardCoin    types.CoinID
+	Value         *big.Int
+	Duration      uint64
+}
+
+func (data CreateMiningProgramData) Gas() int64 {
+	return gasCreateMiningProgram
+}
+func (data CreateMiningProgramData) TxType() TxType {
+	return TypeCreateMiningProgram
+}
+
+func (data CreateMiningProgramData) basicCheck(tx *Transaction, context *state.CheckState, currentBlock uint64) *Response {
+	if data.Value == nil || data.Value.Sign() != 1 {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	liquidityCoin := context.Coins().GetCoin(data.LiquidityCoin)
+	if liquidityCoin == nil {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  "Coin not exists",
+			Info: EncodeError(code.NewCoinNotExists("", data.LiquidityCoin.String())),
+		}
+	}
+
+	rewardCoin := context.Coins().GetCoin(data.RewardCoin)
+	if rewardCoin == nil {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  "Coin not exists",
+			Info: EncodeError(code.NewCoinNotExists("", data.RewardCoin.String())),
+		}
+	}
+
+	if !strings.HasPrefix(liquidityCoin.Symbol().String(), "LP-") || data.Duration == 0 || data.Duration > mining.MaxProgramDuration {
+		return &Response{
+			Code: code.WrongMiningProgram,
+			Log:  fmt.Sprintf("Mining program should reward tokens of a swap pool from one to %d blocks", mining.MaxProgramDuration),
+			Info: EncodeError(code.NewWrongMiningProgram(liquidityCoin.GetFullSymbol(), strconv.FormatUint(currentBlock, 10), strconv.FormatUint(currentBlock+data.Duration, 10))),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	symbolInfo := context.Coins().GetSymbolInfo(rewardCoin.Symbol())
+	if rewardCoin.Version() != 0 || symbolInfo == nil || symbolInfo.OwnerAddress().Compare(sender) != 0 {
+		var owner *string
+		if symbolInfo != nil && symbolInfo.OwnerAddress() != nil {
+			own := symbolInfo.OwnerAddress().String()
+			owner = &own
+		}
+		return &Response{
+			Code: code.IsNotOwnerOfCoin,
+			Log:  "Sender is not owner of coin",
+			Info: EncodeError(code.NewIsNotOwnerOfCoin(rewardCoin.Symbol().String(), owner)),
+		}
+	}
+
+	return nil
+}
+
+func (data CreateMiningProgramData) String() string {
+	return fmt.Sprintf("CREATE MINING PROGRAM: %d %d", data.LiquidityCoin, data.RewardCoin)
+}
+
+func (data CreateMiningProgramData) CommissionData(price *commission.Price) *big.Int {
+	return price.CreateMiningProgramPrice()
+}
+
+func (data CreateMiningProgramData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	amount := new(big.Int).Set(data.Value)
+	if tx.GasCoin != data.RewardCoin {
+		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+			}
+		}
+	} else {
+		amount.Add(amount, commission)
+	}
+	if checkState.Accounts().GetBalance(sender, data.RewardCoin).Cmp(amount) < 0 {
+		coin := checkState.Coins().GetCoin(data.RewardCoin)
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), amount.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), amount.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		deliverState.Accounts.SubBalance(sender, data.RewardCoin, data.Value)
+		id := deliverState.Mining.CreateProgram(sender, data.LiquidityCoin, data.RewardCoin, data.Value, currentBlock, currentBlock+data.Duration)
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.mining_program_id"), Value: []byte(strconv.Itoa(int(id))), Index: true},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.LiquidityCoin.String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
@@ -77,6 +77,11 @@ func (data CreateSwapPoolData) CommissionData(price *commission.Price) *big.Int
 }
 
 func (data CreateSwapPoolData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return data.run(tx, context, rewardPool, currentBlock, price, 0)
+}
+
+// run creates the pool with the fee tier in basis points, zero fee tier keeps the default commission of the pool
+func (data CreateSwapPoolData) run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int, feeTier uint32) Response {
 	sender, _ := tx.Sender()
 
 	var checkState *state.CheckState
@@ -176,6 +181,9 @@ func (data CreateSwapPoolData) Run(tx *Transaction, context state.Interface, rew
 		rewardPool.Add(rewardPool, commissionInBaseCoin)
 
 		amount0, amount1, liquidity, id := deliverState.Swapper().PairCreate(data.Coin0, data.Coin1, data.Volume0, data.Volume1)
+		if feeTier != 0 {
+			deliverState.Swapper().PairSetSwapFee(data.Coin0, data.Coin1, feeTier)
+		}
 
 		deliverState.Accounts.SubBalance(sender, data.Coin0, amount0)
 		deliverState.Accounts.SubBalance(sender, data.Coin1, amount1)
@@ -187,6 +195,7 @@ func (data CreateSwapPoolData) Run(tx *Transaction, context state.Interface, rew
 		deliverState.Coins.CreateToken(coinID, liquidityCoinSymbol, "Liquidity Pool "+coins, true, true, big.NewInt(0).Set(liquidity), maxCoinSupply, nil)
 		deliverState.Accounts.AddBalance(sender, coinID, liquidity.Sub(liquidity, swap.Bound))
 		deliverState.Accounts.AddBalance(types.Address{}, coinID, swap.Bound)
+		addLiquidityChangedEvent(deliverState, sender, data.Coin0, data.Coin1, amount0, amount1, liquidity, false, coinID)
 
 		deliverState.App.SetCoinsCount(coinID.Uint32())
 
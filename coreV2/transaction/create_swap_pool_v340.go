@@ -0,0 +1,101 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// CreateSwapPoolDataV340 is CreateSwapPoolData with the optional fee tier of the pool
+type CreateSwapPoolDataV340 struct {
+	Coin0   types.CoinID
+	Coin1   types.CoinID
+	Volume0 *big.Int
+	Volume1 *big.Int
+	// FeeTier is an optional swap fee of the pool in basis points, it must be one of the tiers voted by validators
+	FeeTier []uint32 `rlp:"tail"`
+}
+
+func (data CreateSwapPoolDataV340) pool() CreateSwapPoolData {
+	return CreateSwapPoolData{
+		Coin0:   data.Coin0,
+		Coin1:   data.Coin1,
+		Volume0: data.Volume0,
+		Volume1: data.Volume1,
+	}
+}
+
+func (data CreateSwapPoolDataV340) Gas() int64 {
+	return gasCreateSwapPool
+}
+func (data CreateSwapPoolDataV340) TxType() TxType {
+	return TypeCreateSwapPool
+}
+
+func (data CreateSwapPoolDataV340) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	sender, _ := tx.Sender()
+	if response := checkFrozen(context, data.Coin0, sender); response != nil {
+		return response
+	}
+	if response := checkFrozen(context, data.Coin1, sender); response != nil {
+		return response
+	}
+
+	if len(data.FeeTier) == 0 || data.isVotedFeeTier(context.Commission().GetCommissions()) {
+		return nil
+	}
+
+	var tiers []string
+	for _, tier := range context.Commission().GetCommissions().SwapFeeTiers() {
+		tiers = append(tiers, tier.String())
+	}
+	return &Response{
+		Code: code.WrongSwapFeeTier,
+		Log:  fmt.Sprintf("swap fee tier must be one of the voted tiers: %s", strings.Join(tiers, ", ")),
+		Info: EncodeError(code.NewWrongSwapFeeTier(fmt.Sprint(data.FeeTier), strings.Join(tiers, ","))),
+	}
+}
+
+func (data CreateSwapPoolDataV340) isVotedFeeTier(price *commission.Price) bool {
+	if len(data.FeeTier) != 1 {
+		return false
+	}
+	for _, tier := range price.SwapFeeTiers() {
+		if tier.IsUint64() && tier.Uint64() == uint64(data.FeeTier[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (data CreateSwapPoolDataV340) String() string {
+	return data.pool().String()
+}
+
+func (data CreateSwapPoolDataV340) CommissionData(price *commission.Price) *big.Int {
+	return price.CreateSwapPool
+}
+
+func (data CreateSwapPoolDataV340) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	var feeTier uint32
+	if len(data.FeeTier) != 0 {
+		feeTier = data.FeeTier[0]
+	}
+	return data.pool().run(tx, context, rewardPool, currentBlock, price, feeTier)
+}
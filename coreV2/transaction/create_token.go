@@ -109,6 +109,10 @@ func (data CreateTokenData) PayForSymbol(price *commission.Price) *big.Int {
 }
 
 func (data CreateTokenData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return data.run(tx, context, rewardPool, currentBlock, price, false)
+}
+
+func (data CreateTokenData) run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int, freezable bool) Response {
 	sender, _ := tx.Sender()
 
 	var checkState *state.CheckState
@@ -177,6 +181,9 @@ func (data CreateTokenData) Run(tx *Transaction, context state.Interface, reward
 			data.MaxSupply,
 			&sender,
 		)
+		if freezable {
+			deliverState.Coins.SetFreezable(coinId)
+		}
 
 		deliverState.App.SetCoinsCount(coinId.Uint32())
 		deliverState.Accounts.AddBalance(sender, coinId, data.InitialAmount)
@@ -0,0 +1,61 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// CreateTokenDataV2 is a token with the optional Freezable flag chosen at creation.
+// The owner of the freezable token can freeze and unfreeze addresses with FreezeAddress and UnfreezeAddress transactions.
+type CreateTokenDataV2 struct {
+	Name          string
+	Symbol        types.CoinSymbol
+	InitialAmount *big.Int
+	MaxSupply     *big.Int
+	Mintable      bool
+	Burnable      bool
+	Freezable     bool
+}
+
+func (data CreateTokenDataV2) token() CreateTokenData {
+	return CreateTokenData{
+		Name:          data.Name,
+		Symbol:        data.Symbol,
+		InitialAmount: data.InitialAmount,
+		MaxSupply:     data.MaxSupply,
+		Mintable:      data.Mintable,
+		Burnable:      data.Burnable,
+	}
+}
+
+func (data CreateTokenDataV2) Gas() int64 {
+	return gasCreateToken
+}
+func (data CreateTokenDataV2) TxType() TxType {
+	return TypeCreateTokenV2
+}
+
+func (data CreateTokenDataV2) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	return data.token().basicCheck(tx, context)
+}
+
+func (data CreateTokenDataV2) String() string {
+	return fmt.Sprintf("CREATE TOKEN symbol:%s emission:%s freezable:%t",
+		data.Symbol.String(), data.MaxSupply, data.Freezable)
+}
+
+func (data CreateTokenDataV2) CommissionData(price *commission.Price) *big.Int {
+	return data.token().CommissionData(price)
+}
+
+func (data CreateTokenDataV2) PayForSymbol(price *commission.Price) *big.Int {
+	return data.token().PayForSymbol(price)
+}
+
+func (data CreateTokenDataV2) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return data.token().run(tx, context, rewardPool, currentBlock, price, data.Freezable)
+}
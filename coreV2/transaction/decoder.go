@@ -79,7 +79,7 @@ func GetDataV1(txType TxType) (Data, bool) {
 }
 
 func GetData(txType TxType) (Data, bool) {
-	return GetDataV3(txType)
+	return GetDataV340(txType)
 }
 
 func GetDataV260(txType TxType) (Data, bool) {
@@ -124,6 +124,56 @@ func GetDataV3(txType TxType) (Data, bool) {
 		return GetDataV260(txType)
 	}
 }
+func GetDataV340(txType TxType) (Data, bool) {
+	switch txType {
+	case TypeRedeemCheck:
+		return &RedeemCheckDataV340{}, true
+	case TypeCreateSwapPool:
+		return &CreateSwapPoolDataV340{}, true
+	case TypeVoteCommission:
+		return &VoteCommissionDataV340{}, true
+	case TypeRevokeCheck:
+		return &RevokeCheckData{}, true
+	case TypeAddLimitOrderV2:
+		return &AddLimitOrderDataV2{}, true
+	case TypeAddStopOrder:
+		return &AddStopOrderData{}, true
+	case TypeRemoveStopOrder:
+		return &RemoveStopOrderData{}, true
+	case TypeCreateMiningProgram:
+		return &CreateMiningProgramData{}, true
+	case TypeStakeMining:
+		return &StakeMiningData{}, true
+	case TypeUnstakeMining:
+		return &UnstakeMiningData{}, true
+	case TypeClaimMiningReward:
+		return &ClaimMiningRewardData{}, true
+	case TypeVoteParameter:
+		return &VoteParameterData{}, true
+	case TypeCreateTreasuryProposal:
+		return &CreateTreasuryProposalData{}, true
+	case TypeVoteTreasuryProposal:
+		return &VoteTreasuryProposalData{}, true
+	case TypeSetCoinMetadata:
+		return &SetCoinMetadataData{}, true
+	case TypeApprove:
+		return &ApproveData{}, true
+	case TypeTransferFrom:
+		return &TransferFromData{}, true
+	case TypeCreateTokenV2:
+		return &CreateTokenDataV2{}, true
+	case TypeFreezeAddress:
+		return &FreezeAddressData{}, true
+	case TypeUnfreezeAddress:
+		return &UnfreezeAddressData{}, true
+	case TypeCreateAirdrop:
+		return &CreateAirdropData{}, true
+	case TypeClaimAirdrop:
+		return &ClaimAirdropData{}, true
+	default:
+		return GetDataV3(txType)
+	}
+}
 func GetDataV250(txType TxType) (Data, bool) {
 	switch txType {
 	case TypeVoteCommission:
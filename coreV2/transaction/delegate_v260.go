@@ -114,6 +114,10 @@ func (data DelegateDataV260) Run(tx *Transaction, context state.Interface, rewar
 		return *response
 	}
 
+	if response := checkFrozen(checkState, data.Coin, sender); response != nil {
+		return *response
+	}
+
 	commissionInBaseCoin := price
 	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
 	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
@@ -205,6 +205,13 @@ func (e *Executor) RunTx(context state.Interface, rawTx []byte, rewardPool *big.
 		}
 	}
 
+	// the commission of the redeem check transaction is paid by the issuer of the check
+	if tx.Type != TypeRedeemCheck {
+		if response := checkFrozen(checkState, tx.GasCoin, sender); response != nil {
+			return *response
+		}
+	}
+
 	response := tx.decodedData.Run(tx, context, rewardPool, currentBlock, price)
 	if response.Code == code.OK && isCheck {
 		// check if mempool already has transactions from this address
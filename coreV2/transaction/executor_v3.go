@@ -197,6 +197,13 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 		}
 	}
 
+	// the commission of the redeem check transaction is paid by the issuer of the check
+	if tx.Type != TypeRedeemCheck {
+		if response := checkFrozen(checkState, tx.GasCoin, sender); response != nil {
+			return *response
+		}
+	}
+
 	response := tx.decodedData.Run(tx, context, rewardPool, currentBlock, price)
 	if response.Code == code.OK && isCheck {
 		// check if mempool already has transactions from this address
@@ -238,7 +245,7 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 
 			var intruder = sender
 			if tx.Type == TypeRedeemCheck {
-				decodedCheck, err := check.DecodeFromBytes(tx.decodedData.(*RedeemCheckData).RawCheck)
+				decodedCheck, err := check.DecodeFromBytes(rawCheckOf(tx.decodedData))
 				if err != nil {
 					return Response{
 						Code: code.DecodeError,
@@ -328,7 +335,7 @@ func (e *ExecutorV3) RunTx(context state.Interface, rawTx []byte, rewardPool *bi
 				}
 			}
 		} else if deliverState, ok := context.(*state.State); ok {
-			if tx.Type == TypeCreateCoin || tx.Type == TypeCreateToken {
+			if tx.Type == TypeCreateCoin || tx.Type == TypeCreateToken || tx.Type == TypeCreateTokenV2 {
 				dataCreateSymbol := tx.decodedData.(symbolCreator)
 				symbolPrice := tx.MulGasPrice(dataCreateSymbol.PayForSymbol(commissions))
 				if !commissions.Coin.IsBaseCoin() {
@@ -0,0 +1,243 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// FreezeAddressData forbids Address to send, receive, swap and delegate the freezable token, only the owner of the token can freeze addresses
+type FreezeAddressData struct {
+	Coin    types.CoinID
+	Address types.Address
+}
+
+func (data FreezeAddressData) Gas() int64 {
+	return gasFreezeAddress
+}
+func (data FreezeAddressData) TxType() TxType {
+	return TypeFreezeAddress
+}
+
+func (data FreezeAddressData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if response := checkFreezeControl(tx, context, data.Coin); response != nil {
+		return response
+	}
+
+	if context.Coins().IsFrozen(data.Coin, data.Address) {
+		coin := context.Coins().GetCoin(data.Coin)
+		return &Response{
+			Code: code.AddressFrozen,
+			Log:  fmt.Sprintf("Address %s is already frozen for coin %s", data.Address.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewAddressFrozen(data.Address.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	return nil
+}
+
+func (data FreezeAddressData) String() string {
+	return fmt.Sprintf("FREEZE ADDRESS coin:%s address:%s", data.Coin.String(), data.Address.String())
+}
+
+func (data FreezeAddressData) CommissionData(price *commission.Price) *big.Int {
+	return price.FreezeAddressPrice()
+}
+
+func (data FreezeAddressData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return runFreezeAddress(tx, context, rewardPool, price, data.basicCheck, data.Coin, data.Address, true)
+}
+
+// UnfreezeAddressData allows the frozen Address to transfer the token again, only the owner of the token can unfreeze addresses
+type UnfreezeAddressData struct {
+	Coin    types.CoinID
+	Address types.Address
+}
+
+func (data UnfreezeAddressData) Gas() int64 {
+	return gasUnfreezeAddress
+}
+func (data UnfreezeAddressData) TxType() TxType {
+	return TypeUnfreezeAddress
+}
+
+func (data UnfreezeAddressData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if response := checkFreezeControl(tx, context, data.Coin); response != nil {
+		return response
+	}
+
+	if !context.Coins().IsFrozen(data.Coin, data.Address) {
+		coin := context.Coins().GetCoin(data.Coin)
+		return &Response{
+			Code: code.AddressNotFrozen,
+			Log:  fmt.Sprintf("Address %s is not frozen for coin %s", data.Address.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewAddressNotFrozen(data.Address.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	return nil
+}
+
+func (data UnfreezeAddressData) String() string {
+	return fmt.Sprintf("UNFREEZE ADDRESS coin:%s address:%s", data.Coin.String(), data.Address.String())
+}
+
+func (data UnfreezeAddressData) CommissionData(price *commission.Price) *big.Int {
+	return price.UnfreezeAddressPrice()
+}
+
+func (data UnfreezeAddressData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return runFreezeAddress(tx, context, rewardPool, price, data.basicCheck, data.Coin, data.Address, false)
+}
+
+// checkFreezeControl checks that the coin is a freezable token and the sender is its owner
+func checkFreezeControl(tx *Transaction, context *state.CheckState, id types.CoinID) *Response {
+	coin := context.Coins().GetCoin(id)
+	if coin == nil {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  "Coin not exists",
+			Info: EncodeError(code.NewCoinNotExists("", id.String())),
+		}
+	}
+
+	if !coin.IsFreezable() {
+		return &Response{
+			Code: code.CoinNotFreezable,
+			Log:  "Coin is not freezable",
+			Info: EncodeError(code.NewCoinNotFreezable(coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	return checkCoinOwner(context, coin.ID(), sender)
+}
+
+// checkCoinOwner checks that the sender owns the ticker of the coin and the coin is its current version
+func checkCoinOwner(context *state.CheckState, id types.CoinID, sender types.Address) *Response {
+	coin := context.Coins().GetCoin(id)
+	symbolInfo := context.Coins().GetSymbolInfo(coin.Symbol())
+	if coin.Version() != 0 || symbolInfo == nil || symbolInfo.OwnerAddress() == nil || *symbolInfo.OwnerAddress() != sender {
+		var owner *string
+		if symbolInfo != nil && symbolInfo.OwnerAddress() != nil {
+			own := symbolInfo.OwnerAddress().String()
+			owner = &own
+		}
+		return &Response{
+			Code: code.IsNotOwnerOfCoin,
+			Log:  "Sender is not owner of coin",
+			Info: EncodeError(code.NewIsNotOwnerOfCoin(coin.GetFullSymbol(), owner)),
+		}
+	}
+
+	return nil
+}
+
+// checkFrozen returns the error if any of the addresses is frozen for the coin
+func checkFrozen(context *state.CheckState, id types.CoinID, addresses ...types.Address) *Response {
+	for _, address := range addresses {
+		if !context.Coins().IsFrozen(id, address) {
+			continue
+		}
+
+		coin := context.Coins().GetCoin(id)
+		return &Response{
+			Code: code.AddressFrozen,
+			Log:  fmt.Sprintf("Address %s is frozen for coin %s", address.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewAddressFrozen(address.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	return nil
+}
+
+func runFreezeAddress(tx *Transaction, context state.Interface, rewardPool *big.Int, price *big.Int, basicCheck func(tx *Transaction, context *state.CheckState) *Response, coin types.CoinID, address types.Address, freeze bool) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		if freeze {
+			deliverState.Coins.Freeze(coin, address)
+		} else {
+			deliverState.Coins.Unfreeze(coin, address)
+		}
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.coin_id"), Value: []byte(coin.String()), Index: true},
+			{Key: []byte("tx.address"), Value: []byte(hex.EncodeToString(address[:])), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
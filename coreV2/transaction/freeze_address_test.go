@@ -0,0 +1,212 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+)
+
+func TestFreezeAddressTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	ownerPrivateKey, ownerAddr := getAccount()
+	cState.Accounts.AddBalance(ownerAddr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	holderPrivateKey, holderAddr := getAccount()
+	cState.Accounts.AddBalance(holderAddr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	response := runTx(t, cState, ownerPrivateKey, 1, TypeCreateTokenV2, CreateTokenDataV2{
+		Name:          "TEST COIN",
+		Symbol:        getTestCoinSymbol(),
+		InitialAmount: helpers.BipToPip(big.NewInt(1000)),
+		MaxSupply:     helpers.BipToPip(big.NewInt(1000)),
+		Freezable:     true,
+	})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	coin := cState.Coins.GetCoinBySymbol(getTestCoinSymbol(), 0)
+	if coin == nil || !coin.IsFreezable() {
+		t.Fatal("Freezable token is not created")
+	}
+	cState.Accounts.SubBalance(ownerAddr, coin.ID(), helpers.BipToPip(big.NewInt(100)))
+	cState.Accounts.AddBalance(holderAddr, coin.ID(), helpers.BipToPip(big.NewInt(100)))
+
+	response = runTx(t, cState, holderPrivateKey, 1, TypeFreezeAddress, FreezeAddressData{Coin: coin.ID(), Address: holderAddr})
+	if response.Code != code.IsNotOwnerOfCoin {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfCoin, response.Log)
+	}
+
+	response = runTx(t, cState, ownerPrivateKey, 2, TypeFreezeAddress, FreezeAddressData{Coin: coin.ID(), Address: holderAddr})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	send := SendData{Coin: coin.ID(), To: types.Address{1}, Value: helpers.BipToPip(big.NewInt(10))}
+	response = runTx(t, cState, holderPrivateKey, 1, TypeSend, send)
+	if response.Code != code.AddressFrozen {
+		t.Fatalf("Response code is not %d. Error %s", code.AddressFrozen, response.Log)
+	}
+
+	response = runTx(t, cState, ownerPrivateKey, 3, TypeSend, SendData{Coin: coin.ID(), To: holderAddr, Value: helpers.BipToPip(big.NewInt(10))})
+	if response.Code != code.AddressFrozen {
+		t.Fatalf("Response code is not %d. Error %s", code.AddressFrozen, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Fatal(err)
+	}
+
+	response = runTx(t, cState, ownerPrivateKey, 3, TypeUnfreezeAddress, UnfreezeAddressData{Coin: coin.ID(), Address: holderAddr})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	response = runTx(t, cState, holderPrivateKey, 1, TypeSend, send)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFreezeAddressTxToNotFreezableCoin(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, addr := getAccount()
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(10000)))
+
+	coin := createTestCoinWithOwner(cState, addr)
+
+	response := runTx(t, cState, privateKey, 1, TypeFreezeAddress, FreezeAddressData{Coin: coin, Address: types.Address{1}})
+	if response.Code != code.CoinNotFreezable {
+		t.Fatalf("Response code is not %d. Error %s", code.CoinNotFreezable, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFreezeAddressTxFrozenHolder(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	ownerPrivateKey, ownerAddr := getAccount()
+	cState.Accounts.AddBalance(ownerAddr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000000)))
+
+	holderPrivateKey, holderAddr := getAccount()
+	cState.Accounts.AddBalance(holderAddr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(1000)))
+
+	response := runTx(t, cState, ownerPrivateKey, 1, TypeCreateTokenV2, CreateTokenDataV2{
+		Name:          "TEST COIN",
+		Symbol:        getTestCoinSymbol(),
+		InitialAmount: helpers.BipToPip(big.NewInt(1000)),
+		MaxSupply:     helpers.BipToPip(big.NewInt(1000)),
+		Freezable:     true,
+	})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+	coin := cState.Coins.GetCoinBySymbol(getTestCoinSymbol(), 0).ID()
+	cState.Accounts.SubBalance(ownerAddr, coin, helpers.BipToPip(big.NewInt(100)))
+	cState.Accounts.AddBalance(holderAddr, coin, helpers.BipToPip(big.NewInt(100)))
+
+	response = runTx(t, cState, ownerPrivateKey, 2, TypeCreateSwapPool, CreateSwapPoolData{
+		Coin0:   types.GetBaseCoinID(),
+		Coin1:   coin,
+		Volume0: helpers.BipToPip(big.NewInt(100)),
+		Volume1: helpers.BipToPip(big.NewInt(100)),
+	})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+	_, _, poolID := cState.Swapper().SwapPool(types.GetBaseCoinID(), coin)
+	liquidityCoin := cState.Coins.GetCoinBySymbol(LiquidityCoinSymbol(poolID), 0).ID()
+	cState.Accounts.SubBalance(ownerAddr, coin, helpers.BipToPip(big.NewInt(100)))
+	programID := cState.Mining.CreateProgram(ownerAddr, liquidityCoin, coin, helpers.BipToPip(big.NewInt(100)), 1, 100)
+	cState.Accounts.SubBalance(ownerAddr, liquidityCoin, helpers.BipToPip(big.NewInt(10)))
+	cState.Accounts.AddBalance(holderAddr, liquidityCoin, helpers.BipToPip(big.NewInt(10)))
+
+	check := makeTestCheck(t, ownerPrivateKey, []byte{1}, coin)
+
+	response = runTx(t, cState, ownerPrivateKey, 3, TypeFreezeAddress, FreezeAddressData{Coin: coin, Address: holderAddr})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	commissionPrice := commissionPrice
+	commissionPrice.AddLimitOrder = helpers.BipToPip(big.NewInt(1))
+	cState.Commission.SetNewCommissions(commissionPrice.Encode())
+
+	for txType, data := range map[TxType]interface{}{
+		TypeCreateSwapPool: CreateSwapPoolDataV340{
+			Coin0:   coin,
+			Coin1:   createTestCoin(cState),
+			Volume0: helpers.BipToPip(big.NewInt(10)),
+			Volume1: helpers.BipToPip(big.NewInt(10)),
+		},
+		TypeAddLiquidity: AddLiquidityDataV260{
+			Coin0:          types.GetBaseCoinID(),
+			Coin1:          coin,
+			Volume0:        helpers.BipToPip(big.NewInt(10)),
+			MaximumVolume1: helpers.BipToPip(big.NewInt(20)),
+		},
+		TypeAddLimitOrder: AddLimitOrderData{
+			CoinToSell:  types.GetBaseCoinID(),
+			ValueToSell: helpers.BipToPip(big.NewInt(10)),
+			CoinToBuy:   coin,
+			ValueToBuy:  helpers.BipToPip(big.NewInt(5)),
+		},
+		TypeAddStopOrder: AddStopOrderData{
+			CoinToSell:        coin,
+			ValueToSell:       helpers.BipToPip(big.NewInt(10)),
+			CoinToBuy:         types.GetBaseCoinID(),
+			TriggerValue:      helpers.BipToPip(big.NewInt(5)),
+			MinimumValueToBuy: helpers.BipToPip(big.NewInt(4)),
+		},
+		TypeStakeMining: StakeMiningData{ProgramID: programID, Value: helpers.BipToPip(big.NewInt(10))},
+	} {
+		if response := runTx(t, cState, holderPrivateKey, 1, txType, data); response.Code != code.AddressFrozen {
+			t.Errorf("Response code of %s is not %d. Error %s", txType, code.AddressFrozen, response.Log)
+		}
+	}
+
+	if response := runRedeemTestCheckTx(t, cState, holderPrivateKey, check); response.Code != code.AddressFrozen {
+		t.Errorf("Response code of redeem check is not %d. Error %s", code.AddressFrozen, response.Log)
+	}
+
+	// the commission in the frozen coin is rejected by both executors
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       coin,
+		Type:          TypeSend,
+		SignatureType: SigTypeSingle,
+	}
+	tx.Data, _ = rlp.EncodeToBytes(SendData{Coin: types.GetBaseCoinID(), To: types.Address{1}, Value: big.NewInt(1)})
+	if err := tx.Sign(holderPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+	encodedTx, _ := rlp.EncodeToBytes(tx)
+	for _, executor := range []ExecutorTx{NewExecutor(GetData), NewExecutorV3(GetData)} {
+		if response := executor.RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false); response.Code != code.AddressFrozen {
+			t.Errorf("Response code of gas coin is not %d. Error %s", code.AddressFrozen, response.Log)
+		}
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
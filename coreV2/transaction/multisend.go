@@ -84,6 +84,12 @@ func (data MultisendData) Run(tx *Transaction, context state.Interface, rewardPo
 		return *response
 	}
 
+	for _, item := range data.List {
+		if response := checkFrozen(checkState, item.Coin, sender, item.To); response != nil {
+			return *response
+		}
+	}
+
 	commissionInBaseCoin := price
 	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
 	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
@@ -62,6 +62,10 @@ func (data RedeemCheckData) CommissionData(price *commission.Price) *big.Int {
 }
 
 func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return data.run(tx, context, rewardPool, currentBlock, price, false)
+}
+
+func (data RedeemCheckData) run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int, isV340 bool) Response {
 	sender, _ := tx.Sender()
 
 	var checkState *state.CheckState
@@ -149,6 +153,23 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 		}
 	}
 
+	if isV340 && checkState.Checks().IsCheckRevoked(checkSender, decodedCheck) {
+		return Response{
+			Code: code.CheckRevoked,
+			Log:  "Check revoked by issuer",
+			Info: EncodeError(code.NewCheckRevoked(checkSender.String())),
+		}
+	}
+
+	if isV340 {
+		if response := checkFrozen(checkState, decodedCheck.Coin, checkSender, sender); response != nil {
+			return *response
+		}
+		if response := checkFrozen(checkState, decodedCheck.GasCoin, checkSender); response != nil {
+			return *response
+		}
+	}
+
 	lockPublicKey, err := decodedCheck.LockPubKey()
 
 	if err != nil {
@@ -0,0 +1,38 @@
+package transaction
+
+import (
+	"math/big"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+)
+
+// RedeemCheckDataV340 is RedeemCheckData which also rejects checks revoked by their issuers and checks of frozen coins
+type RedeemCheckDataV340 RedeemCheckData
+
+func (data RedeemCheckDataV340) Gas() int64 {
+	return gasRedeemCheck
+}
+func (data RedeemCheckDataV340) TxType() TxType {
+	return TypeRedeemCheck
+}
+
+func (data RedeemCheckDataV340) String() string {
+	return RedeemCheckData(data).String()
+}
+
+func (data RedeemCheckDataV340) CommissionData(price *commission.Price) *big.Int {
+	return price.RedeemCheck
+}
+
+func (data RedeemCheckDataV340) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return RedeemCheckData(data).run(tx, context, rewardPool, currentBlock, price, true)
+}
+
+// rawCheckOf returns the check of the redeem check transaction data of any version
+func rawCheckOf(data Data) []byte {
+	if d, ok := data.(*RedeemCheckDataV340); ok {
+		return d.RawCheck
+	}
+	return data.(*RedeemCheckData).RawCheck
+}
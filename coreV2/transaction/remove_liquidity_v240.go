@@ -171,6 +171,7 @@ func (data RemoveLiquidityV240) Run(tx *Transaction, context state.Interface, re
 
 		deliverState.Coins.SubVolume(coinLiquidity.ID(), data.Liquidity)
 		deliverState.Accounts.SubBalance(sender, coinLiquidity.ID(), data.Liquidity)
+		addLiquidityChangedEvent(deliverState, sender, data.Coin0, data.Coin1, amount0, amount1, data.Liquidity, true, coinLiquidity.ID())
 
 		deliverState.Accounts.SetNonce(sender, tx.Nonce)
 
@@ -0,0 +1,166 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// RevokeCheckData revokes checks issued by sender either by hash of the check or by its nonce
+type RevokeCheckData struct {
+	Hash  types.Hash
+	Nonce []byte
+}
+
+func (data RevokeCheckData) TxType() TxType {
+	return TypeRevokeCheck
+}
+
+func (data RevokeCheckData) Gas() int64 {
+	return gasRevokeCheck
+}
+
+func (data RevokeCheckData) byHash() bool {
+	return data.Hash != types.Hash{}
+}
+
+func (data RevokeCheckData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if data.byHash() == (len(data.Nonce) != 0) {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Either hash or nonce of the check should be specified",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if len(data.Nonce) > 16 {
+		return &Response{
+			Code: code.TooLongNonce,
+			Log:  "Nonce is too big. Should be up to 16 bytes.",
+			Info: EncodeError(code.NewTooLongNonce(strconv.Itoa(len(data.Nonce)), "16")),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if data.byHash() {
+		if context.Checks().IsCheckHashUsed(data.Hash) {
+			return &Response{
+				Code: code.CheckUsed,
+				Log:  "Check already redeemed",
+				Info: EncodeError(code.NewCheckUsed()),
+			}
+		}
+		if context.Checks().IsCheckHashRevoked(sender, data.Hash) {
+			return &Response{
+				Code: code.CheckRevoked,
+				Log:  "Check already revoked",
+				Info: EncodeError(code.NewCheckRevoked(sender.String())),
+			}
+		}
+	} else if context.Checks().IsCheckNonceRevoked(sender, data.Nonce) {
+		return &Response{
+			Code: code.CheckRevoked,
+			Log:  "Check already revoked",
+			Info: EncodeError(code.NewCheckRevoked(sender.String())),
+		}
+	}
+
+	return nil
+}
+
+func (data RevokeCheckData) String() string {
+	if data.byHash() {
+		return fmt.Sprintf("REVOKE CHECK hash: %s", data.Hash.String())
+	}
+	return fmt.Sprintf("REVOKE CHECK nonce: %x", data.Nonce)
+}
+
+func (data RevokeCheckData) CommissionData(price *commission.Price) *big.Int {
+	return price.RevokeCheckPrice()
+}
+
+func (data RevokeCheckData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		if data.byHash() {
+			deliverState.Checks.RevokeCheckHash(sender, data.Hash)
+		} else {
+			deliverState.Checks.RevokeCheckNonce(sender, data.Nonce)
+		}
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
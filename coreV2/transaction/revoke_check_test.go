@@ -0,0 +1,183 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"sync"
+	"testing"
+
+	c "github.com/MinterTeam/minter-go-node/coreV2/check"
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/crypto"
+	"github.com/MinterTeam/minter-go-node/helpers"
+	"github.com/MinterTeam/minter-go-node/rlp"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestRevokeCheckTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	senderPrivateKey, senderAddr := getAccount()
+	cState.Accounts.AddBalance(senderAddr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	receiverPrivateKey, _ := getAccount()
+
+	check := makeTestCheck(t, senderPrivateKey, []byte{1, 2, 3}, coin)
+
+	response := runTx(t, cState, senderPrivateKey, 1, TypeRevokeCheck, RevokeCheckData{Nonce: check.Nonce})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	if !cState.Checks.IsCheckRevoked(senderAddr, check) {
+		t.Fatal("Check is not revoked")
+	}
+
+	response = runTx(t, cState, senderPrivateKey, 2, TypeRevokeCheck, RevokeCheckData{Nonce: check.Nonce})
+	if response.Code != code.CheckRevoked {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckRevoked, response.Log)
+	}
+
+	response = runRedeemTestCheckTx(t, cState, receiverPrivateKey, check)
+	if response.Code != code.CheckRevoked {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckRevoked, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRevokeCheckTxByHash(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	senderPrivateKey, senderAddr := getAccount()
+	cState.Accounts.AddBalance(senderAddr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	otherPrivateKey, otherAddr := getAccount()
+	cState.Accounts.AddBalance(otherAddr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	receiverPrivateKey, _ := getAccount()
+
+	check := makeTestCheck(t, senderPrivateKey, []byte{1, 2, 3}, coin)
+
+	// revocation by someone else does not affect the check
+	response := runTx(t, cState, otherPrivateKey, 1, TypeRevokeCheck, RevokeCheckData{Hash: check.Hash()})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+	if cState.Checks.IsCheckRevoked(senderAddr, check) {
+		t.Fatal("Check is revoked by not an issuer")
+	}
+
+	response = runTx(t, cState, senderPrivateKey, 1, TypeRevokeCheck, RevokeCheckData{Hash: check.Hash(), Nonce: check.Nonce})
+	if response.Code != code.DecodeError {
+		t.Fatalf("Response code is not %d. Error %s", code.DecodeError, response.Log)
+	}
+
+	response = runTx(t, cState, senderPrivateKey, 1, TypeRevokeCheck, RevokeCheckData{Hash: check.Hash()})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	response = runRedeemTestCheckTx(t, cState, receiverPrivateKey, check)
+	if response.Code != code.CheckRevoked {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckRevoked, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func makeTestCheck(t *testing.T, senderPrivateKey *ecdsa.PrivateKey, nonce []byte, coin types.CoinID) *c.Check {
+	passphraseHash := sha256.Sum256([]byte("password"))
+	passphrasePk, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := &c.Check{
+		Nonce:    nonce,
+		ChainID:  types.CurrentChainID,
+		DueBlock: 100,
+		Coin:     coin,
+		Value:    helpers.BipToPip(big.NewInt(10)),
+		GasCoin:  types.GetBaseCoinID(),
+	}
+
+	lock, err := crypto.Sign(check.HashWithoutLock().Bytes(), passphrasePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check.Lock = big.NewInt(0).SetBytes(lock)
+
+	if err := check.Sign(senderPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	return check
+}
+
+// runTx signs the transaction of the type with the data and runs it at the first block
+func runTx(t *testing.T, cState *state.State, privateKey *ecdsa.PrivateKey, nonce uint64, txType TxType, data interface{}) Response {
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          txType,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewExecutor(GetData).RunTx(cState, encodedTx, big.NewInt(0), 1, &sync.Map{}, 0, false)
+}
+
+func runRedeemTestCheckTx(t *testing.T, cState *state.State, receiverPrivateKey *ecdsa.PrivateKey, check *c.Check) Response {
+	passphraseHash := sha256.Sum256([]byte("password"))
+	passphrasePk, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rawCheck, _ := rlp.EncodeToBytes(check)
+
+	var senderAddressHash types.Hash
+	hw := sha3.NewLegacyKeccak256()
+	_ = rlp.Encode(hw, []interface{}{
+		crypto.PubkeyToAddress(receiverPrivateKey.PublicKey),
+	})
+	hw.Sum(senderAddressHash[:0])
+
+	sig, err := crypto.Sign(senderAddressHash.Bytes(), passphrasePk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proof := [65]byte{}
+	copy(proof[:], sig)
+
+	return runTx(t, cState, receiverPrivateKey, 1, TypeRedeemCheck, RedeemCheckData{RawCheck: rawCheck, Proof: proof})
+}
@@ -150,6 +150,12 @@ func (data SellAllSwapPoolDataV260) Run(tx *Transaction, context state.Interface
 		return *response
 	}
 
+	for _, coin := range []types.CoinID{data.Coins[0], data.Coins[len(data.Coins)-1]} {
+		if response := checkFrozen(checkState, coin, sender); response != nil {
+			return *response
+		}
+	}
+
 	coinToSell := data.Coins[0]
 
 	commissionInBaseCoin := price
@@ -87,6 +87,12 @@ func (data SellSwapPoolDataV260) Run(tx *Transaction, context state.Interface, r
 		return *response
 	}
 
+	for _, coin := range []types.CoinID{data.Coins[0], data.Coins[len(data.Coins)-1]} {
+		if response := checkFrozen(checkState, coin, sender); response != nil {
+			return *response
+		}
+	}
+
 	commissionInBaseCoin := price
 	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
 	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
@@ -66,6 +66,10 @@ func (data SendData) Run(tx *Transaction, context state.Interface, rewardPool *b
 		return *response
 	}
 
+	if response := checkFrozen(checkState, data.Coin, sender, data.To); response != nil {
+		return *response
+	}
+
 	commissionInBaseCoin := price
 	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
 	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
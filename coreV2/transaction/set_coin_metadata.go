@@ -0,0 +1,165 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+const (
+	maxCoinDescriptionLength = 512
+	maxCoinURLLength         = 256
+)
+
+// SetCoinMetadataData replaces the description, the website URL and the hash of the logo of the coin, only the owner of the coin can set them
+type SetCoinMetadataData struct {
+	Coin        types.CoinID
+	Description string
+	URL         string
+	LogoHash    types.Hash
+}
+
+func (data SetCoinMetadataData) Gas() int64 {
+	return gasSetCoinMetadata
+}
+func (data SetCoinMetadataData) TxType() TxType {
+	return TypeSetCoinMetadata
+}
+
+func (data SetCoinMetadataData) basicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if length := utf8.RuneCountInString(data.Description); length > maxCoinDescriptionLength || !utf8.ValidString(data.Description) {
+		return &Response{
+			Code: code.WrongCoinMetadata,
+			Log:  fmt.Sprintf("Coin description should be a valid string of at most %d characters", maxCoinDescriptionLength),
+			Info: EncodeError(code.NewWrongCoinMetadata("description", strconv.Itoa(length), strconv.Itoa(maxCoinDescriptionLength))),
+		}
+	}
+
+	if length := len(data.URL); length > maxCoinURLLength || !isValidCoinURL(data.URL) {
+		return &Response{
+			Code: code.WrongCoinMetadata,
+			Log:  fmt.Sprintf("Coin URL should be an http or https URL of at most %d bytes", maxCoinURLLength),
+			Info: EncodeError(code.NewWrongCoinMetadata("url", strconv.Itoa(length), strconv.Itoa(maxCoinURLLength))),
+		}
+	}
+
+	coin := context.Coins().GetCoin(data.Coin)
+	if coin == nil {
+		return &Response{
+			Code: code.CoinNotExists,
+			Log:  "Coin not exists",
+			Info: EncodeError(code.NewCoinNotExists("", data.Coin.String())),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	return checkCoinOwner(context, coin.ID(), sender)
+}
+
+// isValidCoinURL returns true if the URL is empty or an absolute http or https URL
+func isValidCoinURL(rawURL string) bool {
+	if rawURL == "" {
+		return true
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func (data SetCoinMetadataData) String() string {
+	return fmt.Sprintf("SET COIN METADATA: %d", data.Coin)
+}
+
+func (data SetCoinMetadataData) CommissionData(price *commission.Price) *big.Int {
+	return price.SetCoinMetadataPrice()
+}
+
+func (data SetCoinMetadataData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		deliverState.Coins.SetMetadata(data.Coin, data.Description, data.URL, data.LogoHash)
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.coin_id"), Value: []byte(data.Coin.String()), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
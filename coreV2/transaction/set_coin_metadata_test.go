@@ -0,0 +1,70 @@
+package transaction
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestSetCoinMetadataTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, addr := getAccount()
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(10000)))
+
+	coin := createTestCoinWithOwner(cState, addr)
+
+	response := runTx(t, cState, privateKey, 1, TypeSetCoinMetadata, SetCoinMetadataData{
+		Coin:        coin,
+		Description: "Test coin",
+		URL:         "ftp://example.com",
+	})
+	if response.Code != code.WrongCoinMetadata {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongCoinMetadata, response.Log)
+	}
+
+	response = runTx(t, cState, privateKey, 1, TypeSetCoinMetadata, SetCoinMetadataData{
+		Coin:        coin,
+		Description: "Test coin",
+		URL:         "https://example.com",
+		LogoHash:    types.Hash{1, 2, 3},
+	})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	metadata := cState.Coins.GetCoin(coin).Metadata()
+	if metadata == nil || metadata.Description != "Test coin" || metadata.URL != "https://example.com" || metadata.LogoHash != (types.Hash{1, 2, 3}) {
+		t.Fatalf("Metadata is not set: %#v", metadata)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetCoinMetadataTxToNotOwner(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+
+	privateKey, addr := getAccount()
+	cState.Accounts.AddBalance(addr, types.GetBaseCoinID(), helpers.BipToPip(big.NewInt(10000)))
+
+	coin := createTestCoinWithOwner(cState, types.Address{1})
+
+	response := runTx(t, cState, privateKey, 1, TypeSetCoinMetadata, SetCoinMetadataData{
+		Coin:        coin,
+		Description: "Test coin",
+	})
+	if response.Code != code.IsNotOwnerOfCoin {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotOwnerOfCoin, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
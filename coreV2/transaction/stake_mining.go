@@ -0,0 +1,165 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// StakeMiningData stakes pool tokens of the sender to the liquidity mining program
+type StakeMiningData struct {
+	ProgramID uint32
+	Value     *big.Int
+}
+
+func (data StakeMiningData) Gas() int64 {
+	return gasStakeMining
+}
+func (data StakeMiningData) TxType() TxType {
+	return TypeStakeMining
+}
+
+func (data StakeMiningData) basicCheck(tx *Transaction, context *state.CheckState, currentBlock uint64) *Response {
+	if data.Value == nil || data.Value.Sign() != 1 {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	program := context.Mining().GetProgram(data.ProgramID)
+	if program == nil {
+		return &Response{
+			Code: code.MiningProgramNotExists,
+			Log:  "mining program not found",
+			Info: EncodeError(code.NewMiningProgramNotExists(data.ProgramID)),
+		}
+	}
+
+	if currentBlock >= program.EndHeight {
+		return &Response{
+			Code: code.WrongMiningProgram,
+			Log:  "mining program is finished",
+			Info: EncodeError(code.NewWrongMiningProgram(program.LiquidityCoin.String(), strconv.FormatUint(program.StartHeight, 10), strconv.FormatUint(program.EndHeight, 10))),
+		}
+	}
+
+	return nil
+}
+
+func (data StakeMiningData) String() string {
+	return fmt.Sprintf("STAKE MINING: %d", data.ProgramID)
+}
+
+func (data StakeMiningData) CommissionData(price *commission.Price) *big.Int {
+	return price.StakeMiningPrice()
+}
+
+func (data StakeMiningData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	program := checkState.Mining().GetProgram(data.ProgramID)
+	if response := checkFrozen(checkState, program.LiquidityCoin, sender); response != nil {
+		return *response
+	}
+	if response := checkFrozen(checkState, program.RewardCoin, sender); response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	liquidityCoin := program.LiquidityCoin
+	amount := new(big.Int).Set(data.Value)
+	if tx.GasCoin != liquidityCoin {
+		if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+			return Response{
+				Code: code.InsufficientFunds,
+				Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+			}
+		}
+	} else {
+		amount.Add(amount, commission)
+	}
+	if checkState.Accounts().GetBalance(sender, liquidityCoin).Cmp(amount) < 0 {
+		coin := checkState.Coins().GetCoin(liquidityCoin)
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), amount.String(), coin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), amount.String(), coin.GetFullSymbol(), coin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		deliverState.Accounts.SubBalance(sender, liquidityCoin, data.Value)
+		deliverState.Mining.Stake(data.ProgramID, sender, data.Value, currentBlock)
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.mining_program_id"), Value: []byte(strconv.Itoa(int(data.ProgramID))), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
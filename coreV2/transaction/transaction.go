@@ -66,6 +66,25 @@ const (
 	TypeRemoveLimitOrder        TxType = 0x24
 	TypeLockStake               TxType = 0x25
 	TypeLock                    TxType = 0x26
+	TypeRevokeCheck             TxType = 0x27
+	TypeAddLimitOrderV2         TxType = 0x28
+	TypeAddStopOrder            TxType = 0x29
+	TypeRemoveStopOrder         TxType = 0x2A
+	TypeCreateMiningProgram     TxType = 0x2B
+	TypeStakeMining             TxType = 0x2C
+	TypeUnstakeMining           TxType = 0x2D
+	TypeClaimMiningReward       TxType = 0x2E
+	TypeVoteParameter           TxType = 0x2F
+	TypeCreateTreasuryProposal  TxType = 0x30
+	TypeVoteTreasuryProposal    TxType = 0x31
+	TypeSetCoinMetadata         TxType = 0x32
+	TypeApprove                 TxType = 0x33
+	TypeTransferFrom            TxType = 0x34
+	TypeCreateTokenV2           TxType = 0x35
+	TypeFreezeAddress           TxType = 0x36
+	TypeUnfreezeAddress         TxType = 0x37
+	TypeCreateAirdrop           TxType = 0x38
+	TypeClaimAirdrop            TxType = 0x39
 )
 
 const (
@@ -75,12 +94,25 @@ const (
 	gasMultisendBase  = 1
 	gasMultisendDelta = 1
 
+	gasApprove      = 1
+	gasTransferFrom = 1
+
 	gasCreateSwapPool  = 10
 	gasAddLiquidity    = 5
 	gasRemoveLiquidity = 5
 
 	gasAddLimitOrder    = 50
 	gasRemoveLimitOrder = 50
+	gasAddStopOrder     = 50
+	gasRemoveStopOrder  = 50
+
+	gasCreateMiningProgram = 10
+	gasStakeMining         = 5
+	gasUnstakeMining       = 5
+	gasClaimMiningReward   = 5
+
+	gasCreateAirdrop = 10
+	gasClaimAirdrop  = 2
 
 	convertDelta       = 1
 	gasSellSwapPool    = 2
@@ -96,10 +128,16 @@ const (
 	gasRecreateToken = 5
 	gasEditCoinOwner = 5
 
+	gasSetCoinMetadata = 5
+
+	gasFreezeAddress   = 5
+	gasUnfreezeAddress = 5
+
 	gasMintToken = 1
 	gasBurnToken = 1
 
 	gasRedeemCheck = 20
+	gasRevokeCheck = 5
 
 	gasDeclareCandidacy = 10
 	gasDelegate         = 6
@@ -120,6 +158,10 @@ const (
 	gasSetHaltBlock   = 5
 	gasVoteCommission = 5
 	gasVoteUpdate     = 5
+	gasVoteParameter  = 5
+
+	gasCreateTreasuryProposal = 10
+	gasVoteTreasuryProposal   = 5
 )
 
 type SigType byte
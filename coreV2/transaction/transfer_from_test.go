@@ -0,0 +1,64 @@
+package transaction
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestTransferFromTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	ownerPrivateKey, ownerAddr := getAccount()
+	cState.Accounts.AddBalance(ownerAddr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	spenderPrivateKey, spenderAddr := getAccount()
+	cState.Accounts.AddBalance(spenderAddr, coin, helpers.BipToPip(big.NewInt(1000)))
+
+	to := types.Address{1}
+	value := helpers.BipToPip(big.NewInt(100))
+
+	response := runTx(t, cState, spenderPrivateKey, 1, TypeTransferFrom, TransferFromData{From: ownerAddr, To: to, Coin: coin, Value: value})
+	if response.Code != code.InsufficientAllowance {
+		t.Fatalf("Response code is not %d. Error %s", code.InsufficientAllowance, response.Log)
+	}
+
+	response = runTx(t, cState, ownerPrivateKey, 1, TypeApprove, ApproveData{Spender: spenderAddr, Coin: coin, Value: helpers.BipToPip(big.NewInt(150))})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	response = runTx(t, cState, spenderPrivateKey, 1, TypeTransferFrom, TransferFromData{From: ownerAddr, To: to, Coin: coin, Value: value})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(to, coin); balance.Cmp(value) != 0 {
+		t.Fatalf("Target %s balance is not correct. Expected %s, got %s", to.String(), value, balance)
+	}
+	if allowance := cState.Accounts.GetAllowance(ownerAddr, spenderAddr, coin); allowance.Cmp(helpers.BipToPip(big.NewInt(50))) != 0 {
+		t.Fatalf("Allowance is not correct. Expected %s, got %s", helpers.BipToPip(big.NewInt(50)), allowance)
+	}
+
+	response = runTx(t, cState, spenderPrivateKey, 2, TypeTransferFrom, TransferFromData{From: ownerAddr, To: to, Coin: coin, Value: value})
+	if response.Code != code.InsufficientAllowance {
+		t.Fatalf("Response code is not %d. Error %s", code.InsufficientAllowance, response.Log)
+	}
+
+	response = runTx(t, cState, ownerPrivateKey, 2, TypeApprove, ApproveData{Spender: spenderAddr, Coin: coin, Value: big.NewInt(0)})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+	if allowances := cState.Accounts.GetAllowances(ownerAddr); len(allowances) != 0 {
+		t.Fatalf("Allowance is not revoked: %#v", allowances)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
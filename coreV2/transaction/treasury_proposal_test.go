@@ -0,0 +1,95 @@
+package transaction
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/treasury"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	"github.com/MinterTeam/minter-go-node/helpers"
+)
+
+func TestTreasuryProposalTx(t *testing.T) {
+	t.Parallel()
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	proposerPrivateKey, proposerAddr := getAccount()
+	cState.Accounts.AddBalance(proposerAddr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	voterPrivateKey, voterAddr := getAccount()
+	cState.Accounts.AddBalance(voterAddr, coin, helpers.BipToPip(big.NewInt(1000000)))
+
+	pubkey := createTestCandidate(cState)
+	cState.Candidates.Delegate(voterAddr, pubkey, coin, helpers.BipToPip(big.NewInt(100)), big.NewInt(0))
+	cState.Candidates.RecalculateStakes(109000)
+	cState.Validators.Create(pubkey, helpers.BipToPip(big.NewInt(100)))
+
+	recipient := types.Address{1}
+	response := runTx(t, cState, proposerPrivateKey, 1, TypeCreateTreasuryProposal, CreateTreasuryProposalData{
+		Recipient:       recipient,
+		Coin:            coin,
+		Value:           helpers.BipToPip(big.NewInt(100)),
+		DescriptionHash: types.Hash{1, 2, 3},
+		Height:          0,
+	})
+	if response.Code != code.VoteExpired {
+		t.Fatalf("Response code is not %d. Error %s", code.VoteExpired, response.Log)
+	}
+
+	response = runTx(t, cState, proposerPrivateKey, 1, TypeCreateTreasuryProposal, CreateTreasuryProposalData{
+		Recipient:       recipient,
+		Coin:            coin,
+		Value:           helpers.BipToPip(big.NewInt(100)),
+		DescriptionHash: types.Hash{1, 2, 3},
+		Height:          100,
+	})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	proposal := cState.Treasury.GetProposal(1)
+	if proposal == nil || proposal.Recipient != recipient || proposal.Proposer != proposerAddr {
+		t.Fatalf("Proposal is not created: %#v", proposal)
+	}
+
+	response = runTx(t, cState, proposerPrivateKey, 2, TypeVoteTreasuryProposal, VoteTreasuryProposalData{ProposalID: 1})
+	if response.Code != code.StakeNotFound {
+		t.Fatalf("Response code is not %d. Error %s", code.StakeNotFound, response.Log)
+	}
+
+	response = runTx(t, cState, voterPrivateKey, 1, TypeVoteTreasuryProposal, VoteTreasuryProposalData{ProposalID: 2})
+	if response.Code != code.TreasuryProposalNotExists {
+		t.Fatalf("Response code is not %d. Error %s", code.TreasuryProposalNotExists, response.Log)
+	}
+
+	response = runTx(t, cState, voterPrivateKey, 1, TypeVoteTreasuryProposal, VoteTreasuryProposalData{ProposalID: 1})
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+	if !proposal.HasVote(voterAddr) {
+		t.Fatal("Vote is not added")
+	}
+
+	response = runTx(t, cState, voterPrivateKey, 2, TypeVoteTreasuryProposal, VoteTreasuryProposalData{ProposalID: 1})
+	if response.Code != code.VoteAlreadyExists {
+		t.Fatalf("Response code is not %d. Error %s", code.VoteAlreadyExists, response.Log)
+	}
+
+	for nonce := uint64(2); nonce <= treasury.MaxProposalsPerHeight+1; nonce++ {
+		response = runTx(t, cState, proposerPrivateKey, nonce, TypeCreateTreasuryProposal, CreateTreasuryProposalData{
+			Recipient: recipient,
+			Coin:      coin,
+			Value:     helpers.BipToPip(big.NewInt(100)),
+			Height:    100,
+		})
+	}
+	if response.Code != code.TooManyTreasuryProposals {
+		t.Fatalf("Response code is not %d. Error %s", code.TooManyTreasuryProposals, response.Log)
+	}
+
+	if err := checkState(cState); err != nil {
+		t.Error(err)
+	}
+}
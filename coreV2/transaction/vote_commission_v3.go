@@ -80,8 +80,12 @@ func (data VoteCommissionDataV3) GetPubKey() types.Pubkey {
 	return data.PubKey
 }
 
-func (data VoteCommissionDataV3) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
-	if len(data.More) != 0 {
+func (data VoteCommissionDataV3) basicCheck(tx *Transaction, context *state.CheckState, block uint64, withMore bool) *Response {
+	if withMore {
+		if response := checkPriceMore(data.More); response != nil {
+			return response
+		}
+	} else if len(data.More) != 0 {
 		return &Response{
 			Code: code.DecodeError,
 			Log:  "More or less parameters than expected",
@@ -133,6 +137,10 @@ func (data VoteCommissionDataV3) CommissionData(price *commission.Price) *big.In
 }
 
 func (data VoteCommissionDataV3) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return data.run(tx, context, rewardPool, currentBlock, price, false)
+}
+
+func (data VoteCommissionDataV3) run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int, withMore bool) Response {
 	sender, _ := tx.Sender()
 
 	var checkState *state.CheckState
@@ -141,7 +149,7 @@ func (data VoteCommissionDataV3) Run(tx *Transaction, context state.Interface, r
 		checkState = state.NewCheckState(context.(*state.State))
 	}
 
-	response := data.basicCheck(tx, checkState, currentBlock)
+	response := data.basicCheck(tx, checkState, currentBlock, withMore)
 	if response != nil {
 		return *response
 	}
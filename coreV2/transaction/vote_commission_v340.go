@@ -0,0 +1,71 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+)
+
+// VoteCommissionDataV340 is VoteCommissionDataV3 which also votes the tail of prices: prices of new transactions and swap fee tiers
+type VoteCommissionDataV340 VoteCommissionDataV3
+
+func (data VoteCommissionDataV340) TxType() TxType {
+	return TypeVoteCommission
+}
+func (data VoteCommissionDataV340) Gas() int64 {
+	return gasVoteCommission
+}
+
+func (data VoteCommissionDataV340) GetPubKey() types.Pubkey {
+	return data.PubKey
+}
+
+func (data VoteCommissionDataV340) String() string {
+	return VoteCommissionDataV3(data).String()
+}
+
+func (data VoteCommissionDataV340) CommissionData(price *commission.Price) *big.Int {
+	return price.VoteCommission
+}
+
+func (data VoteCommissionDataV340) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	return VoteCommissionDataV3(data).run(tx, context, rewardPool, currentBlock, price, true)
+}
+
+func checkPriceMore(more []*big.Int) *Response {
+	if len(more) > commission.MaxMoreLen {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "More or less parameters than expected",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+	for _, value := range more {
+		if value == nil {
+			return &Response{
+				Code: code.DecodeError,
+				Log:  "Incorrect tx data",
+				Info: EncodeError(code.NewDecodeError()),
+			}
+		}
+	}
+
+	price := &commission.Price{More: more}
+	for _, tier := range price.SwapFeeTiers() {
+		if tier.Sign() != 1 || tier.Cmp(big.NewInt(swap.MaxSwapFee)) == 1 {
+			return &Response{
+				Code: code.WrongSwapFeeTier,
+				Log:  fmt.Sprintf("swap fee tier must be between 1 and %d basis points", swap.MaxSwapFee),
+				Info: EncodeError(code.NewWrongSwapFeeTier(tier.String(), strconv.Itoa(swap.MaxSwapFee))),
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/MinterTeam/minter-go-node/coreV2/code"
+	"github.com/MinterTeam/minter-go-node/coreV2/state"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/commission"
+	"github.com/MinterTeam/minter-go-node/coreV2/state/swap"
+	"github.com/MinterTeam/minter-go-node/coreV2/types"
+	abcTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// VoteTreasuryProposalData is a vote of the sender for the treasury spending proposal, the vote is weighted by stakes of the sender.
+// Only delegators of validators can vote.
+type VoteTreasuryProposalData struct {
+	ProposalID uint32
+}
+
+func (data VoteTreasuryProposalData) Gas() int64 {
+	return gasVoteTreasuryProposal
+}
+func (data VoteTreasuryProposalData) TxType() TxType {
+	return TypeVoteTreasuryProposal
+}
+
+func (data VoteTreasuryProposalData) basicCheck(tx *Transaction, context *state.CheckState, block uint64) *Response {
+	proposal := context.Treasury().GetProposal(data.ProposalID)
+	if proposal == nil {
+		return &Response{
+			Code: code.TreasuryProposalNotExists,
+			Log:  "treasury proposal not found",
+			Info: EncodeError(code.NewTreasuryProposalNotExists(data.ProposalID)),
+		}
+	}
+
+	if proposal.Height < block {
+		return &Response{
+			Code: code.VoteExpired,
+			Log:  "voting for the proposal is finished",
+			Info: EncodeError(code.NewVoteExpired(strconv.Itoa(int(block)), strconv.Itoa(int(proposal.Height)))),
+		}
+	}
+
+	sender, _ := tx.Sender()
+	if proposal.HasVote(sender) {
+		return &Response{
+			Code: code.VoteAlreadyExists,
+			Log:  "Treasury proposal vote of the sender already exists",
+			Info: EncodeError(code.NewVoteAlreadyExists(strconv.FormatUint(proposal.Height, 10), sender.String())),
+		}
+	}
+
+	if !isValidatorsDelegator(context, sender) {
+		return &Response{
+			Code: code.StakeNotFound,
+			Log:  "sender has no stakes in validators",
+			Info: EncodeError(code.NewStakeNotFound("", sender.String(), "", "")),
+		}
+	}
+
+	return nil
+}
+
+// isValidatorsDelegator returns true if the address has a stake in any of the validators
+func isValidatorsDelegator(context *state.CheckState, address types.Address) bool {
+	for _, val := range context.Validators().GetValidators() {
+		for _, stake := range context.Candidates().GetStakes(val.PubKey) {
+			if stake.Owner == address {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (data VoteTreasuryProposalData) String() string {
+	return fmt.Sprintf("VOTE TREASURY PROPOSAL: %d", data.ProposalID)
+}
+
+func (data VoteTreasuryProposalData) CommissionData(price *commission.Price) *big.Int {
+	return price.VoteTreasuryProposalPrice()
+}
+
+func (data VoteTreasuryProposalData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64, price *big.Int) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.basicCheck(tx, checkState, currentBlock)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := price
+	commissionPoolSwapper := checkState.Swap().GetSwapper(tx.GasCoin, types.GetBaseCoinID())
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+	commission, isGasCommissionFromPoolSwap, errResp := CalculateCommission(checkState, commissionPoolSwapper, gasCoin, commissionInBaseCoin)
+	if errResp != nil {
+		return *errResp
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	var tags []abcTypes.EventAttribute
+	if deliverState, ok := context.(*state.State); ok {
+		var tagsCom *tagPoolChange
+		if isGasCommissionFromPoolSwap {
+			var (
+				poolIDCom  uint32
+				detailsCom *swap.ChangeDetailsWithOrders
+				ownersCom  []*swap.OrderDetail
+			)
+			commission, commissionInBaseCoin, poolIDCom, detailsCom, ownersCom = deliverState.Swapper().PairSellWithOrders(tx.CommissionCoin(), types.GetBaseCoinID(), commission, big.NewInt(0))
+			tagsCom = &tagPoolChange{
+				PoolID:   poolIDCom,
+				CoinIn:   tx.CommissionCoin(),
+				ValueIn:  commission.String(),
+				CoinOut:  types.GetBaseCoinID(),
+				ValueOut: commissionInBaseCoin.String(),
+				Orders:   detailsCom,
+				// Sellers:  ownersCom,
+			}
+			for _, value := range ownersCom {
+				deliverState.Accounts.AddBalance(value.Owner, tx.CommissionCoin(), value.ValueBigInt)
+			}
+		} else if !tx.GasCoin.IsBaseCoin() {
+			deliverState.Coins.SubVolume(tx.CommissionCoin(), commission)
+			deliverState.Coins.SubReserve(tx.CommissionCoin(), commissionInBaseCoin)
+		}
+
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+
+		deliverState.Treasury.AddVote(data.ProposalID, sender)
+
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+
+		tags = []abcTypes.EventAttribute{
+			{Key: []byte("tx.commission_in_base_coin"), Value: []byte(commissionInBaseCoin.String())},
+			{Key: []byte("tx.commission_conversion"), Value: []byte(isGasCommissionFromPoolSwap.String()), Index: true},
+			{Key: []byte("tx.commission_amount"), Value: []byte(commission.String())},
+			{Key: []byte("tx.commission_details"), Value: []byte(tagsCom.string())},
+			{Key: []byte("tx.treasury_proposal_id"), Value: []byte(strconv.Itoa(int(data.ProposalID))), Index: true},
+		}
+	}
+
+	return Response{
+		Code: code.OK,
+		Tags: tags,
+	}
+}
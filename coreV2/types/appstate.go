@@ -24,7 +24,13 @@ type AppState struct {
 	Commission          Commission         `json:"commission,omitempty"`
 	CommissionVotes     []CommissionVote   `json:"commission_votes,omitempty"`
 	UpdateVotes         []UpdateVote       `json:"update_votes,omitempty"`
+	ParameterVotes      []ParameterVote    `json:"parameter_votes,omitempty"`
+	Parameters          []Parameter        `json:"parameters,omitempty"`
 	UsedChecks          []UsedCheck        `json:"used_checks,omitempty"`
+	RevokedChecks       []RevokedCheck     `json:"revoked_checks,omitempty"`
+	MiningPrograms      []MiningProgram    `json:"mining_programs,omitempty"`
+	TreasuryProposals   []TreasuryProposal `json:"treasury_proposals,omitempty"`
+	Airdrops            []Airdrop          `json:"airdrops,omitempty"`
 	MaxGas              uint64             `json:"max_gas"`
 	TotalSlashed        string             `json:"total_slashed"`
 
@@ -122,6 +128,36 @@ func (s *AppState) Verify() error {
 				}
 			}
 		}
+
+		allowances := map[string]struct{}{}
+		for _, allowance := range acc.Allowances {
+			if !helpers.IsValidBigInt(allowance.Value) {
+				return fmt.Errorf("not valid allowance for account %s", acc.Address.String())
+			}
+
+			// check duplicated allowances
+			coinID := CoinID(allowance.Coin)
+			key := fmt.Sprintf("%s:%s", allowance.Spender.String(), coinID.String())
+			if _, exists := allowances[key]; exists {
+				return fmt.Errorf("duplicated allowance %s of account %s", key, acc.Address.String())
+			}
+			allowances[key] = struct{}{}
+
+			// check not existing coins
+			if !coinID.IsBaseCoin() {
+				foundCoin := false
+				for _, coin := range s.Coins {
+					if CoinID(coin.ID) == coinID {
+						foundCoin = true
+						break
+					}
+				}
+
+				if !foundCoin {
+					return fmt.Errorf("coin %s not found", coinID)
+				}
+			}
+		}
 	}
 
 	for _, candidate := range s.Candidates {
@@ -166,6 +202,10 @@ func (s *AppState) Verify() error {
 
 		coins[coin.ID] = struct{}{}
 
+		if len(coin.Frozen) != 0 && !coin.Freezable {
+			return fmt.Errorf("coin %s is not freezable", coin.Symbol)
+		}
+
 		// check coins' volume
 		volume := big.NewInt(0)
 
@@ -200,9 +240,31 @@ func (s *AppState) Verify() error {
 					}
 				}
 			}
+			for _, order := range swap.StopOrders {
+				if order.CoinToSell == coin.ID {
+					volume.Add(volume, helpers.StringToBigInt(order.ValueToSell))
+				}
+			}
 
 		}
 
+		for _, program := range s.MiningPrograms {
+			if program.RewardCoin == coin.ID {
+				volume.Add(volume, big.NewInt(0).Sub(helpers.StringToBigInt(program.Value), helpers.StringToBigInt(program.Claimed)))
+			}
+			if program.LiquidityCoin == coin.ID {
+				for _, stake := range program.Stakes {
+					volume.Add(volume, helpers.StringToBigInt(stake.Value))
+				}
+			}
+		}
+
+		for _, airdrop := range s.Airdrops {
+			if airdrop.Coin == coin.ID {
+				volume.Add(volume, big.NewInt(0).Sub(helpers.StringToBigInt(airdrop.Value), helpers.StringToBigInt(airdrop.Claimed)))
+			}
+		}
+
 		if coin.Crr == 0 {
 			if volume.Cmp(helpers.StringToBigInt(coin.Volume)) != 0 {
 				return fmt.Errorf("wrong token %s (%d) volume (%s)", coin.Symbol.String(), coin.ID, big.NewInt(0).Sub(volume, helpers.StringToBigInt(coin.Volume)))
@@ -298,6 +360,34 @@ func (s *AppState) Verify() error {
 		}
 	}
 
+	// check revoked checks
+	for _, check := range s.RevokedChecks {
+		if (check.Hash == "") == (check.Nonce == "") {
+			return fmt.Errorf("revoked check of %s should have either hash or nonce", check.Issuer.String())
+		}
+
+		if check.Hash != "" {
+			b, err := hex.DecodeString(check.Hash)
+			if err != nil {
+				return err
+			}
+
+			if len(b) != 32 {
+				return fmt.Errorf("wrong revoked check size %s", check.Hash)
+			}
+			continue
+		}
+
+		b, err := hex.DecodeString(check.Nonce)
+		if err != nil {
+			return err
+		}
+
+		if len(b) > 16 {
+			return fmt.Errorf("wrong revoked check nonce size %s", check.Nonce)
+		}
+	}
+
 	return nil
 }
 
@@ -343,6 +433,8 @@ type Order struct {
 	ID      uint64  `json:"id"`
 	Owner   Address `json:"owner"`
 	Height  uint64  `json:"height"`
+
+	ExpireHeight uint64 `json:"expire_height,omitempty"`
 }
 type Pool struct {
 	Coin0    uint64  `json:"coin0,omitempty"`
@@ -351,20 +443,43 @@ type Pool struct {
 	Reserve1 string  `json:"reserve1"`
 	ID       uint64  `json:"id"`
 	Orders   []Order `json:"orders,omitempty"`
+	SwapFee  uint32  `json:"swap_fee,omitempty"`
+
+	StopOrders []StopOrder `json:"stop_orders,omitempty"`
+}
+
+type StopOrder struct {
+	ID                uint64  `json:"id"`
+	Owner             Address `json:"owner"`
+	CoinToSell        uint64  `json:"coin_to_sell"`
+	ValueToSell       string  `json:"value_to_sell"`
+	CoinToBuy         uint64  `json:"coin_to_buy"`
+	TriggerValue      string  `json:"trigger_value"`
+	MinimumValueToBuy string  `json:"minimum_value_to_buy"`
+	Height            uint64  `json:"height"`
 }
 
 type Coin struct {
-	ID           uint64     `json:"id"`
-	Name         string     `json:"name"`
-	Symbol       CoinSymbol `json:"symbol"`
-	Volume       string     `json:"volume"`
-	Crr          uint64     `json:"crr,omitempty"`
-	Reserve      string     `json:"reserve,omitempty"`
-	MaxSupply    string     `json:"max_supply"`
-	Version      uint64     `json:"version,omitempty"`
-	OwnerAddress *Address   `json:"owner_address,omitempty"`
-	Mintable     bool       `json:"mintable,omitempty"`
-	Burnable     bool       `json:"burnable,omitempty"`
+	ID           uint64        `json:"id"`
+	Name         string        `json:"name"`
+	Symbol       CoinSymbol    `json:"symbol"`
+	Volume       string        `json:"volume"`
+	Crr          uint64        `json:"crr,omitempty"`
+	Reserve      string        `json:"reserve,omitempty"`
+	MaxSupply    string        `json:"max_supply"`
+	Version      uint64        `json:"version,omitempty"`
+	OwnerAddress *Address      `json:"owner_address,omitempty"`
+	Mintable     bool          `json:"mintable,omitempty"`
+	Burnable     bool          `json:"burnable,omitempty"`
+	Metadata     *CoinMetadata `json:"metadata,omitempty"`
+	Freezable    bool          `json:"freezable,omitempty"`
+	Frozen       []Address     `json:"frozen,omitempty"`
+}
+
+type CoinMetadata struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+	LogoHash    Hash   `json:"logo_hash"`
 }
 
 type DeletedCandidate struct {
@@ -384,12 +499,71 @@ type FrozenFund struct {
 
 type UsedCheck string
 
+type RevokedCheck struct {
+	Issuer Address `json:"issuer"`
+	Hash   string  `json:"hash,omitempty"`
+	Nonce  string  `json:"nonce,omitempty"`
+}
+
+type MiningProgram struct {
+	ID             uint64        `json:"id"`
+	Owner          Address       `json:"owner"`
+	LiquidityCoin  uint64        `json:"liquidity_coin"`
+	RewardCoin     uint64        `json:"reward_coin"`
+	Value          string        `json:"value"`
+	StartHeight    uint64        `json:"start_height"`
+	EndHeight      uint64        `json:"end_height"`
+	RewardPerToken string        `json:"reward_per_token"`
+	LastHeight     uint64        `json:"last_height"`
+	Unallocated    string        `json:"unallocated"`
+	Claimed        string        `json:"claimed"`
+	Stakes         []MiningStake `json:"stakes,omitempty"`
+}
+
+type MiningStake struct {
+	Owner              Address `json:"owner"`
+	Value              string  `json:"value"`
+	RewardPerTokenPaid string  `json:"reward_per_token_paid"`
+	Reward             string  `json:"reward"`
+}
+
+type TreasuryProposal struct {
+	ID              uint64    `json:"id"`
+	Proposer        Address   `json:"proposer"`
+	Recipient       Address   `json:"recipient"`
+	Coin            uint64    `json:"coin"`
+	Value           string    `json:"value"`
+	DescriptionHash Hash      `json:"description_hash"`
+	Height          uint64    `json:"height"`
+	Votes           []Address `json:"votes,omitempty"`
+}
+
+// Airdrop is Value of Coin reserved by Owner for recipients of the Merkle tree, Claims are addresses which have claimed their values
+type Airdrop struct {
+	ID           uint64    `json:"id"`
+	Owner        Address   `json:"owner"`
+	Coin         uint64    `json:"coin"`
+	Value        string    `json:"value"`
+	MerkleRoot   Hash      `json:"merkle_root"`
+	ExpireHeight uint64    `json:"expire_height"`
+	Claimed      string    `json:"claimed"`
+	Claims       []Address `json:"claims,omitempty"`
+}
+
 type Account struct {
-	Address             Address   `json:"address"`
-	Balance             []Balance `json:"balance,omitempty"`
-	Nonce               uint64    `json:"nonce"`
-	MultisigData        *Multisig `json:"multisig_data,omitempty"`
-	LockStakeUntilBlock uint64    `json:"lock_stake_until_block,omitempty"`
+	Address             Address     `json:"address"`
+	Balance             []Balance   `json:"balance,omitempty"`
+	Nonce               uint64      `json:"nonce"`
+	MultisigData        *Multisig   `json:"multisig_data,omitempty"`
+	LockStakeUntilBlock uint64      `json:"lock_stake_until_block,omitempty"`
+	Allowances          []Allowance `json:"allowances,omitempty"`
+}
+
+// Allowance is an amount of the coin which the spender is allowed to transfer from the account
+type Allowance struct {
+	Spender Address `json:"spender"`
+	Coin    uint64  `json:"coin"`
+	Value   string  `json:"value"`
 }
 
 type Balance struct {
@@ -419,6 +593,19 @@ type UpdateVote struct {
 	Version string   `json:"version"`
 }
 
+type ParameterVote struct {
+	Height    uint64   `json:"height"`
+	Votes     []Pubkey `json:"votes"`
+	Parameter string   `json:"parameter"`
+	Value     uint64   `json:"value"`
+}
+
+type Parameter struct {
+	Name    string `json:"name"`
+	Value   uint64 `json:"value"`
+	Pending bool   `json:"pending,omitempty"`
+}
+
 type Commission struct {
 	Coin                    uint64 `json:"coin"`
 	PayloadByte             string `json:"payload_byte"`